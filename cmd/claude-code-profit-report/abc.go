@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/config"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/presentation/cli"
+)
+
+func newABCCommand() *cobra.Command {
+	var (
+		abcStart        string
+		abcEnd          string
+		groupBy         string
+		cutoffA         float64
+		cutoffB         float64
+		minMargin       float64
+		abcFormat       string
+		abcNoAllocation bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "abc",
+		Short: "取引先の売上・粗利のABC分析（パレート分析）を表示する",
+		Long: `指定期間の売上・粗利で会社（または会社・倉庫）を順位付けし、累積構成比からA/B/C区分を付けます。
+売上はA区分だが粗利がC区分、または粗利率が基準未満の取引先を「売上上位・低粗利」として表示します。`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			start, end, err := parsePeriod(abcStart, abcEnd)
+			if err != nil {
+				return err
+			}
+			if abcFormat != "text" && abcFormat != "csv" {
+				return fmt.Errorf("unsupported format: %s", abcFormat)
+			}
+
+			return withContainer(cmd, func(ctx context.Context, container *config.Container) error {
+				analysis, err := container.ABCAnalysisUseCase.Analyze(ctx, entity.ABCGroupBy(groupBy), start, end,
+					entity.ABCCutoffs{A: cutoffA, B: cutoffB}, minMargin, !abcNoAllocation)
+				if err != nil {
+					return fmt.Errorf("failed to analyze: %w", err)
+				}
+
+				if abcFormat == "csv" {
+					return cli.WriteABCAnalysisCSV(os.Stdout, analysis)
+				}
+				fmt.Print(cli.NewTextFormatter().FormatABCAnalysis(analysis))
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().StringVarP(&abcStart, "start", "s", "", "開始日 (YYYY-MM-DD) (必須)")
+	cmd.Flags().StringVarP(&abcEnd, "end", "e", "", "終了日 (YYYY-MM-DD) (必須)")
+	cmd.Flags().StringVar(&groupBy, "by", "company", "集計単位 (company / company-warehouse)")
+	cmd.Flags().Float64Var(&cutoffA, "a", 70, "A区分とする累積構成比の上限 (%)")
+	cmd.Flags().Float64Var(&cutoffB, "b", 90, "B区分とする累積構成比の上限 (%)")
+	cmd.Flags().Float64Var(&minMargin, "min-margin", 10, "売上A区分でこの粗利率 (%) 未満を売上上位・低粗利とする")
+	cmd.Flags().StringVar(&abcFormat, "format", "text", "出力形式 (text / csv)")
+	cmd.Flags().BoolVar(&abcNoAllocation, "no-allocation", false, "共通費配賦ルールを適用せず計上どおりのコストで集計する")
+
+	cmd.MarkFlagRequired("start")
+	cmd.MarkFlagRequired("end")
+
+	return cmd
+}
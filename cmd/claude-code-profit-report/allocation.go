@@ -0,0 +1,68 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/config"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/presentation/cli"
+)
+
+func newAllocationCommand() *cobra.Command {
+	allocationCmd := &cobra.Command{
+		Use:   "allocation",
+		Short: "共通費配賦ルールと配賦結果の確認",
+	}
+
+	rulesCmd := &cobra.Command{
+		Use:   "rules",
+		Short: "有効な配賦ルールを表示する",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withContainer(cmd, func(ctx context.Context, container *config.Container) error {
+				rules, err := container.CostAllocationUseCase.GetAllocationRules(ctx)
+				if err != nil {
+					return err
+				}
+				fmt.Print(cli.NewTextFormatter().FormatAllocationRules(rules))
+				return nil
+			})
+		},
+	}
+
+	var (
+		previewWarehouseID uint
+		previewStart       string
+		previewEnd         string
+	)
+	previewCmd := &cobra.Command{
+		Use:   "preview",
+		Short: "期間内の配賦による会社別のコスト調整額を表示する",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			start, end, err := parsePeriod(previewStart, previewEnd)
+			if err != nil {
+				return err
+			}
+
+			return withContainer(cmd, func(ctx context.Context, container *config.Container) error {
+				adjustments, err := container.CostAllocationUseCase.CalculateAdjustments(ctx, previewWarehouseID, start, end)
+				if err != nil {
+					return fmt.Errorf("failed to allocate shared cost: %w", err)
+				}
+				fmt.Printf("期間: %s ~ %s\n\n", start.Format("2006-01-02"), end.Format("2006-01-02"))
+				fmt.Print(cli.NewTextFormatter().FormatAllocationAdjustments(adjustments))
+				return nil
+			})
+		},
+	}
+	previewCmd.Flags().UintVarP(&previewWarehouseID, "warehouse", "w", 0, "倉庫ID (オプション: 未指定時は全倉庫)")
+	previewCmd.Flags().StringVarP(&previewStart, "start", "s", "", "開始日 (YYYY-MM-DD) (必須)")
+	previewCmd.Flags().StringVarP(&previewEnd, "end", "e", "", "終了日 (YYYY-MM-DD) (必須)")
+	previewCmd.MarkFlagRequired("start")
+	previewCmd.MarkFlagRequired("end")
+
+	allocationCmd.AddCommand(rulesCmd, previewCmd)
+	return allocationCmd
+}
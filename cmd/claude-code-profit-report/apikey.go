@@ -0,0 +1,116 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/config"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/presentation/cli"
+)
+
+func newAPIKeyCommand() *cobra.Command {
+	apiKeyCmd := &cobra.Command{
+		Use:   "apikey",
+		Short: "粗利レポートAPI (serve) のAPIキーの発行・一覧・失効と監査ログの表示",
+	}
+
+	var (
+		createName      string
+		createRole      string
+		createCompanies []uint
+	)
+	createCmd := &cobra.Command{
+		Use:   "create",
+		Short: "APIキーを発行する（キーはこのときだけ表示される）",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withContainer(cmd, func(ctx context.Context, container *config.Container) error {
+				key, rawKey, err := container.AccessUseCase.IssueAPIKey(ctx, createName, entity.Role(createRole), createCompanies)
+				if err != nil {
+					return err
+				}
+				// キーは標準出力にのみ出し、案内は標準エラーに出す（パイプでそのまま保管先に渡せるように）
+				fmt.Fprintf(os.Stderr, "APIキーを発行しました (ID: %d, 利用者: %s, 権限: %s)\n", key.ID, key.Name, key.Role)
+				fmt.Fprintln(os.Stderr, "キーは再表示できません。安全な場所に保管してください。")
+				fmt.Println(rawKey)
+				return nil
+			})
+		},
+	}
+	createCmd.Flags().StringVar(&createName, "name", "", "利用者名 (必須)")
+	createCmd.Flags().StringVar(&createRole, "role", string(entity.RoleManager), "権限 (admin: 全社 / manager: --company で指定した会社のみ / ingest: --company で指定した会社の日次レポートの登録のみ)")
+	createCmd.Flags().UintSliceVarP(&createCompanies, "company", "c", nil, "参照・登録を許可する会社ID (manager・ingest の場合は必須、複数指定可)")
+	createCmd.MarkFlagRequired("name")
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "発行済みのAPIキーを表示する",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withContainer(cmd, func(ctx context.Context, container *config.Container) error {
+				keys, err := container.AccessUseCase.ListAPIKeys(ctx)
+				if err != nil {
+					return err
+				}
+				fmt.Print(cli.NewTextFormatter().FormatAPIKeys(keys))
+				return nil
+			})
+		},
+	}
+
+	revokeCmd := &cobra.Command{
+		Use:   "revoke <APIキーID>",
+		Short: "APIキーを失効させる",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil || id == 0 {
+				return fmt.Errorf("invalid api key id: %s", args[0])
+			}
+
+			return withContainer(cmd, func(ctx context.Context, container *config.Container) error {
+				if err := container.AccessUseCase.RevokeAPIKey(ctx, id); err != nil {
+					return err
+				}
+				fmt.Printf("APIキーを失効させました (ID: %d)\n", id)
+				return nil
+			})
+		},
+	}
+
+	var (
+		auditAPIKeyID uint64
+		auditCompany  uint
+		auditLimit    int
+	)
+	auditCmd := &cobra.Command{
+		Use:   "audit",
+		Short: "APIの監査ログ（誰がどの会社・期間を参照したか）を新しい順に表示する",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withContainer(cmd, func(ctx context.Context, container *config.Container) error {
+				logs, err := container.AccessUseCase.ListAuditLogs(ctx, repository.AuditLogFilter{
+					APIKeyID:  auditAPIKeyID,
+					CompanyID: auditCompany,
+					Limit:     auditLimit,
+				})
+				if err != nil {
+					return err
+				}
+				fmt.Print(cli.NewTextFormatter().FormatAuditLogs(logs))
+				return nil
+			})
+		},
+	}
+	auditCmd.Flags().Uint64Var(&auditAPIKeyID, "key", 0, "APIキーIDで絞り込む")
+	auditCmd.Flags().UintVarP(&auditCompany, "company", "c", 0, "会社IDで絞り込む")
+	auditCmd.Flags().IntVarP(&auditLimit, "limit", "n", 50, "表示件数 (0: 全件)")
+
+	apiKeyCmd.AddCommand(createCmd, listCmd, revokeCmd, auditCmd)
+	return apiKeyCmd
+}
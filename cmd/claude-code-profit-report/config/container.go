@@ -5,29 +5,97 @@ import (
 
 	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
 	infraRepo "github.com/taka512/golang/cmd/claude-code-profit-report/infrastructure/repository"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/infrastructure/tracing"
 	"github.com/taka512/golang/cmd/claude-code-profit-report/usecase"
 )
 
 type Container struct {
-	DB                  *sql.DB
-	SalesRepository     repository.SalesRepository
-	CostRepository      repository.CostRepository
-	CompanyRepository   repository.CompanyRepository
-	ProfitReportUseCase usecase.ProfitReportUseCase
+	DB                       *sql.DB
+	SalesRepository          repository.SalesRepository
+	CostRepository           repository.CostRepository
+	CompanyRepository        repository.CompanyRepository
+	ReportRunRepository      repository.ReportRunRepository
+	CorrectionRepository     repository.CorrectionRepository
+	ValidationRepository     repository.ValidationRepository
+	TaxRepository            repository.TaxRepository
+	CostAllocationRepository repository.CostAllocationRepository
+	APIKeyRepository         repository.APIKeyRepository
+	AuditLogRepository       repository.AuditLogRepository
+	MasterRepository         repository.MasterRepository
+	ContractRepository       repository.ContractRepository
+	ImportRepository         repository.ImportRepository
+	ImportBatchRepository    repository.ImportBatchRepository
+	ProfitReportUseCase      usecase.ProfitReportUseCase
+	ReportRunUseCase         usecase.ReportRunUseCase
+	CorrectionUseCase        usecase.CorrectionUseCase
+	ValidationUseCase        usecase.ValidationUseCase
+	TaxUseCase               usecase.TaxUseCase
+	CostAllocationUseCase    usecase.CostAllocationUseCase
+	ABCAnalysisUseCase       usecase.ABCAnalysisUseCase
+	KPIUseCase               usecase.KPIUseCase
+	AccessUseCase            usecase.AccessUseCase
+	MasterUseCase            usecase.MasterUseCase
+	ContractUseCase          usecase.ContractUseCase
+	ImportUseCase            usecase.ImportUseCase
 }
 
+// NewContainer リポジトリはスパンの計測とクエリのタイムアウトを適用するラッパー越しに各ユースケースへ渡す
 func NewContainer(db *sql.DB) *Container {
-	salesRepo := infraRepo.NewSalesRepository(db)
-	costRepo := infraRepo.NewCostRepository(db)
-	companyRepo := infraRepo.NewCompanyRepository(db)
-	
+	salesRepo := tracing.WrapSalesRepository(infraRepo.NewSalesRepository(db))
+	costRepo := tracing.WrapCostRepository(infraRepo.NewCostRepository(db))
+	companyRepo := tracing.WrapCompanyRepository(infraRepo.NewCompanyRepository(db))
+	reportRunRepo := tracing.WrapReportRunRepository(infraRepo.NewReportRunRepository(db))
+	correctionRepo := tracing.WrapCorrectionRepository(infraRepo.NewCorrectionRepository(db))
+	validationRepo := tracing.WrapValidationRepository(infraRepo.NewValidationRepository(db))
+	taxRepo := tracing.WrapTaxRepository(infraRepo.NewTaxRepository(db))
+	costAllocationRepo := tracing.WrapCostAllocationRepository(infraRepo.NewCostAllocationRepository(db))
+	apiKeyRepo := tracing.WrapAPIKeyRepository(infraRepo.NewAPIKeyRepository(db))
+	auditLogRepo := tracing.WrapAuditLogRepository(infraRepo.NewAuditLogRepository(db))
+	masterRepo := tracing.WrapMasterRepository(infraRepo.NewMasterRepository(db))
+	contractRepo := tracing.WrapContractRepository(infraRepo.NewContractRepository(db))
+	importRepo := tracing.WrapImportRepository(infraRepo.NewImportRepository(db))
+	importBatchRepo := tracing.WrapImportBatchRepository(infraRepo.NewImportBatchRepository(db))
+
 	profitReportUseCase := usecase.NewProfitReportUseCase(salesRepo, costRepo, companyRepo)
+	costAllocationUseCase := usecase.NewCostAllocationUseCase(costAllocationRepo, salesRepo, costRepo)
+	abcAnalysisUseCase := usecase.NewABCAnalysisUseCase(salesRepo, costRepo, companyRepo, contractRepo, costAllocationUseCase)
+	kpiUseCase := usecase.NewKPIUseCase(salesRepo, costRepo, costAllocationUseCase)
+	reportRunUseCase := usecase.NewReportRunUseCase(reportRunRepo, correctionRepo, profitReportUseCase, costAllocationUseCase)
+	correctionUseCase := usecase.NewCorrectionUseCase(correctionRepo)
+	validationUseCase := usecase.NewValidationUseCase(validationRepo)
+	taxUseCase := usecase.NewTaxUseCase(taxRepo)
+	accessUseCase := usecase.NewAccessUseCase(apiKeyRepo, auditLogRepo, companyRepo)
+	masterUseCase := usecase.NewMasterUseCase(masterRepo)
+	contractUseCase := usecase.NewContractUseCase(contractRepo, companyRepo)
+	importUseCase := usecase.NewImportUseCase(importRepo, importBatchRepo, masterRepo, contractRepo)
 
 	return &Container{
-		DB:                  db,
-		SalesRepository:     salesRepo,
-		CostRepository:      costRepo,
-		CompanyRepository:   companyRepo,
-		ProfitReportUseCase: profitReportUseCase,
+		DB:                       db,
+		SalesRepository:          salesRepo,
+		CostRepository:           costRepo,
+		CompanyRepository:        companyRepo,
+		ReportRunRepository:      reportRunRepo,
+		CorrectionRepository:     correctionRepo,
+		ValidationRepository:     validationRepo,
+		TaxRepository:            taxRepo,
+		CostAllocationRepository: costAllocationRepo,
+		APIKeyRepository:         apiKeyRepo,
+		AuditLogRepository:       auditLogRepo,
+		MasterRepository:         masterRepo,
+		ContractRepository:       contractRepo,
+		ImportRepository:         importRepo,
+		ImportBatchRepository:    importBatchRepo,
+		ProfitReportUseCase:      profitReportUseCase,
+		ReportRunUseCase:         reportRunUseCase,
+		CorrectionUseCase:        correctionUseCase,
+		ValidationUseCase:        validationUseCase,
+		TaxUseCase:               taxUseCase,
+		CostAllocationUseCase:    costAllocationUseCase,
+		ABCAnalysisUseCase:       abcAnalysisUseCase,
+		KPIUseCase:               kpiUseCase,
+		AccessUseCase:            accessUseCase,
+		MasterUseCase:            masterUseCase,
+		ContractUseCase:          contractUseCase,
+		ImportUseCase:            importUseCase,
 	}
-}
\ No newline at end of file
+}
@@ -0,0 +1,139 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/config"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/presentation/cli"
+)
+
+func newContractCommand() *cobra.Command {
+	contractCmd := &cobra.Command{
+		Use:   "contract",
+		Short: "会社と倉庫の契約の表示・登録・終了",
+		Long: `会社と倉庫の契約（契約開始日〜終了日）を管理します。
+会社・倉庫別の集計（abc --by company-warehouse など）は、期間内に契約のある組み合わせのみを対象にします。`,
+	}
+
+	var (
+		listCompany   uint
+		listWarehouse uint
+		listStart     string
+		listEnd       string
+	)
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "契約を会社・倉庫・開始日順に表示する",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filter := repository.ContractFilter{CompanyID: listCompany, WarehouseID: listWarehouse}
+			if listStart != "" || listEnd != "" {
+				start, end, err := parsePeriod(listStart, listEnd)
+				if err != nil {
+					return err
+				}
+				filter.StartDate = &start
+				filter.EndDate = &end
+			}
+
+			return withContainer(cmd, func(ctx context.Context, container *config.Container) error {
+				contracts, err := container.ContractUseCase.ListContracts(ctx, filter)
+				if err != nil {
+					return err
+				}
+				fmt.Print(cli.NewTextFormatter().FormatContracts(contracts))
+				return nil
+			})
+		},
+	}
+	listCmd.Flags().UintVarP(&listCompany, "company", "c", 0, "会社IDで絞り込む")
+	listCmd.Flags().UintVarP(&listWarehouse, "warehouse", "w", 0, "倉庫IDで絞り込む")
+	listCmd.Flags().StringVarP(&listStart, "start", "s", "", "契約期間が重なる期間の開始日 (YYYY-MM-DD、--end と同時に指定)")
+	listCmd.Flags().StringVarP(&listEnd, "end", "e", "", "契約期間が重なる期間の終了日 (YYYY-MM-DD、--start と同時に指定)")
+
+	var (
+		addCompany   uint
+		addWarehouse uint
+		addStart     string
+		addEnd       string
+	)
+	addCmd := &cobra.Command{
+		Use:   "add",
+		Short: "契約を登録する（同じ会社・倉庫の契約と期間が重なる場合はエラー）",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			start, err := parseContractDate(addStart)
+			if err != nil {
+				return fmt.Errorf("invalid start date format: %w", err)
+			}
+			var end *time.Time
+			if addEnd != "" {
+				date, err := parseContractDate(addEnd)
+				if err != nil {
+					return fmt.Errorf("invalid end date format: %w", err)
+				}
+				end = &date
+			}
+
+			return withContainer(cmd, func(ctx context.Context, container *config.Container) error {
+				contract, err := container.ContractUseCase.AddContract(ctx, addCompany, addWarehouse, start, end)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("契約を登録しました (ID: %d)\n", contract.ID)
+				fmt.Print(cli.NewTextFormatter().FormatContracts([]entity.Contract{*contract}))
+				return nil
+			})
+		},
+	}
+	addCmd.Flags().UintVarP(&addCompany, "company", "c", 0, "会社ID (必須)")
+	addCmd.Flags().UintVarP(&addWarehouse, "warehouse", "w", 0, "倉庫ID (必須)")
+	addCmd.Flags().StringVarP(&addStart, "start", "s", "", "契約開始日 (YYYY-MM-DD) (必須)")
+	addCmd.Flags().StringVarP(&addEnd, "end", "e", "", "契約終了日 (YYYY-MM-DD、省略時は終了日なし)")
+	addCmd.MarkFlagRequired("company")
+	addCmd.MarkFlagRequired("warehouse")
+	addCmd.MarkFlagRequired("start")
+
+	var endDate string
+	endCmd := &cobra.Command{
+		Use:   "end <契約ID>",
+		Short: "契約終了日を設定する（終了日の翌日以降は集計の対象外になる）",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil || id == 0 {
+				return fmt.Errorf("invalid contract id: %s", args[0])
+			}
+			end, err := parseContractDate(endDate)
+			if err != nil {
+				return fmt.Errorf("invalid end date format: %w", err)
+			}
+
+			return withContainer(cmd, func(ctx context.Context, container *config.Container) error {
+				contract, err := container.ContractUseCase.EndContract(ctx, id, end)
+				if err != nil {
+					return err
+				}
+				fmt.Printf("契約終了日を設定しました (ID: %d)\n", contract.ID)
+				fmt.Print(cli.NewTextFormatter().FormatContracts([]entity.Contract{*contract}))
+				return nil
+			})
+		},
+	}
+	endCmd.Flags().StringVarP(&endDate, "end", "e", "", "契約終了日 (YYYY-MM-DD) (必須)")
+	endCmd.MarkFlagRequired("end")
+
+	contractCmd.AddCommand(listCmd, addCmd, endCmd)
+	return contractCmd
+}
+
+// parseContractDate リポジトリから読み込んだ契約と比較できるようローカルタイムゾーンの日付として解釈する
+func parseContractDate(value string) (time.Time, error) {
+	return time.ParseInLocation("2006-01-02", value, time.Local)
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/config"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/presentation/cli"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/presentation/slack"
+)
+
+func newCorrectionsCommand() *cobra.Command {
+	var (
+		since       string
+		notifySlack bool
+		dryRun      bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "corrections",
+		Short: "報告済み期間のデータ修正を検出し、訂正を通知する",
+		Long: `前回チェック以降に updated_at が更新された日次レポート・明細のうち、報告済みのレポート期間に含まれるものを
+会社・倉庫・日別に集計し直し、報告時の値と異なるものを訂正として表示・通知します。`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var sinceTime *time.Time
+			if since != "" {
+				t, err := parseSince(since)
+				if err != nil {
+					return err
+				}
+				sinceTime = &t
+			}
+
+			var slackClient *slack.Client
+			if notifySlack {
+				webhookURL := os.Getenv("SLACK_HOOK")
+				if webhookURL == "" {
+					return fmt.Errorf("SLACK_HOOK environment variable is not set")
+				}
+				slackClient = slack.NewClient(webhookURL)
+			}
+
+			return withContainer(cmd, func(ctx context.Context, container *config.Container) error {
+				report, err := container.CorrectionUseCase.DetectCorrections(ctx, sinceTime)
+				if err != nil {
+					return err
+				}
+				fmt.Print(cli.NewTextFormatter().FormatCorrections(report))
+				markDone("訂正検出")
+
+				if slackClient != nil && len(report.Corrections) > 0 {
+					if err := slackClient.SendCorrectionNotice(ctx, report); err != nil {
+						return fmt.Errorf("failed to send to slack: %w", err)
+					}
+					fmt.Println("\nSlackに訂正を送信しました。")
+					markDone("Slack送信")
+				}
+
+				if dryRun {
+					return nil
+				}
+				// 通知できた場合のみチェック済みにする（失敗時は次回再検出される）
+				return container.CorrectionUseCase.ConfirmCorrections(ctx, report)
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&since, "since", "", "この日時より後の更新を対象にする (YYYY-MM-DD または YYYY-MM-DD HH:MM:SS、未指定時: 前回チェック日時)")
+	cmd.Flags().BoolVar(&notifySlack, "slack", false, "修正があればSlackに訂正を送信する")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "チェック日時と報告済みの値を更新しない")
+
+	return cmd
+}
+
+func parseSince(value string) (time.Time, error) {
+	for _, layout := range []string{"2006-01-02 15:04:05", "2006-01-02"} {
+		if t, err := time.ParseInLocation(layout, value, time.Local); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid since format: %s", value)
+}
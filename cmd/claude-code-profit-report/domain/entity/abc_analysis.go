@@ -0,0 +1,153 @@
+package entity
+
+import (
+	"sort"
+	"time"
+)
+
+// ABC分析の集計単位
+type ABCGroupBy string
+
+const (
+	ABCByCompany          ABCGroupBy = "company"
+	ABCByCompanyWarehouse ABCGroupBy = "company-warehouse"
+)
+
+type ABCClass string
+
+const (
+	ABCClassA ABCClass = "A"
+	ABCClassB ABCClass = "B"
+	ABCClassC ABCClass = "C"
+)
+
+// 累積構成比 (%) の区分境界（A以下: A、B以下: B、それ以外: C）
+type ABCCutoffs struct {
+	A float64
+	B float64
+}
+
+// 指標（売上または粗利）ごとの順位・構成比・区分
+type ABCRank struct {
+	Rank int
+	// 構成比・累積構成比 (%)
+	Share           float64
+	CumulativeShare float64
+	Class           ABCClass
+}
+
+// 会社（または会社・倉庫）単位の集計と分析結果
+type ABCItem struct {
+	CompanyID     uint
+	CompanyName   string
+	WarehouseID   uint
+	WarehouseName string
+	Sales         float64
+	Cost          float64
+	SalesRank     ABCRank
+	ProfitRank    ABCRank
+	// 売上はA区分だが、粗利がC区分または粗利率が基準未満
+	HighSalesLowProfit bool
+}
+
+func (i *ABCItem) GrossProfit() float64 {
+	return i.Sales - i.Cost
+}
+
+func (i *ABCItem) GrossProfitRate() float64 {
+	if i.Sales > 0 {
+		return i.GrossProfit() / i.Sales * 100
+	}
+	return 0
+}
+
+type ABCAnalysis struct {
+	GroupBy   ABCGroupBy
+	StartDate time.Time
+	EndDate   time.Time
+	Cutoffs   ABCCutoffs
+	// 売上・粗利の区分に関わらず注意対象とする粗利率 (%)
+	MinMargin     float64
+	CostAllocated bool
+	// 売上順
+	Items []ABCItem
+}
+
+func (a *ABCAnalysis) TotalSales() float64 {
+	var total float64
+	for _, item := range a.Items {
+		total += item.Sales
+	}
+	return total
+}
+
+func (a *ABCAnalysis) TotalGrossProfit() float64 {
+	var total float64
+	for _, item := range a.Items {
+		total += item.GrossProfit()
+	}
+	return total
+}
+
+// Classify 売上・粗利それぞれで順位・累積構成比・区分を付け、売上順に並べる
+func (a *ABCAnalysis) Classify() {
+	rankBy(a.Items, a.Cutoffs, func(item *ABCItem) float64 { return item.GrossProfit() }, func(item *ABCItem) *ABCRank { return &item.ProfitRank })
+	rankBy(a.Items, a.Cutoffs, func(item *ABCItem) float64 { return item.Sales }, func(item *ABCItem) *ABCRank { return &item.SalesRank })
+
+	for i := range a.Items {
+		item := &a.Items[i]
+		item.HighSalesLowProfit = item.SalesRank.Class == ABCClassA &&
+			(item.ProfitRank.Class == ABCClassC || item.GrossProfitRate() < a.MinMargin)
+	}
+}
+
+// rankBy value の降順に並べて rank を設定する
+// 構成比は正の値の合計に対する割合で、0以下の値は累積に含めずC区分とする
+func rankBy(items []ABCItem, cutoffs ABCCutoffs, value func(*ABCItem) float64, rank func(*ABCItem) *ABCRank) {
+	sort.SliceStable(items, func(i, j int) bool {
+		vi, vj := value(&items[i]), value(&items[j])
+		if vi != vj {
+			return vi > vj
+		}
+		if items[i].CompanyID != items[j].CompanyID {
+			return items[i].CompanyID < items[j].CompanyID
+		}
+		return items[i].WarehouseID < items[j].WarehouseID
+	})
+
+	var total float64
+	for i := range items {
+		if v := value(&items[i]); v > 0 {
+			total += v
+		}
+	}
+
+	var cumulative float64
+	for i := range items {
+		item := &items[i]
+		r := rank(item)
+		v := value(item)
+		r.Rank = i + 1
+		if total > 0 {
+			r.Share = v / total * 100
+		}
+
+		// 境界をまたぐ項目は上位の区分に含める
+		previous := cumulative
+		if v > 0 {
+			cumulative += r.Share
+		}
+		r.CumulativeShare = cumulative
+
+		switch {
+		case v <= 0:
+			r.Class = ABCClassC
+		case previous < cutoffs.A:
+			r.Class = ABCClassA
+		case previous < cutoffs.B:
+			r.Class = ABCClassB
+		default:
+			r.Class = ABCClassC
+		}
+	}
+}
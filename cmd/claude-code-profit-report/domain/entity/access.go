@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// APIの利用権限
+type Role string
+
+const (
+	// 全社のデータを参照できる
+	RoleAdmin Role = "admin"
+	// 割り当てられた会社のデータのみ参照できる
+	RoleManager Role = "manager"
+	// 割り当てられた会社の日次レポートの登録のみできる（倉庫管理システムなどの連携用、参照は不可）
+	RoleIngest Role = "ingest"
+)
+
+var (
+	// ErrUnauthenticated APIキーがない・無効・失効済み
+	ErrUnauthenticated = errors.New("valid api key is required")
+	// ErrForbidden 参照が許可されていない会社のデータを要求した
+	ErrForbidden = errors.New("access to the requested company is not allowed")
+)
+
+// 認証済みのAPI利用者（APIキー単位）
+type Principal struct {
+	APIKeyID   uint64
+	Name       string
+	Role       Role
+	CompanyIDs []uint
+}
+
+func (p *Principal) IsAdmin() bool {
+	return p.Role == RoleAdmin
+}
+
+// CanRead レポート・マスタを参照できるか（ingest 権限のキーは参照できない）
+func (p *Principal) CanRead() bool {
+	return p.Role == RoleAdmin || p.Role == RoleManager
+}
+
+// CanIngest 日次レポートを登録できるか（対象の会社はリポジトリで確認する）
+func (p *Principal) CanIngest() bool {
+	return p.Role == RoleAdmin || p.Role == RoleIngest
+}
+
+// CanAccessCompany companyID が 0（全社）の場合は管理者のみ許可する
+func (p *Principal) CanAccessCompany(companyID uint) bool {
+	if p.IsAdmin() {
+		return true
+	}
+	for _, id := range p.CompanyIDs {
+		if companyID > 0 && id == companyID {
+			return true
+		}
+	}
+	return false
+}
+
+type principalContextKey struct{}
+
+// WithPrincipal 以降のリポジトリの問い合わせを principal の参照範囲に制限する
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalContextKey{}, principal)
+}
+
+// PrincipalFromContext 利用者が設定されていない場合（CLIからの実行）は false を返す
+func PrincipalFromContext(ctx context.Context) (*Principal, bool) {
+	principal, ok := ctx.Value(principalContextKey{}).(*Principal)
+	return principal, ok && principal != nil
+}
+
+// 発行済みのAPIキー（キー自体は発行時にのみ表示し、ハッシュのみ保存する）
+type APIKey struct {
+	ID         uint64
+	Name       string
+	Role       Role
+	CompanyIDs []uint
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+}
+
+func (k *APIKey) Principal() *Principal {
+	return &Principal{
+		APIKeyID:   k.ID,
+		Name:       k.Name,
+		Role:       k.Role,
+		CompanyIDs: k.CompanyIDs,
+	}
+}
+
+// APIへのリクエストの記録
+type AuditLog struct {
+	ID uint64
+	// 認証に失敗した場合は 0
+	APIKeyID      uint64
+	PrincipalName string
+	Method        string
+	Path          string
+	Query         string
+	// 0 の場合は全社・指定なし
+	CompanyID  uint
+	Status     int
+	RemoteAddr string
+	CreatedAt  time.Time
+}
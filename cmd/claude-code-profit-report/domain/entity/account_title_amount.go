@@ -0,0 +1,34 @@
+package entity
+
+import (
+	"context"
+	"time"
+)
+
+// 会社・倉庫・日付・勘定科目単位の金額
+type AccountTitleAmount struct {
+	CompanyID        uint
+	CompanyName      string
+	WarehouseID      uint
+	WarehouseName    string
+	Date             time.Time
+	AccountTitleID   uint
+	AccountTitleCode string
+	AccountTitleName string
+	// 無効化された勘定科目か（WithDisabledAccountTitles の場合のみ true になり得る）
+	AccountTitleDisabled bool
+	Amount               float64
+}
+
+type disabledAccountTitlesKey struct{}
+
+// WithDisabledAccountTitles 無効化された勘定科目の日報も集計に含める（既定では除外する）
+func WithDisabledAccountTitles(ctx context.Context) context.Context {
+	return context.WithValue(ctx, disabledAccountTitlesKey{}, true)
+}
+
+// IncludesDisabledAccountTitles 無効化された勘定科目の日報を集計に含めるか
+func IncludesDisabledAccountTitles(ctx context.Context) bool {
+	included, _ := ctx.Value(disabledAccountTitlesKey{}).(bool)
+	return included
+}
@@ -0,0 +1,39 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+// 会社と倉庫の契約（契約期間内の組み合わせのみ集計の対象とする）
+type Contract struct {
+	ID            uint64
+	CompanyID     uint
+	CompanyName   string
+	WarehouseID   uint
+	WarehouseName string
+	StartDate     time.Time
+	// nil の場合は終了日なし
+	EndDate   *time.Time
+	CreatedAt time.Time
+}
+
+// Validate 契約開始日が終了日より後でないかを検証する
+func (c *Contract) Validate() error {
+	if c.CompanyID == 0 || c.WarehouseID == 0 {
+		return fmt.Errorf("company and warehouse are required")
+	}
+	if c.EndDate != nil && c.EndDate.Before(c.StartDate) {
+		return fmt.Errorf("contract end date %s is before start date %s",
+			c.EndDate.Format("2006-01-02"), c.StartDate.Format("2006-01-02"))
+	}
+	return nil
+}
+
+// Overlaps 契約期間が startDate〜endDate と1日でも重なるか
+func (c *Contract) Overlaps(startDate, endDate time.Time) bool {
+	if c.StartDate.After(endDate) {
+		return false
+	}
+	return c.EndDate == nil || !c.EndDate.Before(startDate)
+}
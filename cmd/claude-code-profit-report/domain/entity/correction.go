@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"math"
+	"time"
+)
+
+// 会社・倉庫・日付単位の売上・コスト合計
+type DailyTotal struct {
+	CompanyID     uint
+	CompanyName   string
+	WarehouseID   uint
+	WarehouseName string
+	Date          time.Time
+	Sales         float64
+	Cost          float64
+}
+
+func (t DailyTotal) GrossProfit() float64 {
+	return t.Sales - t.Cost
+}
+
+// 報告済みの値から変更された会社・倉庫・日付の合計
+type Correction struct {
+	Current DailyTotal
+	// 報告時の値（OldKnown が false の場合は報告時の値が記録されていない）
+	OldSales float64
+	OldCost  float64
+	OldKnown bool
+}
+
+func (c Correction) OldGrossProfit() float64 {
+	return c.OldSales - c.OldCost
+}
+
+func (c Correction) SalesDelta() float64 {
+	return c.Current.Sales - c.OldSales
+}
+
+func (c Correction) CostDelta() float64 {
+	return c.Current.Cost - c.OldCost
+}
+
+func (c Correction) GrossProfitDelta() float64 {
+	return c.Current.GrossProfit() - c.OldGrossProfit()
+}
+
+func (c Correction) Changed() bool {
+	if !c.OldKnown {
+		return true
+	}
+	return math.Abs(c.SalesDelta()) > amountTolerance || math.Abs(c.CostDelta()) > amountTolerance
+}
+
+type CorrectionReport struct {
+	// Since より後に更新されたデータを対象とする
+	Since       time.Time
+	CheckedAt   time.Time
+	Corrections []Correction
+}
+
+func (r *CorrectionReport) TotalSalesDelta() float64 {
+	var total float64
+	for _, c := range r.Corrections {
+		total += c.SalesDelta()
+	}
+	return total
+}
+
+func (r *CorrectionReport) TotalCostDelta() float64 {
+	var total float64
+	for _, c := range r.Corrections {
+		total += c.CostDelta()
+	}
+	return total
+}
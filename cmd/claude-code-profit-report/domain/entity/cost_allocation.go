@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"time"
+)
+
+// 配賦方法
+type AllocationMethod string
+
+const (
+	// 出荷科目の数量比で配賦する
+	AllocationByShippedQuantity AllocationMethod = "shipped_quantity"
+	// 売上金額比で配賦する
+	AllocationBySales AllocationMethod = "sales"
+	// 会社ごとの固定比率で配賦する
+	AllocationFixed AllocationMethod = "fixed"
+)
+
+// 固定比率の配賦先
+type AllocationShare struct {
+	CompanyID   uint
+	CompanyName string
+	// 配賦比率 (%)
+	Percentage float64
+}
+
+// 倉庫・原価科目単位の配賦ルール
+// 倉庫の該当科目のコストは計上した会社にかかわらず合算し、配賦方法に従って各社に振り分ける
+type AllocationRule struct {
+	ID                   uint
+	WarehouseID          uint
+	WarehouseName        string
+	CostAccountTitleID   uint
+	CostAccountTitleCode string
+	CostAccountTitleName string
+	Method               AllocationMethod
+	// 固定比率の場合のみ設定
+	Shares []AllocationShare
+}
+
+// 会社・日付単位の数量
+type CompanyQuantity struct {
+	CompanyID   uint
+	CompanyName string
+	Date        time.Time
+	Quantity    float64
+}
+
+// Allocate pool を weights の比率で按分する（weights の合計が0の場合は nil）
+func Allocate(pool float64, weights map[uint]float64) map[uint]float64 {
+	var total float64
+	for _, weight := range weights {
+		total += weight
+	}
+	if total == 0 {
+		return nil
+	}
+
+	allocated := make(map[uint]float64, len(weights))
+	for id, weight := range weights {
+		allocated[id] = pool * weight / total
+	}
+	return allocated
+}
@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+)
+
+func ParseReportKind(value string) (ReportKind, error) {
+	switch ReportKind(value) {
+	case ReportKindSales, ReportKindCost:
+		return ReportKind(value), nil
+	}
+	return "", fmt.Errorf("unknown report kind: %s (%s / %s)", value, ReportKindSales, ReportKindCost)
+}
+
+// TitleMasterKind 日次レポートの科目のマスタ種別
+func (k ReportKind) TitleMasterKind() MasterKind {
+	if k == ReportKindCost {
+		return MasterCostAccountTitle
+	}
+	return MasterSalesAccountTitle
+}
+
+// 取り込む日次レポートの明細1行（CSVの1行、APIの明細1件）
+type DailyReportRow struct {
+	Line          int
+	CompanyCode   string
+	WarehouseCode string
+	Date          time.Time
+	TitleCode     string
+	Size          string
+	Quantity      int
+	Price         float64
+	Amount        float64
+	// 読み込み時のエラー（設定されている場合は取り込まない）
+	ParseError string
+}
+
+// 会社・倉庫・日・科目単位で取り込むレポート（既存のレポートの明細はすべて置き換える）
+type DailyReportImport struct {
+	CompanyID      uint
+	WarehouseID    uint
+	Date           time.Time
+	AccountTitleID uint
+	Rows           []DailyReportRow
+	// 反映後に設定する
+	ReportID uint64
+	Updated  bool
+}
+
+// 取り込まなかった行と理由
+type ImportRejection struct {
+	Line   int
+	Reason string
+	// 同じレポートの他の行のエラーで除外した場合はその行（行自体にエラーがある場合は 0）
+	CausedBy int
+}
+
+// 1ファイル（1リクエスト）分の取り込み結果
+type ImportResult struct {
+	Kind     ReportKind
+	DryRun   bool
+	Reports  []DailyReportImport
+	Rejected []ImportRejection
+	// 取り込んだレポートを記録したバッチ（取り込んだレポートがない場合・dry-run の場合は 0）
+	BatchID uint64
+}
+
+// Inserted 新規に作成したレポート数
+func (r *ImportResult) Inserted() int {
+	count := 0
+	for _, report := range r.Reports {
+		if !report.Updated {
+			count++
+		}
+	}
+	return count
+}
+
+// Updated 明細を置き換えた既存のレポート数
+func (r *ImportResult) Updated() int {
+	return len(r.Reports) - r.Inserted()
+}
+
+// ImportedRows 取り込んだ明細の行数
+func (r *ImportResult) ImportedRows() int {
+	count := 0
+	for _, report := range r.Reports {
+		count += len(report.Rows)
+	}
+	return count
+}
@@ -0,0 +1,33 @@
+package entity
+
+import "time"
+
+// 日次レポートの取り込みバッチ（CSV 1ファイル・APIの1リクエスト・data-insert の1回の実行）
+// バッチが書き込んだレポートと明細には import_batch_id を記録し、置き換えた明細はロールバック用に保存する
+type ImportBatch struct {
+	ID     uint64
+	Source string
+	// APIキーの利用者名（CLIからの実行は空）
+	CreatedBy    string
+	ReportCount  int
+	ItemCount    int
+	RolledBackAt *time.Time
+	CreatedAt    time.Time
+}
+
+func (b *ImportBatch) RolledBack() bool {
+	return b.RolledBackAt != nil
+}
+
+// バッチのロールバック結果
+type ImportBatchRollback struct {
+	BatchID uint64
+	DryRun  bool
+	// バッチで作成したため削除したレポート
+	DeletedReports int
+	// バッチが置き換える前の明細に戻したレポート
+	RestoredReports int
+	// 削除したバッチの明細・復元した置き換え前の明細
+	DeletedItems  int
+	RestoredItems int
+}
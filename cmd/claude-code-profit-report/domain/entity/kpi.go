@@ -0,0 +1,33 @@
+package entity
+
+import (
+	"time"
+)
+
+// KPIを集計する期間
+type KPIPeriod struct {
+	// メトリクスのラベル値 (today / yesterday / month_to_date)
+	Name      string
+	StartDate time.Time
+	EndDate   time.Time
+}
+
+// KPIPeriods now を基準に当日・前日・当月累計の期間を返す
+func KPIPeriods(now time.Time) []KPIPeriod {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	yesterday := today.AddDate(0, 0, -1)
+	monthStart := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.Local)
+
+	return []KPIPeriod{
+		{Name: "today", StartDate: today, EndDate: today},
+		{Name: "yesterday", StartDate: yesterday, EndDate: yesterday},
+		{Name: "month_to_date", StartDate: monthStart, EndDate: today},
+	}
+}
+
+// 期間内の会社・倉庫・日付・勘定科目別の売上・コスト
+type KPISnapshot struct {
+	Period KPIPeriod
+	Sales  []AccountTitleAmount
+	Cost   []AccountTitleAmount
+}
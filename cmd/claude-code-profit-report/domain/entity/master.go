@@ -0,0 +1,156 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// マスタの種別
+type MasterKind string
+
+const (
+	MasterCompany           MasterKind = "company"
+	MasterWarehouse         MasterKind = "warehouse"
+	MasterSalesAccountTitle MasterKind = "sales-title"
+	MasterCostAccountTitle  MasterKind = "cost-title"
+)
+
+// 表示・指定順
+var MasterKinds = []MasterKind{MasterCompany, MasterWarehouse, MasterSalesAccountTitle, MasterCostAccountTitle}
+
+// コードの最大文字数（全マスタ共通）
+const MasterCodeMaxLength = 16
+
+func ParseMasterKind(value string) (MasterKind, error) {
+	for _, kind := range MasterKinds {
+		if string(kind) == value {
+			return kind, nil
+		}
+	}
+	names := make([]string, 0, len(MasterKinds))
+	for _, kind := range MasterKinds {
+		names = append(names, string(kind))
+	}
+	return "", fmt.Errorf("unknown master kind: %s (%s)", value, strings.Join(names, " / "))
+}
+
+func (k MasterKind) Label() string {
+	switch k {
+	case MasterCompany:
+		return "会社"
+	case MasterWarehouse:
+		return "倉庫"
+	case MasterSalesAccountTitle:
+		return "売上科目"
+	case MasterCostAccountTitle:
+		return "原価科目"
+	}
+	return string(k)
+}
+
+// NameMaxLength 名称の最大文字数（会社・倉庫: 36、科目: 32）
+func (k MasterKind) NameMaxLength() int {
+	if k.IsAccountTitle() {
+		return 32
+	}
+	return 36
+}
+
+// IsAccountTitle 課税区分を持つ科目マスタか
+func (k MasterKind) IsAccountTitle() bool {
+	return k == MasterSalesAccountTitle || k == MasterCostAccountTitle
+}
+
+// 会社・倉庫・売上科目・原価科目の1件
+type MasterRecord struct {
+	ID       uint
+	Code     string
+	Name     string
+	Disabled bool
+	// 科目のみ
+	Taxable bool
+}
+
+// Validate 必須項目とカラムの文字数（varchar の文字数）を検証する
+func (r *MasterRecord) Validate(kind MasterKind) error {
+	if strings.TrimSpace(r.Code) == "" {
+		return fmt.Errorf("code is required")
+	}
+	if r.Code != strings.TrimSpace(r.Code) {
+		return fmt.Errorf("code must not have leading or trailing spaces: %q", r.Code)
+	}
+	if n := utf8.RuneCountInString(r.Code); n > MasterCodeMaxLength {
+		return fmt.Errorf("code is too long: %d characters (max %d)", n, MasterCodeMaxLength)
+	}
+	if strings.TrimSpace(r.Name) == "" {
+		return fmt.Errorf("name is required")
+	}
+	if n := utf8.RuneCountInString(r.Name); n > kind.NameMaxLength() {
+		return fmt.Errorf("name is too long: %d characters (max %d)", n, kind.NameMaxLength())
+	}
+	return nil
+}
+
+// 作成・更新の入力（nil の項目は既存の値を引き継ぐ、作成時は既定値）
+type MasterInput struct {
+	// CSV の行番号（コマンドから指定した場合は 0）
+	Line int
+	// 更新対象のコード（作成時は Code と同じ）
+	LookupCode string
+	Code       *string
+	Name       *string
+	Disabled   *bool
+	Taxable    *bool
+	// true の場合は既存のレコードがなければエラー（update / disable コマンド）
+	MustExist bool
+	// true の場合は既存のレコードがあればエラー（create コマンド）
+	MustNotExist bool
+}
+
+// 変更の種類
+type MasterAction string
+
+const (
+	MasterActionCreate    MasterAction = "create"
+	MasterActionUpdate    MasterAction = "update"
+	MasterActionUnchanged MasterAction = "unchanged"
+	MasterActionInvalid   MasterAction = "invalid"
+)
+
+// 1件分の変更内容（Before は作成時 nil）
+type MasterChange struct {
+	Action MasterAction
+	// CSV の行番号（コマンドから指定した場合は 0）
+	Line   int
+	Before *MasterRecord
+	After  MasterRecord
+	// Action が MasterActionInvalid の場合の理由
+	Error error
+}
+
+// 変更内容の一覧（dry-run の表示と反映に使う）
+type MasterPlan struct {
+	Kind    MasterKind
+	Changes []MasterChange
+}
+
+func (p *MasterPlan) HasErrors() bool {
+	for _, change := range p.Changes {
+		if change.Action == MasterActionInvalid {
+			return true
+		}
+	}
+	return false
+}
+
+// Count 種類ごとの件数
+func (p *MasterPlan) Count(action MasterAction) int {
+	count := 0
+	for _, change := range p.Changes {
+		if change.Action == action {
+			count++
+		}
+	}
+	return count
+}
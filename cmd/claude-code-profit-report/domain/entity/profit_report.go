@@ -16,6 +16,18 @@ type ProfitReport struct {
 	GrossProfit    float64
 	GrossProfitRate float64
 	DailyReports   []DailyProfitReport
+	// 勘定科目別の内訳（GenerateProfitReportWithDetails の場合のみ設定）
+	SalesDetails []AccountTitleAmount
+	CostDetails  []AccountTitleAmount
+	// 消費税（TaxUseCase.ApplyTax の場合のみ設定）
+	Tax *TaxSummary
+	// 共通費配賦を反映済みか、反映によるコストの増減額
+	CostAllocated            bool
+	CostAllocationAdjustment float64
+	// 無効化された勘定科目を含めて集計したか、合計のうち無効な科目の金額
+	IncludesDisabledTitles bool
+	InactiveSales          float64
+	InactiveCost           float64
 }
 
 type DailyProfitReport struct {
@@ -0,0 +1,128 @@
+package entity
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// 出力済みレポートの記録（監査用に生成時点の値を保持する）
+type ReportRun struct {
+	ID           uint64
+	Report       ProfitReport
+	OutputFormat string
+	Destination  string
+	OutputPath   string
+	CreatedAt    time.Time
+}
+
+func NewReportRun(report *ProfitReport, outputFormat, destination, outputPath string) *ReportRun {
+	archived := *report
+	// 勘定科目別の内訳は保存対象外
+	archived.SalesDetails = nil
+	archived.CostDetails = nil
+
+	return &ReportRun{
+		Report:       archived,
+		OutputFormat: outputFormat,
+		Destination:  destination,
+		OutputPath:   outputPath,
+	}
+}
+
+// 保存済みレポートと再生成したレポートの差分
+type ReportDiff struct {
+	Run     *ReportRun
+	Current *ProfitReport
+	Totals  AmountDiff
+	Daily   []DailyDiff
+}
+
+type AmountDiff struct {
+	Archived DailyProfitReport
+	Current  DailyProfitReport
+}
+
+type DailyDiff struct {
+	Date time.Time
+	AmountDiff
+	// 片方にしか存在しない日
+	OnlyArchived bool
+	OnlyCurrent  bool
+}
+
+// 金額の比較時に許容する誤差（DBは小数3桁で保持）
+const amountTolerance = 0.0005
+
+func (d AmountDiff) Changed() bool {
+	return math.Abs(d.Archived.Sales-d.Current.Sales) > amountTolerance ||
+		math.Abs(d.Archived.Cost-d.Current.Cost) > amountTolerance
+}
+
+func (d AmountDiff) SalesDelta() float64 {
+	return d.Current.Sales - d.Archived.Sales
+}
+
+func (d AmountDiff) CostDelta() float64 {
+	return d.Current.Cost - d.Archived.Cost
+}
+
+func (d AmountDiff) GrossProfitDelta() float64 {
+	return d.Current.GrossProfit - d.Archived.GrossProfit
+}
+
+func (d *ReportDiff) HasChanges() bool {
+	return d.Totals.Changed() || len(d.Daily) > 0
+}
+
+// 日別明細を日付で突き合わせ、変更のあった日だけを差分として返す
+func NewReportDiff(run *ReportRun, current *ProfitReport) *ReportDiff {
+	diff := &ReportDiff{
+		Run:     run,
+		Current: current,
+		Totals: AmountDiff{
+			Archived: DailyProfitReport{
+				Sales:           run.Report.TotalSales,
+				Cost:            run.Report.TotalCost,
+				GrossProfit:     run.Report.GrossProfit,
+				GrossProfitRate: run.Report.GrossProfitRate,
+			},
+			Current: DailyProfitReport{
+				Sales:           current.TotalSales,
+				Cost:            current.TotalCost,
+				GrossProfit:     current.GrossProfit,
+				GrossProfitRate: current.GrossProfitRate,
+			},
+		},
+	}
+
+	archived := make(map[string]DailyProfitReport)
+	for _, d := range run.Report.DailyReports {
+		archived[d.Date.Format("2006-01-02")] = d
+	}
+	seen := make(map[string]bool)
+
+	for _, c := range current.DailyReports {
+		key := c.Date.Format("2006-01-02")
+		seen[key] = true
+		a, ok := archived[key]
+		if !ok {
+			diff.Daily = append(diff.Daily, DailyDiff{Date: c.Date, AmountDiff: AmountDiff{Current: c}, OnlyCurrent: true})
+			continue
+		}
+		d := DailyDiff{Date: c.Date, AmountDiff: AmountDiff{Archived: a, Current: c}}
+		if d.Changed() {
+			diff.Daily = append(diff.Daily, d)
+		}
+	}
+	for _, a := range run.Report.DailyReports {
+		if !seen[a.Date.Format("2006-01-02")] {
+			diff.Daily = append(diff.Daily, DailyDiff{Date: a.Date, AmountDiff: AmountDiff{Archived: a}, OnlyArchived: true})
+		}
+	}
+
+	sort.Slice(diff.Daily, func(i, j int) bool {
+		return diff.Daily[i].Date.Before(diff.Daily[j].Date)
+	})
+	return diff
+}
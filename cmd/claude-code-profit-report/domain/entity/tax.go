@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"math"
+	"time"
+)
+
+// 金額が税込か税抜か
+type TaxBasis string
+
+const (
+	TaxBasisExclusive TaxBasis = "exclusive"
+	TaxBasisInclusive TaxBasis = "inclusive"
+)
+
+// 消費税率（EffectiveFrom 以降、次の税率の適用開始日の前日まで適用）
+type TaxRate struct {
+	ID            uint
+	Name          string
+	Rate          float64
+	EffectiveFrom time.Time
+}
+
+// 日付・課税区分単位の金額
+type TaxableAmount struct {
+	Date    time.Time
+	Taxable bool
+	Amount  float64
+}
+
+// 税率ごとの課税対象額（税抜）と消費税額
+type TaxBreakdown struct {
+	Name      string
+	Rate      float64
+	SalesBase float64
+	SalesTax  float64
+	CostBase  float64
+	CostTax   float64
+}
+
+// 税抜・税込の売上・コスト・粗利
+type TaxSummary struct {
+	// 元データの金額が税込か税抜か
+	Basis             TaxBasis
+	SalesExcludingTax float64
+	SalesTax          float64
+	CostExcludingTax  float64
+	CostTax           float64
+	// 非課税科目の金額（税抜・税込の金額に含む）
+	NonTaxableSales float64
+	NonTaxableCost  float64
+	ByRate          []TaxBreakdown
+}
+
+func (s *TaxSummary) SalesIncludingTax() float64 {
+	return s.SalesExcludingTax + s.SalesTax
+}
+
+func (s *TaxSummary) CostIncludingTax() float64 {
+	return s.CostExcludingTax + s.CostTax
+}
+
+func (s *TaxSummary) GrossProfitExcludingTax() float64 {
+	return s.SalesExcludingTax - s.CostExcludingTax
+}
+
+func (s *TaxSummary) GrossProfitIncludingTax() float64 {
+	return s.SalesIncludingTax() - s.CostIncludingTax()
+}
+
+// SplitTax 課税対象額を税抜額と消費税額（1円未満切り捨て）に分ける
+func SplitTax(amount, rate float64, basis TaxBasis) (base, tax float64) {
+	if basis == TaxBasisInclusive {
+		tax = math.Floor(amount * rate / (1 + rate))
+		return amount - tax, tax
+	}
+	return amount, math.Floor(amount * rate)
+}
@@ -0,0 +1,108 @@
+package entity
+
+import (
+	"time"
+)
+
+// 日次レポートの種別
+type ReportKind string
+
+const (
+	ReportKindSales ReportKind = "sales"
+	ReportKindCost  ReportKind = "cost"
+)
+
+// 検証項目
+type ValidationCheck string
+
+const (
+	// 期間内にレポートがある会社・倉庫・科目で、レポートがない日
+	CheckMissingDay ValidationCheck = "missing_day"
+	// 明細が1件もないレポート
+	CheckEmptyReport ValidationCheck = "empty_report"
+	// 金額が数量×単価と許容誤差を超えて異なる明細
+	CheckAmountMismatch ValidationCheck = "amount_mismatch"
+	// 同じ会社・倉庫・日に売上レポートがないコストレポート
+	CheckCostWithoutSales ValidationCheck = "cost_without_sales"
+	// 同じ会社・倉庫・日にコストレポートがない売上レポート
+	CheckSalesWithoutCost ValidationCheck = "sales_without_cost"
+	// 数量が0以下の明細
+	CheckNonPositiveQuantity ValidationCheck = "non_positive_quantity"
+)
+
+// 出力順
+var ValidationChecks = []ValidationCheck{
+	CheckMissingDay,
+	CheckEmptyReport,
+	CheckAmountMismatch,
+	CheckCostWithoutSales,
+	CheckSalesWithoutCost,
+	CheckNonPositiveQuantity,
+}
+
+// 明細件数付きの日次レポート
+type DailyReportHeader struct {
+	Kind             ReportKind
+	ReportID         uint64
+	CompanyID        uint
+	CompanyName      string
+	WarehouseID      uint
+	WarehouseName    string
+	Date             time.Time
+	AccountTitleID   uint
+	AccountTitleCode string
+	AccountTitleName string
+	ItemCount        int
+}
+
+// 日次レポートの明細（売上は price / amount、コストは cost_price / cost_amount）
+type DailyReportItem struct {
+	Header   DailyReportHeader
+	ItemID   uint64
+	Size     string
+	Quantity int
+	Price    float64
+	Amount   float64
+}
+
+func (i DailyReportItem) ExpectedAmount() float64 {
+	return float64(i.Quantity) * i.Price
+}
+
+// 検証で見つかった問題（Item は明細単位の問題の場合のみ設定）
+type ValidationIssue struct {
+	Check  ValidationCheck
+	Header DailyReportHeader
+	Item   *DailyReportItem
+}
+
+type ValidationResult struct {
+	CompanyID   uint
+	WarehouseID uint
+	StartDate   time.Time
+	EndDate     time.Time
+	Tolerance   float64
+	Issues      []ValidationIssue
+}
+
+func (r *ValidationResult) Passed() bool {
+	return len(r.Issues) == 0
+}
+
+func (r *ValidationResult) CountByCheck() map[ValidationCheck]int {
+	counts := make(map[ValidationCheck]int, len(ValidationChecks))
+	for _, issue := range r.Issues {
+		counts[issue.Check]++
+	}
+	return counts
+}
+
+func (r *ValidationResult) IssuesByCheck(check ValidationCheck) []ValidationIssue {
+	var issues []ValidationIssue
+	for _, issue := range r.Issues {
+		if issue.Check == check {
+			issues = append(issues, issue)
+		}
+	}
+	return issues
+}
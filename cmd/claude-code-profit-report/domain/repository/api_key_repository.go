@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+type APIKeyRepository interface {
+	// キーのハッシュと参照可能な会社を保存する
+	Create(ctx context.Context, key *entity.APIKey, keyHash string) error
+	// 失効していないキーをハッシュで検索する（見つからない場合は nil）
+	FindActiveByHash(ctx context.Context, keyHash string) (*entity.APIKey, error)
+	// 発行順に取得する（失効済みを含む）
+	List(ctx context.Context) ([]entity.APIKey, error)
+	Revoke(ctx context.Context, id uint64) error
+	TouchLastUsed(ctx context.Context, id uint64) error
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+type AuditLogFilter struct {
+	// 0 の場合は絞り込まない
+	APIKeyID  uint64
+	CompanyID uint
+	Limit     int
+}
+
+type AuditLogRepository interface {
+	Create(ctx context.Context, log *entity.AuditLog) error
+	// 新しい順に取得する
+	List(ctx context.Context, filter AuditLogFilter) ([]entity.AuditLog, error)
+}
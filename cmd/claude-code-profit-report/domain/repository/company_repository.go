@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"time"
 )
 
 type Company struct {
@@ -20,5 +21,6 @@ type CompanyRepository interface {
 	GetCompanyByID(ctx context.Context, id uint) (*Company, error)
 	GetWarehouseByID(ctx context.Context, id uint) (*WarehouseBase, error)
 	GetAllCompanies(ctx context.Context) ([]Company, error)
-	GetWarehousesByCompanyID(ctx context.Context, companyID uint) ([]WarehouseBase, error)
+	// 契約期間が startDate〜endDate と重なる倉庫を返す
+	GetWarehousesByCompanyID(ctx context.Context, companyID uint, startDate, endDate time.Time) ([]WarehouseBase, error)
 }
\ No newline at end of file
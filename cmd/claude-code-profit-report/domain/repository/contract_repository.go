@@ -0,0 +1,27 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+type ContractFilter struct {
+	// 0 の場合は絞り込まない
+	CompanyID   uint
+	WarehouseID uint
+	// 指定した場合は契約期間が StartDate〜EndDate と重なるもののみ
+	StartDate *time.Time
+	EndDate   *time.Time
+}
+
+type ContractRepository interface {
+	// 会社・倉庫・契約開始日順に取得する
+	List(ctx context.Context, filter ContractFilter) ([]entity.Contract, error)
+	// 見つからない場合は nil
+	GetByID(ctx context.Context, id uint64) (*entity.Contract, error)
+	Create(ctx context.Context, contract *entity.Contract) error
+	// 契約終了日を設定する
+	End(ctx context.Context, id uint64, endDate time.Time) error
+}
@@ -0,0 +1,21 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+type CorrectionRepository interface {
+	// 期間内の会社・倉庫・日別の合計を取得する（companyID / warehouseID が 0 の場合は全件）
+	GetDailyTotals(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) ([]entity.DailyTotal, error)
+	// 報告済みの値として保存する（既存の値は上書き）
+	SaveReportedDailyTotals(ctx context.Context, totals []entity.DailyTotal) error
+	// since より後に日次レポート・明細が更新された会社・倉庫・日と、直近の報告済みの合計がある会社・倉庫・日のうち、
+	// 報告済み期間に含まれるものを現在の合計と報告時の値を付けて返す
+	FindCorrections(ctx context.Context, since, until time.Time) ([]entity.Correction, error)
+	// 前回チェック日時を返す（未実行の場合は最初のレポート保存日時、どちらもない場合は false）
+	GetLastCheckedAt(ctx context.Context) (time.Time, bool, error)
+	SaveCheck(ctx context.Context, since, checkedAt time.Time, corrections int) error
+}
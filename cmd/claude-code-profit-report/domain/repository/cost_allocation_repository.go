@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+type CostAllocationRepository interface {
+	// 有効な配賦ルールを固定比率付きで取得する
+	GetAllocationRules(ctx context.Context) ([]entity.AllocationRule, error)
+	// 倉庫の会社・日別の出荷数量を取得する
+	GetShippedQuantities(ctx context.Context, warehouseID uint, startDate, endDate time.Time) ([]entity.CompanyQuantity, error)
+}
@@ -10,4 +10,7 @@ import (
 type CostRepository interface {
 	GetDailyReportsByPeriod(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) ([]entity.CostDailyReport, error)
 	GetDailySummaryByPeriod(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) (map[time.Time]float64, error)
+	GetAccountTitleAmountsByPeriod(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) ([]entity.AccountTitleAmount, error)
+	// 無効化された勘定科目の期間合計（WithDisabledAccountTitles で含めた金額の内訳表示用）
+	GetDisabledTitleTotalByPeriod(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) (float64, error)
 }
\ No newline at end of file
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+type ImportBatchRepository interface {
+	// 新しい順に最大 limit 件取得する（0 の場合は全件）
+	List(ctx context.Context, limit int) ([]entity.ImportBatch, error)
+	// 存在しない場合は nil を返す
+	GetByID(ctx context.Context, id uint64) (*entity.ImportBatch, error)
+	// バッチが作成したレポートを削除し、置き換えたレポートは置き換え前の明細に戻す（1つのトランザクションで反映する。
+	// 後続のバッチが同じレポートを更新している場合はエラー。dryRun の場合は反映後にロールバックする）
+	Rollback(ctx context.Context, id uint64, dryRun bool) (*entity.ImportBatchRollback, error)
+}
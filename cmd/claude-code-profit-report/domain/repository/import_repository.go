@@ -0,0 +1,14 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+type ImportRepository interface {
+	// 取り込みバッチを作成し、会社・倉庫・日・科目のレポートを作成または更新して明細を置き換える
+	// （1つのトランザクションで反映し、batch の ID と各レポートの ReportID・Updated を設定する。
+	// 置き換えた明細はバッチのロールバック用に保存する。dryRun の場合は反映後にロールバックする）
+	ImportDailyReports(ctx context.Context, kind entity.ReportKind, batch *entity.ImportBatch, reports []entity.DailyReportImport, dryRun bool) error
+}
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+type MasterRepository interface {
+	// コード順に取得する（includeDisabled が false の場合は有効なもののみ）
+	List(ctx context.Context, kind entity.MasterKind, includeDisabled bool) ([]entity.MasterRecord, error)
+	// コードで検索する（見つからない場合は nil）
+	GetByCode(ctx context.Context, kind entity.MasterKind, code string) (*entity.MasterRecord, error)
+	// 作成・更新を1つのトランザクションで反映する（変更なし・エラーの行は無視する）
+	Apply(ctx context.Context, kind entity.MasterKind, changes []entity.MasterChange) error
+}
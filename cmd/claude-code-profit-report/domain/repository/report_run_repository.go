@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+type ReportRunFilter struct {
+	// 0 の場合は絞り込まない
+	CompanyID   uint
+	WarehouseID uint
+	Limit       int
+}
+
+type ReportRunRepository interface {
+	Create(ctx context.Context, run *entity.ReportRun) error
+	// 日別明細を含めて取得する
+	GetByID(ctx context.Context, id uint64) (*entity.ReportRun, error)
+	// 新しい順に取得する（日別明細は含まない）
+	List(ctx context.Context, filter ReportRunFilter) ([]entity.ReportRun, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+type TaxRepository interface {
+	// 税率を適用開始日の昇順で取得する
+	GetTaxRates(ctx context.Context) ([]entity.TaxRate, error)
+	// 期間内の金額を日付・課税区分別に取得する（companyID / warehouseID が 0 の場合は全件）
+	GetTaxableAmounts(ctx context.Context, kind entity.ReportKind, companyID, warehouseID uint, startDate, endDate time.Time) ([]entity.TaxableAmount, error)
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+type ValidationRepository interface {
+	// 期間内の日次レポートを明細件数付きで取得する（companyID / warehouseID が 0 の場合は全件）
+	GetDailyReportHeaders(ctx context.Context, kind entity.ReportKind, companyID, warehouseID uint, startDate, endDate time.Time) ([]entity.DailyReportHeader, error)
+	// 金額が数量×単価と tolerance を超えて異なる明細と、数量が0以下の明細を取得する
+	FindInvalidItems(ctx context.Context, kind entity.ReportKind, companyID, warehouseID uint, startDate, endDate time.Time, tolerance float64) ([]entity.DailyReportItem, error)
+}
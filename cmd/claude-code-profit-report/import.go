@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/config"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/presentation/cli"
+)
+
+func newImportCommand() *cobra.Command {
+	var (
+		importDryRun    bool
+		importTolerance float64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "import <sales|cost> <CSVファイル>...",
+		Short: "倉庫システムが出力した売上・コストの明細CSVを日次レポートとして取り込む",
+		Long: `売上 (sales)・コスト (cost) の明細CSVを会社・倉庫・日・科目のレポート単位で取り込みます。
+1行目はヘッダーで company_code・warehouse_code・date・title_code・quantity・price・amount (必須) と size の列を指定します。
+既存のレポートは明細をCSVの内容で置き換えます。ファイルごとに1つのトランザクションで反映し、
+エラーの行を含むレポートは同じレポートの行をすべて除外します。除外した行がある場合は終了コード1で終了します。
+ファイルごとに取り込みバッチを記録し、import-batch rollback で取り込み前の状態に戻せます。`,
+		Args: cobra.MinimumNArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kind, err := entity.ParseReportKind(args[0])
+			if err != nil {
+				return err
+			}
+			if importTolerance < 0 {
+				return fmt.Errorf("tolerance must not be negative")
+			}
+
+			return withContainer(cmd, func(ctx context.Context, container *config.Container) error {
+				rejected := 0
+				for _, path := range args[1:] {
+					result, err := importDailyReportCSV(ctx, container, kind, path, importTolerance, importDryRun)
+					if err != nil {
+						return err
+					}
+					fmt.Print(cli.NewTextFormatter().FormatImportResult(path, result))
+					rejected += len(result.Rejected)
+				}
+
+				if rejected > 0 {
+					return fmt.Errorf("%d rows were rejected", rejected)
+				}
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().BoolVar(&importDryRun, "dry-run", false, "取り込み結果を表示するだけで反映しない")
+	cmd.Flags().Float64Var(&importTolerance, "tolerance", 0.01, "金額と数量×単価の許容誤差")
+
+	return cmd
+}
+
+func importDailyReportCSV(ctx context.Context, container *config.Container, kind entity.ReportKind, path string, tolerance float64, dryRun bool) (*entity.ImportResult, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open csv: %w", err)
+	}
+	defer file.Close()
+
+	rows, err := cli.ReadDailyReportCSV(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("csv has no rows: %s", path)
+	}
+
+	result, err := container.ImportUseCase.ImportDailyReports(ctx, kind, path, rows, tolerance, dryRun)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return result, nil
+}
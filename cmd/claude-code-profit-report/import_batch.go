@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/config"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/presentation/cli"
+)
+
+func newImportBatchCommand() *cobra.Command {
+	batchCmd := &cobra.Command{
+		Use:   "import-batch",
+		Short: "日次レポートの取り込みバッチの表示・ロールバック",
+		Long: `import コマンド・日次レポート登録API・data-insert が書き込んだレポートと明細は、実行ごとの取り込みバッチに記録されます。
+誤ったデータを取り込んだ場合は rollback でバッチが作成したレポートを削除し、明細を置き換えたレポートは取り込み前の明細に戻します。`,
+	}
+
+	var listLimit int
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "取り込みバッチを新しい順に表示する",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withContainer(cmd, func(ctx context.Context, container *config.Container) error {
+				batches, err := container.ImportUseCase.ListBatches(ctx, listLimit)
+				if err != nil {
+					return err
+				}
+				fmt.Print(cli.NewTextFormatter().FormatImportBatches(batches))
+				return nil
+			})
+		},
+	}
+	listCmd.Flags().IntVarP(&listLimit, "limit", "n", 20, "表示件数 (0: 全件)")
+
+	var rollbackDryRun bool
+	rollbackCmd := &cobra.Command{
+		Use:   "rollback <バッチID>",
+		Short: "取り込みバッチの反映を1つのトランザクションで取り消す",
+		Long: `バッチが作成したレポートと明細を削除し、明細を置き換えたレポートは置き換え前の明細（ID・作成日時を含む）に戻します。
+後続のバッチが同じレポートを更新している場合は、先に後続のバッチをロールバックしてください。`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseUint(args[0], 10, 64)
+			if err != nil || id == 0 {
+				return fmt.Errorf("invalid import batch id: %s", args[0])
+			}
+
+			return withContainer(cmd, func(ctx context.Context, container *config.Container) error {
+				result, err := container.ImportUseCase.RollbackBatch(ctx, id, rollbackDryRun)
+				if err != nil {
+					return err
+				}
+				fmt.Print(cli.NewTextFormatter().FormatImportBatchRollback(result))
+				return nil
+			})
+		},
+	}
+	rollbackCmd.Flags().BoolVar(&rollbackDryRun, "dry-run", false, "ロールバックの結果を表示するだけで反映しない")
+
+	batchCmd.AddCommand(listCmd, rollbackCmd)
+	return batchCmd
+}
@@ -1,11 +1,12 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"os"
 
-	_ "github.com/go-sql-driver/mysql"
+	"github.com/go-sql-driver/mysql"
 )
 
 type DBConfig struct {
@@ -26,7 +27,9 @@ func NewDBConfig() *DBConfig {
 	}
 }
 
-func NewDB(config *DBConfig) (*sql.DB, error) {
+// NewDB 接続を開いて疎通を確認する
+// 接続確認を含め、この接続を通る問い合わせには ctx に設定されたクエリのタイムアウトが1回ごとに適用される
+func NewDB(ctx context.Context, config *DBConfig) (*sql.DB, error) {
 	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8mb4&parseTime=true&loc=Local",
 		config.User,
 		config.Password,
@@ -35,12 +38,18 @@ func NewDB(config *DBConfig) (*sql.DB, error) {
 		config.Database,
 	)
 
-	db, err := sql.Open("mysql", dsn)
+	cfg, err := mysql.ParseDSN(dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
+	connector, err := mysql.NewConnector(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	db := sql.OpenDB(timeoutConnector{Connector: connector})
 
-	if err := db.Ping(); err != nil {
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
@@ -0,0 +1,164 @@
+package database
+
+import (
+	"context"
+	"database/sql/driver"
+)
+
+// timeoutConnector ctx に設定されたクエリのタイムアウトを1回の問い合わせごとに適用する接続を作る
+// リポジトリやトレースの有無に関係なく、この接続を通るすべての問い合わせに期限がかかる
+type timeoutConnector struct {
+	driver.Connector
+}
+
+func (c timeoutConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	conn, err := c.Connector.Connect(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &timeoutConn{Conn: conn}, nil
+}
+
+// timeoutConn 問い合わせ・準備・トランザクション開始のそれぞれにタイムアウトを適用する
+// プレースホルダ付きの問い合わせは準備済みステートメント経由になるため、ステートメントにも適用する
+type timeoutConn struct {
+	driver.Conn
+}
+
+func (c *timeoutConn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := c.Conn.(driver.QueryerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	ctx, cancel := QueryContext(ctx)
+	rows, err := queryer.QueryContext(ctx, query, args)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &timeoutRows{Rows: rows, cancel: cancel}, nil
+}
+
+func (c *timeoutConn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := c.Conn.(driver.ExecerContext)
+	if !ok {
+		return nil, driver.ErrSkip
+	}
+	ctx, cancel := QueryContext(ctx)
+	defer cancel()
+	return execer.ExecContext(ctx, query, args)
+}
+
+func (c *timeoutConn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	ctx, cancel := QueryContext(ctx)
+	defer cancel()
+
+	var stmt driver.Stmt
+	var err error
+	if preparer, ok := c.Conn.(driver.ConnPrepareContext); ok {
+		stmt, err = preparer.PrepareContext(ctx, query)
+	} else {
+		stmt, err = c.Conn.Prepare(query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &timeoutStmt{Stmt: stmt}, nil
+}
+
+// BeginTx トランザクション開始の問い合わせにだけ期限をかける（トランザクション内の各問い合わせには個別に適用される）
+func (c *timeoutConn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	ctx, cancel := QueryContext(ctx)
+	defer cancel()
+	if beginner, ok := c.Conn.(driver.ConnBeginTx); ok {
+		return beginner.BeginTx(ctx, opts)
+	}
+	return c.Conn.Begin()
+}
+
+func (c *timeoutConn) Ping(ctx context.Context) error {
+	pinger, ok := c.Conn.(driver.Pinger)
+	if !ok {
+		return nil
+	}
+	ctx, cancel := QueryContext(ctx)
+	defer cancel()
+	return pinger.Ping(ctx)
+}
+
+func (c *timeoutConn) ResetSession(ctx context.Context) error {
+	if resetter, ok := c.Conn.(driver.SessionResetter); ok {
+		return resetter.ResetSession(ctx)
+	}
+	return nil
+}
+
+func (c *timeoutConn) IsValid() bool {
+	if validator, ok := c.Conn.(driver.Validator); ok {
+		return validator.IsValid()
+	}
+	return true
+}
+
+func (c *timeoutConn) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := c.Conn.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+// timeoutStmt 準備済みステートメントの実行ごとにタイムアウトを適用する
+type timeoutStmt struct {
+	driver.Stmt
+}
+
+func (s *timeoutStmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	queryer, ok := s.Stmt.(driver.StmtQueryContext)
+	if !ok {
+		return s.Stmt.Query(values(args))
+	}
+	ctx, cancel := QueryContext(ctx)
+	rows, err := queryer.QueryContext(ctx, args)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	return &timeoutRows{Rows: rows, cancel: cancel}, nil
+}
+
+func (s *timeoutStmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	execer, ok := s.Stmt.(driver.StmtExecContext)
+	if !ok {
+		return s.Stmt.Exec(values(args))
+	}
+	ctx, cancel := QueryContext(ctx)
+	defer cancel()
+	return execer.ExecContext(ctx, args)
+}
+
+func (s *timeoutStmt) CheckNamedValue(nv *driver.NamedValue) error {
+	if checker, ok := s.Stmt.(driver.NamedValueChecker); ok {
+		return checker.CheckNamedValue(nv)
+	}
+	return driver.ErrSkip
+}
+
+// timeoutRows 結果を読み終えて閉じるまで問い合わせの期限を保つ
+type timeoutRows struct {
+	driver.Rows
+	cancel context.CancelFunc
+}
+
+func (r *timeoutRows) Close() error {
+	defer r.cancel()
+	return r.Rows.Close()
+}
+
+// values 名前付きの引数を旧来のステートメント API 向けに並べ直す
+func values(args []driver.NamedValue) []driver.Value {
+	vals := make([]driver.Value, len(args))
+	for i, arg := range args {
+		vals[i] = arg.Value
+	}
+	return vals
+}
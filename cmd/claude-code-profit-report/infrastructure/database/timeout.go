@@ -0,0 +1,28 @@
+package database
+
+import (
+	"context"
+	"time"
+)
+
+type queryTimeoutKey struct{}
+
+// WithQueryTimeout データベースへの1回の問い合わせに許す時間を ctx に設定する（0以下は無制限）
+func WithQueryTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, queryTimeoutKey{}, timeout)
+}
+
+// QueryTimeout ctx に設定されたクエリのタイムアウトを返す
+func QueryTimeout(ctx context.Context) time.Duration {
+	timeout, _ := ctx.Value(queryTimeoutKey{}).(time.Duration)
+	return timeout
+}
+
+// QueryContext ctx に設定されたタイムアウトを期限にした context を返す
+// 全体の期限の方が早い場合はそちらが優先される
+func QueryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if timeout := QueryTimeout(ctx); timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return context.WithCancel(ctx)
+}
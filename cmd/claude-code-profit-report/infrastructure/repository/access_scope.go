@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+// 参照範囲は ctx の利用者（entity.WithPrincipal）で決まる。
+// 利用者が設定されていない CLI からの実行と管理者は制限しない。
+
+// checkCompanyAccess 利用者が companyID（0 は全社）を参照できない場合は entity.ErrForbidden を返す
+func checkCompanyAccess(ctx context.Context, companyID uint) error {
+	principal, ok := entity.PrincipalFromContext(ctx)
+	if !ok || principal.CanAccessCompany(companyID) {
+		return nil
+	}
+	return fmt.Errorf("%w: principal=%s company_id=%d", entity.ErrForbidden, principal.Name, companyID)
+}
+
+// requireAdmin 全社のデータをまとめて扱う処理（配賦ルール、報告済みの値の更新など）を管理者に限定する
+func requireAdmin(ctx context.Context) error {
+	principal, ok := entity.PrincipalFromContext(ctx)
+	if !ok || principal.IsAdmin() {
+		return nil
+	}
+	return fmt.Errorf("%w: principal=%s requires admin role", entity.ErrForbidden, principal.Name)
+}
+
+// companyScope companyID を指定した問い合わせでは参照可否を確認し、
+// 全社（0）の集計では column を利用者が参照できる会社に絞り込む条件を返す（制限がない場合は空）
+func companyScope(ctx context.Context, column string, companyID uint) (string, []interface{}, error) {
+	principal, ok := entity.PrincipalFromContext(ctx)
+	if !ok || principal.IsAdmin() {
+		return "", nil, nil
+	}
+	if companyID > 0 {
+		return "", nil, checkCompanyAccess(ctx, companyID)
+	}
+	if len(principal.CompanyIDs) == 0 {
+		return "1 = 0", nil, nil
+	}
+
+	placeholders := make([]string, 0, len(principal.CompanyIDs))
+	args := make([]interface{}, 0, len(principal.CompanyIDs))
+	for _, id := range principal.CompanyIDs {
+		placeholders = append(placeholders, "?")
+		args = append(args, id)
+	}
+	return column + " IN (" + strings.Join(placeholders, ", ") + ")", args, nil
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+// buildPeriodCondition 会社・倉庫（0は全件）と期間の WHERE 条件を組み立てる
+// （APIの利用者の場合は参照できる会社に限定し、無効化された勘定科目の日報は除外する）
+func buildPeriodCondition(ctx context.Context, kind entity.ReportKind, alias string, companyID, warehouseID uint, startDate, endDate time.Time) (string, []interface{}, error) {
+	conditions := []string{alias + ".target_date BETWEEN ? AND ?"}
+	args := []interface{}{startDate, endDate}
+
+	if title := activeTitleCondition(ctx, kind, alias); title != "" {
+		conditions = append(conditions, title)
+	}
+
+	if companyID > 0 {
+		conditions = append(conditions, alias+".company_id = ?")
+		args = append(args, companyID)
+	}
+	if warehouseID > 0 {
+		conditions = append(conditions, alias+".warehouse_base_id = ?")
+		args = append(args, warehouseID)
+	}
+
+	scope, scopeArgs, err := companyScope(ctx, alias+".company_id", companyID)
+	if err != nil {
+		return "", nil, err
+	}
+	if scope != "" {
+		conditions = append(conditions, scope)
+		args = append(args, scopeArgs...)
+	}
+
+	return strings.Join(conditions, " AND "), args, nil
+}
+
+// activeTitleCondition alias の日報を有効な勘定科目に限定する条件を返す
+// （entity.WithDisabledAccountTitles の場合は空）
+func activeTitleCondition(ctx context.Context, kind entity.ReportKind, alias string) string {
+	if entity.IncludesDisabledAccountTitles(ctx) {
+		return ""
+	}
+	t := reportTablesByKind[kind]
+	return alias + "." + t.titleFK + " IN (SELECT id FROM " + t.titles + " WHERE disabled = 0)"
+}
+
+func scanAccountTitleAmounts(rows *sql.Rows) ([]entity.AccountTitleAmount, error) {
+	var amounts []entity.AccountTitleAmount
+	for rows.Next() {
+		var amount entity.AccountTitleAmount
+		if err := rows.Scan(
+			&amount.CompanyID,
+			&amount.CompanyName,
+			&amount.WarehouseID,
+			&amount.WarehouseName,
+			&amount.Date,
+			&amount.AccountTitleID,
+			&amount.AccountTitleCode,
+			&amount.AccountTitleName,
+			&amount.AccountTitleDisabled,
+			&amount.Amount,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan account title amount: %w", err)
+		}
+		// 日付部分のみを使用（時刻を00:00:00、ローカルタイムゾーンに正規化）
+		amount.Date = time.Date(amount.Date.Year(), amount.Date.Month(), amount.Date.Day(), 0, 0, 0, 0, time.Local)
+		amounts = append(amounts, amount)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return amounts, nil
+}
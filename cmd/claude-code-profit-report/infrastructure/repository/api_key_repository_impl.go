@@ -0,0 +1,208 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+)
+
+type apiKeyRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewAPIKeyRepository(db *sql.DB) repository.APIKeyRepository {
+	return &apiKeyRepositoryImpl{db: db}
+}
+
+func (r *apiKeyRepositoryImpl) Create(ctx context.Context, key *entity.APIKey, keyHash string) error {
+	if err := requireAdmin(ctx); err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO api_keys (name, role, key_hash) VALUES (?, ?, ?)
+	`, key.Name, key.Role, keyHash)
+	if err != nil {
+		return fmt.Errorf("failed to insert api key: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get api key id: %w", err)
+	}
+
+	if len(key.CompanyIDs) > 0 {
+		placeholders := make([]string, 0, len(key.CompanyIDs))
+		args := make([]interface{}, 0, len(key.CompanyIDs)*2)
+		for _, companyID := range key.CompanyIDs {
+			placeholders = append(placeholders, "(?, ?)")
+			args = append(args, id, companyID)
+		}
+
+		query := `INSERT INTO api_key_companies (api_key_id, company_id) VALUES ` + strings.Join(placeholders, ", ")
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to insert api key companies: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit api key: %w", err)
+	}
+
+	key.ID = uint64(id)
+	return nil
+}
+
+func (r *apiKeyRepositoryImpl) FindActiveByHash(ctx context.Context, keyHash string) (*entity.APIKey, error) {
+	query := apiKeySelect + ` WHERE key_hash = ? AND revoked_at IS NULL`
+
+	key, err := scanAPIKey(r.db.QueryRowContext(ctx, query, keyHash))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get api key: %w", err)
+	}
+
+	companies, err := r.getCompanyIDs(ctx, key.ID)
+	if err != nil {
+		return nil, err
+	}
+	key.CompanyIDs = companies[key.ID]
+
+	return key, nil
+}
+
+func (r *apiKeyRepositoryImpl) List(ctx context.Context) ([]entity.APIKey, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, apiKeySelect+` ORDER BY id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query api keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []entity.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan api key: %w", err)
+		}
+		keys = append(keys, *key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	companies, err := r.getCompanyIDs(ctx, 0)
+	if err != nil {
+		return nil, err
+	}
+	for i := range keys {
+		keys[i].CompanyIDs = companies[keys[i].ID]
+	}
+
+	return keys, nil
+}
+
+func (r *apiKeyRepositoryImpl) Revoke(ctx context.Context, id uint64) error {
+	if err := requireAdmin(ctx); err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE api_keys SET revoked_at = NOW() WHERE id = ? AND revoked_at IS NULL
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke api key: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("active api key not found: id=%d", id)
+	}
+
+	return nil
+}
+
+func (r *apiKeyRepositoryImpl) TouchLastUsed(ctx context.Context, id uint64) error {
+	if _, err := r.db.ExecContext(ctx, `UPDATE api_keys SET last_used_at = NOW() WHERE id = ?`, id); err != nil {
+		return fmt.Errorf("failed to update api key last used: %w", err)
+	}
+	return nil
+}
+
+// getCompanyIDs APIキーごとの参照可能な会社を取得する（apiKeyID が 0 の場合は全キー）
+func (r *apiKeyRepositoryImpl) getCompanyIDs(ctx context.Context, apiKeyID uint64) (map[uint64][]uint, error) {
+	query := `SELECT api_key_id, company_id FROM api_key_companies`
+	var args []interface{}
+	if apiKeyID > 0 {
+		query += ` WHERE api_key_id = ?`
+		args = append(args, apiKeyID)
+	}
+	query += ` ORDER BY api_key_id, company_id`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query api key companies: %w", err)
+	}
+	defer rows.Close()
+
+	companies := make(map[uint64][]uint)
+	for rows.Next() {
+		var keyID uint64
+		var companyID uint
+		if err := rows.Scan(&keyID, &companyID); err != nil {
+			return nil, fmt.Errorf("failed to scan api key company: %w", err)
+		}
+		companies[keyID] = append(companies[keyID], companyID)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return companies, nil
+}
+
+const apiKeySelect = `
+	SELECT id, name, role, last_used_at, revoked_at, created_at
+	FROM api_keys`
+
+func scanAPIKey(row rowScanner) (*entity.APIKey, error) {
+	var key entity.APIKey
+	var lastUsedAt, revokedAt sql.NullTime
+	if err := row.Scan(
+		&key.ID,
+		&key.Name,
+		&key.Role,
+		&lastUsedAt,
+		&revokedAt,
+		&key.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if lastUsedAt.Valid {
+		key.LastUsedAt = &lastUsedAt.Time
+	}
+	if revokedAt.Valid {
+		key.RevokedAt = &revokedAt.Time
+	}
+	return &key, nil
+}
@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+)
+
+type auditLogRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewAuditLogRepository(db *sql.DB) repository.AuditLogRepository {
+	return &auditLogRepositoryImpl{db: db}
+}
+
+func (r *auditLogRepositoryImpl) Create(ctx context.Context, log *entity.AuditLog) error {
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO api_audit_logs (
+			api_key_id, principal_name, method, path, query, company_id, status, remote_addr
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		log.APIKeyID, log.PrincipalName, log.Method, log.Path, truncate(log.Query, 1024),
+		log.CompanyID, log.Status, truncate(log.RemoteAddr, 64),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert audit log: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get audit log id: %w", err)
+	}
+
+	log.ID = uint64(id)
+	return nil
+}
+
+func (r *auditLogRepositoryImpl) List(ctx context.Context, filter repository.AuditLogFilter) ([]entity.AuditLog, error) {
+	var conditions []string
+	var args []interface{}
+
+	// 管理者以外は自分のキーの記録のみ参照できる
+	if principal, ok := entity.PrincipalFromContext(ctx); ok && !principal.IsAdmin() {
+		if filter.APIKeyID > 0 && filter.APIKeyID != principal.APIKeyID {
+			return nil, fmt.Errorf("%w: principal=%s api_key_id=%d", entity.ErrForbidden, principal.Name, filter.APIKeyID)
+		}
+		filter.APIKeyID = principal.APIKeyID
+	}
+
+	if filter.APIKeyID > 0 {
+		conditions = append(conditions, "api_key_id = ?")
+		args = append(args, filter.APIKeyID)
+	}
+	if filter.CompanyID > 0 {
+		conditions = append(conditions, "company_id = ?")
+		args = append(args, filter.CompanyID)
+	}
+
+	query := `
+		SELECT id, api_key_id, principal_name, method, path, query, company_id, status, remote_addr, created_at
+		FROM api_audit_logs`
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY id DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query audit logs: %w", err)
+	}
+	defer rows.Close()
+
+	var logs []entity.AuditLog
+	for rows.Next() {
+		var log entity.AuditLog
+		if err := rows.Scan(
+			&log.ID,
+			&log.APIKeyID,
+			&log.PrincipalName,
+			&log.Method,
+			&log.Path,
+			&log.Query,
+			&log.CompanyID,
+			&log.Status,
+			&log.RemoteAddr,
+			&log.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan audit log: %w", err)
+		}
+		logs = append(logs, log)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return logs, nil
+}
+
+// truncate 列の長さを超える値を切り詰める（監査ログの保存自体は失敗させない）
+func truncate(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) <= n {
+		return s
+	}
+	return string(runes[:n])
+}
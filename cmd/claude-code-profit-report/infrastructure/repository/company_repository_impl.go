@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
 )
@@ -17,6 +18,10 @@ func NewCompanyRepository(db *sql.DB) repository.CompanyRepository {
 }
 
 func (r *companyRepositoryImpl) GetCompanyByID(ctx context.Context, id uint) (*repository.Company, error) {
+	if err := checkCompanyAccess(ctx, id); err != nil {
+		return nil, err
+	}
+
 	query := `
 		SELECT id, name, code
 		FROM companies
@@ -66,10 +71,17 @@ func (r *companyRepositoryImpl) GetAllCompanies(ctx context.Context) ([]reposito
 	query := `
 		SELECT id, name, code
 		FROM companies
-		ORDER BY id
 	`
+	scope, args, err := companyScope(ctx, "id", 0)
+	if err != nil {
+		return nil, err
+	}
+	if scope != "" {
+		query += " WHERE " + scope
+	}
+	query += " ORDER BY id"
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query companies: %w", err)
 	}
@@ -95,15 +107,26 @@ func (r *companyRepositoryImpl) GetAllCompanies(ctx context.Context) ([]reposito
 	return companies, nil
 }
 
-func (r *companyRepositoryImpl) GetWarehousesByCompanyID(ctx context.Context, companyID uint) ([]repository.WarehouseBase, error) {
-	// warehouse_basesテーブルにはcompany_idがないため、全倉庫を返す
+func (r *companyRepositoryImpl) GetWarehousesByCompanyID(ctx context.Context, companyID uint, startDate, endDate time.Time) ([]repository.WarehouseBase, error) {
+	if err := checkCompanyAccess(ctx, companyID); err != nil {
+		return nil, err
+	}
+
+	// 契約期間が期間と1日でも重なる倉庫のみ返す（同じ倉庫と複数回契約していても1件にまとめる）
 	query := `
-		SELECT id, name, code
-		FROM warehouse_bases
-		ORDER BY id
+		SELECT wb.id, wb.name, wb.code
+		FROM warehouse_bases wb
+		WHERE EXISTS (
+			SELECT 1
+			FROM company_warehouse_contracts cwc
+			WHERE cwc.warehouse_base_id = wb.id
+				AND cwc.company_id = ?
+				AND ` + contractPeriodCondition("cwc") + `
+		)
+		ORDER BY wb.id
 	`
 
-	rows, err := r.db.QueryContext(ctx, query)
+	rows, err := r.db.QueryContext(ctx, query, companyID, endDate.Format("2006-01-02"), startDate.Format("2006-01-02"))
 	if err != nil {
 		return nil, fmt.Errorf("failed to query warehouses: %w", err)
 	}
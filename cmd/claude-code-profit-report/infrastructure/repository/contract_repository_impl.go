@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+)
+
+type contractRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewContractRepository(db *sql.DB) repository.ContractRepository {
+	return &contractRepositoryImpl{db: db}
+}
+
+const contractSelect = `
+	SELECT
+		cwc.id,
+		cwc.company_id,
+		c.name,
+		cwc.warehouse_base_id,
+		wb.name,
+		cwc.start_date,
+		cwc.end_date,
+		cwc.created_at
+	FROM company_warehouse_contracts cwc
+	INNER JOIN companies c ON c.id = cwc.company_id
+	INNER JOIN warehouse_bases wb ON wb.id = cwc.warehouse_base_id
+`
+
+// contractPeriodCondition alias の契約期間が startDate〜endDate と1日でも重なる条件
+func contractPeriodCondition(alias string) string {
+	return alias + ".start_date <= ? AND (" + alias + ".end_date IS NULL OR " + alias + ".end_date >= ?)"
+}
+
+func (r *contractRepositoryImpl) List(ctx context.Context, filter repository.ContractFilter) ([]entity.Contract, error) {
+	scope, args, err := companyScope(ctx, "cwc.company_id", filter.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+
+	var conditions []string
+	if scope != "" {
+		conditions = append(conditions, scope)
+	}
+	if filter.CompanyID > 0 {
+		conditions = append(conditions, "cwc.company_id = ?")
+		args = append(args, filter.CompanyID)
+	}
+	if filter.WarehouseID > 0 {
+		conditions = append(conditions, "cwc.warehouse_base_id = ?")
+		args = append(args, filter.WarehouseID)
+	}
+	if filter.StartDate != nil && filter.EndDate != nil {
+		conditions = append(conditions, contractPeriodCondition("cwc"))
+		args = append(args, filter.EndDate.Format("2006-01-02"), filter.StartDate.Format("2006-01-02"))
+	}
+
+	query := contractSelect
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY cwc.company_id, cwc.warehouse_base_id, cwc.start_date"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query contracts: %w", err)
+	}
+	defer rows.Close()
+
+	var contracts []entity.Contract
+	for rows.Next() {
+		contract, err := scanContract(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan contract: %w", err)
+		}
+		contracts = append(contracts, *contract)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return contracts, nil
+}
+
+func (r *contractRepositoryImpl) GetByID(ctx context.Context, id uint64) (*entity.Contract, error) {
+	contract, err := scanContract(r.db.QueryRowContext(ctx, contractSelect+" WHERE cwc.id = ?", id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get contract: %w", err)
+	}
+	if err := checkCompanyAccess(ctx, contract.CompanyID); err != nil {
+		return nil, err
+	}
+
+	return contract, nil
+}
+
+func (r *contractRepositoryImpl) Create(ctx context.Context, contract *entity.Contract) error {
+	if err := requireAdmin(ctx); err != nil {
+		return err
+	}
+
+	var endDate interface{}
+	if contract.EndDate != nil {
+		endDate = contract.EndDate.Format("2006-01-02")
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		INSERT INTO company_warehouse_contracts (company_id, warehouse_base_id, start_date, end_date)
+		VALUES (?, ?, ?, ?)
+	`, contract.CompanyID, contract.WarehouseID, contract.StartDate.Format("2006-01-02"), endDate)
+	if err != nil {
+		return fmt.Errorf("failed to insert contract: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get contract id: %w", err)
+	}
+	contract.ID = uint64(id)
+
+	return nil
+}
+
+func (r *contractRepositoryImpl) End(ctx context.Context, id uint64, endDate time.Time) error {
+	if err := requireAdmin(ctx); err != nil {
+		return err
+	}
+
+	result, err := r.db.ExecContext(ctx, `
+		UPDATE company_warehouse_contracts SET end_date = ? WHERE id = ?
+	`, endDate.Format("2006-01-02"), id)
+	if err != nil {
+		return fmt.Errorf("failed to end contract: %w", err)
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("contract not found: id=%d", id)
+	}
+
+	return nil
+}
+
+func scanContract(row rowScanner) (*entity.Contract, error) {
+	var contract entity.Contract
+	var endDate sql.NullTime
+	if err := row.Scan(
+		&contract.ID,
+		&contract.CompanyID,
+		&contract.CompanyName,
+		&contract.WarehouseID,
+		&contract.WarehouseName,
+		&contract.StartDate,
+		&endDate,
+		&contract.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	contract.StartDate = toLocalDate(contract.StartDate)
+	if endDate.Valid {
+		end := toLocalDate(endDate.Time)
+		contract.EndDate = &end
+	}
+	return &contract, nil
+}
@@ -0,0 +1,294 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+)
+
+// deletedReportLookbackDays 明細・レポートの削除を検出するため、報告済みの合計と現在の合計を比較する日数（チェック日時から遡る）
+const deletedReportLookbackDays = 90
+
+type correctionRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewCorrectionRepository(db *sql.DB) repository.CorrectionRepository {
+	return &correctionRepositoryImpl{db: db}
+}
+
+func (r *correctionRepositoryImpl) GetDailyTotals(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) ([]entity.DailyTotal, error) {
+	salesCondition, salesArgs, err := buildPeriodCondition(ctx, entity.ReportKindSales, "sdr", companyID, warehouseID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	costCondition, costArgs, err := buildPeriodCondition(ctx, entity.ReportKindCost, "cdr", companyID, warehouseID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	// 売上とコストを別々に集計してから合算する（明細同士のJOINによる重複を避ける）
+	query := `
+		SELECT
+			t.company_id,
+			c.name,
+			t.warehouse_base_id,
+			wb.name,
+			t.target_date,
+			SUM(t.sales),
+			SUM(t.cost)
+		FROM (
+			SELECT sdr.company_id, sdr.warehouse_base_id, sdr.target_date,
+				COALESCE(SUM(sdri.amount), 0) AS sales, 0 AS cost
+			FROM sales_daily_reports sdr
+			LEFT JOIN sales_daily_report_items sdri ON sdri.sales_daily_report_id = sdr.id
+			WHERE ` + salesCondition + `
+			GROUP BY sdr.company_id, sdr.warehouse_base_id, sdr.target_date
+			UNION ALL
+			SELECT cdr.company_id, cdr.warehouse_base_id, cdr.target_date,
+				0 AS sales, COALESCE(SUM(cdri.cost_amount), 0) AS cost
+			FROM cost_daily_reports cdr
+			LEFT JOIN cost_daily_report_items cdri ON cdri.cost_daily_report_id = cdr.id
+			WHERE ` + costCondition + `
+			GROUP BY cdr.company_id, cdr.warehouse_base_id, cdr.target_date
+		) t
+		INNER JOIN companies c ON c.id = t.company_id
+		INNER JOIN warehouse_bases wb ON wb.id = t.warehouse_base_id
+		GROUP BY t.company_id, c.name, t.warehouse_base_id, wb.name, t.target_date
+		ORDER BY t.target_date, t.company_id, t.warehouse_base_id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, append(salesArgs, costArgs...)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query daily totals: %w", err)
+	}
+	defer rows.Close()
+
+	var totals []entity.DailyTotal
+	for rows.Next() {
+		var total entity.DailyTotal
+		if err := rows.Scan(
+			&total.CompanyID,
+			&total.CompanyName,
+			&total.WarehouseID,
+			&total.WarehouseName,
+			&total.Date,
+			&total.Sales,
+			&total.Cost,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan daily total: %w", err)
+		}
+		total.Date = toLocalDate(total.Date)
+		totals = append(totals, total)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return totals, nil
+}
+
+func (r *correctionRepositoryImpl) SaveReportedDailyTotals(ctx context.Context, totals []entity.DailyTotal) error {
+	if err := requireAdmin(ctx); err != nil {
+		return err
+	}
+
+	const batchSize = 500
+
+	for start := 0; start < len(totals); start += batchSize {
+		end := start + batchSize
+		if end > len(totals) {
+			end = len(totals)
+		}
+
+		placeholders := make([]string, 0, end-start)
+		args := make([]interface{}, 0, (end-start)*5)
+		for _, total := range totals[start:end] {
+			placeholders = append(placeholders, "(?, ?, ?, ?, ?)")
+			args = append(args, total.CompanyID, total.WarehouseID, total.Date.Format("2006-01-02"), total.Sales, total.Cost)
+		}
+
+		query := `
+			INSERT INTO reported_daily_totals (company_id, warehouse_base_id, target_date, sales, cost)
+			VALUES ` + strings.Join(placeholders, ", ") + `
+			ON DUPLICATE KEY UPDATE sales = VALUES(sales), cost = VALUES(cost)
+		`
+		if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to save reported daily totals: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (r *correctionRepositoryImpl) FindCorrections(ctx context.Context, since, until time.Time) ([]entity.Correction, error) {
+	scope, scopeArgs, err := companyScope(ctx, "k.company_id", 0)
+	if err != nil {
+		return nil, err
+	}
+	if scope != "" {
+		scope = " AND " + scope
+	}
+	// 報告済みの値と同じく、無効化された勘定科目は現在の合計にも含めない
+	salesTitle, costTitle := "", ""
+	if title := activeTitleCondition(ctx, entity.ReportKindSales, "sdr"); title != "" {
+		salesTitle = " AND " + title
+	}
+	if title := activeTitleCondition(ctx, entity.ReportKindCost, "cdr"); title != "" {
+		costTitle = " AND " + title
+	}
+
+	// 日次レポートまたは明細の updated_at が since より後の会社・倉庫・日と、
+	// 直近 deletedReportLookbackDays 日以内で報告済みの合計がある会社・倉庫・日を対象に、
+	// 報告済みのレポート期間（全社・全倉庫のレポートを含む）に含まれるものだけを現在の合計で返す
+	// 明細・レポートの削除は updated_at に現れないため、報告済みの合計との比較で検出する
+	// （値が変わっていない日は DetectCorrections で除外される）
+	query := `
+		WITH k AS (
+			SELECT sdr.company_id, sdr.warehouse_base_id, sdr.target_date
+			FROM sales_daily_reports sdr
+			LEFT JOIN sales_daily_report_items sdri ON sdri.sales_daily_report_id = sdr.id
+			WHERE (sdr.updated_at > ? AND sdr.updated_at <= ?)
+				OR (sdri.updated_at > ? AND sdri.updated_at <= ?)
+			UNION
+			SELECT cdr.company_id, cdr.warehouse_base_id, cdr.target_date
+			FROM cost_daily_reports cdr
+			LEFT JOIN cost_daily_report_items cdri ON cdri.cost_daily_report_id = cdr.id
+			WHERE (cdr.updated_at > ? AND cdr.updated_at <= ?)
+				OR (cdri.updated_at > ? AND cdri.updated_at <= ?)
+			UNION
+			SELECT company_id, warehouse_base_id, target_date
+			FROM reported_daily_totals
+			WHERE target_date >= ?
+		)
+		SELECT
+			k.company_id,
+			c.name,
+			k.warehouse_base_id,
+			wb.name,
+			k.target_date,
+			COALESCE(st.sales, 0) AS sales,
+			COALESCE(ct.cost, 0) AS cost,
+			rdt.sales,
+			rdt.cost
+		FROM k
+		INNER JOIN companies c ON c.id = k.company_id
+		INNER JOIN warehouse_bases wb ON wb.id = k.warehouse_base_id
+		LEFT JOIN (
+			SELECT k.company_id, k.warehouse_base_id, k.target_date, SUM(sdri.amount) AS sales
+			FROM k
+			INNER JOIN sales_daily_reports sdr
+				ON sdr.company_id = k.company_id
+				AND sdr.warehouse_base_id = k.warehouse_base_id
+				AND sdr.target_date = k.target_date` + salesTitle + `
+			INNER JOIN sales_daily_report_items sdri ON sdri.sales_daily_report_id = sdr.id
+			GROUP BY k.company_id, k.warehouse_base_id, k.target_date
+		) st
+			ON st.company_id = k.company_id
+			AND st.warehouse_base_id = k.warehouse_base_id
+			AND st.target_date = k.target_date
+		LEFT JOIN (
+			SELECT k.company_id, k.warehouse_base_id, k.target_date, SUM(cdri.cost_amount) AS cost
+			FROM k
+			INNER JOIN cost_daily_reports cdr
+				ON cdr.company_id = k.company_id
+				AND cdr.warehouse_base_id = k.warehouse_base_id
+				AND cdr.target_date = k.target_date` + costTitle + `
+			INNER JOIN cost_daily_report_items cdri ON cdri.cost_daily_report_id = cdr.id
+			GROUP BY k.company_id, k.warehouse_base_id, k.target_date
+		) ct
+			ON ct.company_id = k.company_id
+			AND ct.warehouse_base_id = k.warehouse_base_id
+			AND ct.target_date = k.target_date
+		LEFT JOIN reported_daily_totals rdt
+			ON rdt.company_id = k.company_id
+			AND rdt.warehouse_base_id = k.warehouse_base_id
+			AND rdt.target_date = k.target_date
+		WHERE EXISTS (
+			SELECT 1
+			FROM report_runs rr
+			WHERE k.target_date BETWEEN rr.start_date AND rr.end_date
+				AND rr.company_id IN (0, k.company_id)
+				AND rr.warehouse_base_id IN (0, k.warehouse_base_id)
+		)` + scope + `
+		ORDER BY k.target_date, k.company_id, k.warehouse_base_id
+	`
+
+	lookbackFrom := until.AddDate(0, 0, -deletedReportLookbackDays).Format("2006-01-02")
+	args := append([]interface{}{since, until, since, until, since, until, since, until, lookbackFrom}, scopeArgs...)
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query corrections: %w", err)
+	}
+	defer rows.Close()
+
+	var corrections []entity.Correction
+	for rows.Next() {
+		var correction entity.Correction
+		var oldSales, oldCost sql.NullFloat64
+		current := &correction.Current
+		if err := rows.Scan(
+			&current.CompanyID,
+			&current.CompanyName,
+			&current.WarehouseID,
+			&current.WarehouseName,
+			&current.Date,
+			&current.Sales,
+			&current.Cost,
+			&oldSales,
+			&oldCost,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan correction: %w", err)
+		}
+		current.Date = toLocalDate(current.Date)
+		correction.OldSales = oldSales.Float64
+		correction.OldCost = oldCost.Float64
+		correction.OldKnown = oldSales.Valid
+		corrections = append(corrections, correction)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return corrections, nil
+}
+
+func (r *correctionRepositoryImpl) GetLastCheckedAt(ctx context.Context) (time.Time, bool, error) {
+	var checkedAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, `SELECT MAX(checked_at) FROM correction_checks`).Scan(&checkedAt)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to get last correction check: %w", err)
+	}
+	if checkedAt.Valid {
+		return checkedAt.Time, true, nil
+	}
+
+	// 初回は最初のレポート保存時点から検出する
+	err = r.db.QueryRowContext(ctx, `SELECT MIN(created_at) FROM report_runs`).Scan(&checkedAt)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to get first report run: %w", err)
+	}
+	return checkedAt.Time, checkedAt.Valid, nil
+}
+
+func (r *correctionRepositoryImpl) SaveCheck(ctx context.Context, since, checkedAt time.Time, corrections int) error {
+	if err := requireAdmin(ctx); err != nil {
+		return err
+	}
+
+	_, err := r.db.ExecContext(ctx, `
+		INSERT INTO correction_checks (checked_since, checked_at, corrections)
+		VALUES (?, ?, ?)
+	`, since, checkedAt, corrections)
+	if err != nil {
+		return fmt.Errorf("failed to save correction check: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+)
+
+// 出荷数量比の配賦で数量を集計する売上科目
+const shipmentAccountTitleCode = "shipment"
+
+type costAllocationRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewCostAllocationRepository(db *sql.DB) repository.CostAllocationRepository {
+	return &costAllocationRepositoryImpl{db: db}
+}
+
+// 配賦の基準は倉庫を利用する全社の実績から求めるため、参照範囲で絞り込むと配賦額が変わってしまう。
+// 絞り込まずに管理者のみに限定する。
+func (r *costAllocationRepositoryImpl) GetAllocationRules(ctx context.Context) ([]entity.AllocationRule, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT
+			car.id,
+			wb.id,
+			wb.name,
+			cat.id,
+			cat.code,
+			cat.name,
+			car.method
+		FROM cost_allocation_rules car
+		INNER JOIN warehouse_bases wb ON wb.id = car.warehouse_base_id
+		INNER JOIN cost_account_titles cat ON cat.id = car.cost_account_title_id
+		WHERE car.disabled = 0
+		ORDER BY wb.id, cat.id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cost allocation rules: %w", err)
+	}
+	defer rows.Close()
+
+	var rules []entity.AllocationRule
+	index := make(map[uint]int)
+	for rows.Next() {
+		var rule entity.AllocationRule
+		if err := rows.Scan(
+			&rule.ID,
+			&rule.WarehouseID,
+			&rule.WarehouseName,
+			&rule.CostAccountTitleID,
+			&rule.CostAccountTitleCode,
+			&rule.CostAccountTitleName,
+			&rule.Method,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan cost allocation rule: %w", err)
+		}
+		index[rule.ID] = len(rules)
+		rules = append(rules, rule)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	shareRows, err := r.db.QueryContext(ctx, `
+		SELECT
+			cas.cost_allocation_rule_id,
+			c.id,
+			c.name,
+			cas.percentage
+		FROM cost_allocation_shares cas
+		INNER JOIN cost_allocation_rules car ON car.id = cas.cost_allocation_rule_id
+		INNER JOIN companies c ON c.id = cas.company_id
+		WHERE car.disabled = 0
+		ORDER BY cas.cost_allocation_rule_id, c.id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cost allocation shares: %w", err)
+	}
+	defer shareRows.Close()
+
+	for shareRows.Next() {
+		var ruleID uint
+		var share entity.AllocationShare
+		if err := shareRows.Scan(&ruleID, &share.CompanyID, &share.CompanyName, &share.Percentage); err != nil {
+			return nil, fmt.Errorf("failed to scan cost allocation share: %w", err)
+		}
+		if i, ok := index[ruleID]; ok {
+			rules[i].Shares = append(rules[i].Shares, share)
+		}
+	}
+	if err := shareRows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return rules, nil
+}
+
+func (r *costAllocationRepositoryImpl) GetShippedQuantities(ctx context.Context, warehouseID uint, startDate, endDate time.Time) ([]entity.CompanyQuantity, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+	titleCondition := ""
+	if title := activeTitleCondition(ctx, entity.ReportKindSales, "sdr"); title != "" {
+		titleCondition = " AND " + title
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT
+			c.id,
+			c.name,
+			sdr.target_date,
+			COALESCE(SUM(sdri.quantity), 0)
+		FROM sales_daily_reports sdr
+		INNER JOIN sales_account_titles sat ON sat.id = sdr.sales_account_title_id
+		INNER JOIN sales_daily_report_items sdri ON sdri.sales_daily_report_id = sdr.id
+		INNER JOIN companies c ON c.id = sdr.company_id
+		WHERE sdr.warehouse_base_id = ?
+			AND sdr.target_date BETWEEN ? AND ?
+			AND sat.code = ?`+titleCondition+`
+		GROUP BY c.id, c.name, sdr.target_date
+		ORDER BY sdr.target_date, c.id
+	`, warehouseID, startDate, endDate, shipmentAccountTitleCode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query shipped quantities: %w", err)
+	}
+	defer rows.Close()
+
+	var quantities []entity.CompanyQuantity
+	for rows.Next() {
+		var quantity entity.CompanyQuantity
+		if err := rows.Scan(&quantity.CompanyID, &quantity.CompanyName, &quantity.Date, &quantity.Quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan shipped quantity: %w", err)
+		}
+		quantity.Date = toLocalDate(quantity.Date)
+		quantities = append(quantities, quantity)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return quantities, nil
+}
@@ -20,6 +20,14 @@ func NewCostRepository(db *sql.DB) repository.CostRepository {
 }
 
 func (r *costRepositoryImpl) GetDailyReportsByPeriod(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) ([]entity.CostDailyReport, error) {
+	if err := checkCompanyAccess(ctx, companyID); err != nil {
+		return nil, err
+	}
+	titleCondition := ""
+	if title := activeTitleCondition(ctx, entity.ReportKindCost, "cdr"); title != "" {
+		titleCondition = " AND " + title
+	}
+
 	query := `
 		SELECT 
 			cdr.id,
@@ -30,7 +38,7 @@ func (r *costRepositoryImpl) GetDailyReportsByPeriod(ctx context.Context, compan
 		FROM cost_daily_reports cdr
 		WHERE cdr.company_id = ?
 			AND cdr.warehouse_base_id = ?
-			AND cdr.target_date BETWEEN ? AND ?
+			AND cdr.target_date BETWEEN ? AND ?` + titleCondition + `
 		ORDER BY cdr.target_date, cdr.id
 	`
 
@@ -113,62 +121,21 @@ func (r *costRepositoryImpl) getReportItems(ctx context.Context, reportID uint64
 }
 
 func (r *costRepositoryImpl) GetDailySummaryByPeriod(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) (map[time.Time]float64, error) {
-	var query string
-	var args []interface{}
-
-	if companyID == 0 && warehouseID == 0 {
-		query = `
-			SELECT 
-				cdr.target_date,
-				COALESCE(SUM(cdri.cost_amount), 0) as total_amount
-			FROM cost_daily_reports cdr
-			LEFT JOIN cost_daily_report_items cdri ON cdr.id = cdri.cost_daily_report_id
-			WHERE cdr.target_date BETWEEN ? AND ?
-			GROUP BY cdr.target_date
-			ORDER BY cdr.target_date
-		`
-		args = []interface{}{startDate, endDate}
-	} else if companyID > 0 && warehouseID == 0 {
-		query = `
-			SELECT 
-				cdr.target_date,
-				COALESCE(SUM(cdri.cost_amount), 0) as total_amount
-			FROM cost_daily_reports cdr
-			LEFT JOIN cost_daily_report_items cdri ON cdr.id = cdri.cost_daily_report_id
-			WHERE cdr.company_id = ?
-				AND cdr.target_date BETWEEN ? AND ?
-			GROUP BY cdr.target_date
-			ORDER BY cdr.target_date
-		`
-		args = []interface{}{companyID, startDate, endDate}
-	} else if companyID == 0 && warehouseID > 0 {
-		query = `
-			SELECT 
-				cdr.target_date,
-				COALESCE(SUM(cdri.cost_amount), 0) as total_amount
-			FROM cost_daily_reports cdr
-			LEFT JOIN cost_daily_report_items cdri ON cdr.id = cdri.cost_daily_report_id
-			WHERE cdr.warehouse_base_id = ?
-				AND cdr.target_date BETWEEN ? AND ?
-			GROUP BY cdr.target_date
-			ORDER BY cdr.target_date
-		`
-		args = []interface{}{warehouseID, startDate, endDate}
-	} else {
-		query = `
-			SELECT 
-				cdr.target_date,
-				COALESCE(SUM(cdri.cost_amount), 0) as total_amount
-			FROM cost_daily_reports cdr
-			LEFT JOIN cost_daily_report_items cdri ON cdr.id = cdri.cost_daily_report_id
-			WHERE cdr.company_id = ?
-				AND cdr.warehouse_base_id = ?
-				AND cdr.target_date BETWEEN ? AND ?
-			GROUP BY cdr.target_date
-			ORDER BY cdr.target_date
-		`
-		args = []interface{}{companyID, warehouseID, startDate, endDate}
+	// 会社・倉庫の指定有無にかかわらず同じ条件の組み立てを通し、全社の集計も利用者の参照範囲に限定する
+	condition, args, err := buildPeriodCondition(ctx, entity.ReportKindCost, "cdr", companyID, warehouseID, startDate, endDate)
+	if err != nil {
+		return nil, err
 	}
+	query := `
+		SELECT 
+			cdr.target_date,
+			COALESCE(SUM(cdri.cost_amount), 0) as total_amount
+		FROM cost_daily_reports cdr
+		LEFT JOIN cost_daily_report_items cdri ON cdr.id = cdri.cost_daily_report_id
+		WHERE ` + condition + `
+		GROUP BY cdr.target_date
+		ORDER BY cdr.target_date
+	`
 
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -198,4 +165,60 @@ func (r *costRepositoryImpl) GetDailySummaryByPeriod(ctx context.Context, compan
 	}
 
 	return summary, nil
-}
\ No newline at end of file
+}
+func (r *costRepositoryImpl) GetAccountTitleAmountsByPeriod(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) ([]entity.AccountTitleAmount, error) {
+	condition, args, err := buildPeriodCondition(ctx, entity.ReportKindCost, "cdr", companyID, warehouseID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	query := `
+		SELECT 
+			c.id,
+			c.name,
+			wb.id,
+			wb.name,
+			cdr.target_date,
+			cat.id,
+			cat.code,
+			cat.name,
+			cat.disabled,
+			COALESCE(SUM(cdri.cost_amount), 0) as total_amount
+		FROM cost_daily_reports cdr
+		INNER JOIN companies c ON c.id = cdr.company_id
+		INNER JOIN warehouse_bases wb ON wb.id = cdr.warehouse_base_id
+		INNER JOIN cost_account_titles cat ON cat.id = cdr.cost_account_title_id
+		LEFT JOIN cost_daily_report_items cdri ON cdr.id = cdri.cost_daily_report_id
+		WHERE ` + condition + `
+		GROUP BY c.id, c.name, wb.id, wb.name, cdr.target_date, cat.id, cat.code, cat.name, cat.disabled
+		ORDER BY c.id, wb.id, cdr.target_date, cat.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query cost account title amounts: %w", err)
+	}
+	defer rows.Close()
+
+	return scanAccountTitleAmounts(rows)
+}
+
+func (r *costRepositoryImpl) GetDisabledTitleTotalByPeriod(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) (float64, error) {
+	condition, args, err := buildPeriodCondition(ctx, entity.ReportKindCost, "cdr", companyID, warehouseID, startDate, endDate)
+	if err != nil {
+		return 0, err
+	}
+	query := `
+		SELECT COALESCE(SUM(cdri.cost_amount), 0)
+		FROM cost_daily_reports cdr
+		INNER JOIN cost_account_titles cat ON cat.id = cdr.cost_account_title_id
+		INNER JOIN cost_daily_report_items cdri ON cdri.cost_daily_report_id = cdr.id
+		WHERE ` + condition + `
+			AND cat.disabled = 1
+	`
+
+	var total float64
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to query cost amount of disabled account titles: %w", err)
+	}
+	return total, nil
+}
@@ -0,0 +1,247 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+)
+
+const importBatchSelect = `
+	SELECT id, source, created_by, report_count, item_count, rolled_back_at, created_at
+	FROM import_batches
+`
+
+type importBatchRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewImportBatchRepository(db *sql.DB) repository.ImportBatchRepository {
+	return &importBatchRepositoryImpl{db: db}
+}
+
+func (r *importBatchRepositoryImpl) List(ctx context.Context, limit int) ([]entity.ImportBatch, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	query := importBatchSelect + ` ORDER BY id DESC`
+	var args []interface{}
+	if limit > 0 {
+		query += ` LIMIT ?`
+		args = append(args, limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query import batches: %w", err)
+	}
+	defer rows.Close()
+
+	var batches []entity.ImportBatch
+	for rows.Next() {
+		batch, err := scanImportBatch(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan import batch: %w", err)
+		}
+		batches = append(batches, *batch)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return batches, nil
+}
+
+func (r *importBatchRepositoryImpl) GetByID(ctx context.Context, id uint64) (*entity.ImportBatch, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	batch, err := scanImportBatch(r.db.QueryRowContext(ctx, importBatchSelect+` WHERE id = ?`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get import batch: %w", err)
+	}
+	return batch, nil
+}
+
+// バッチが作成・更新したレポート
+type importBatchReport struct {
+	kind            entity.ReportKind
+	reportID        uint64
+	created         bool
+	previousBatchID sql.NullInt64
+}
+
+func (r *importBatchRepositoryImpl) Rollback(ctx context.Context, id uint64, dryRun bool) (*entity.ImportBatchRollback, error) {
+	if err := requireAdmin(ctx); err != nil {
+		return nil, err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var rolledBackAt sql.NullTime
+	err = tx.QueryRowContext(ctx, `SELECT rolled_back_at FROM import_batches WHERE id = ? FOR UPDATE`, id).Scan(&rolledBackAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, fmt.Errorf("import batch not found: %d", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get import batch: %w", err)
+	}
+	if rolledBackAt.Valid {
+		return nil, fmt.Errorf("import batch %d was already rolled back at %s", id, rolledBackAt.Time.Format("2006-01-02 15:04:05"))
+	}
+
+	reports, err := listImportBatchReports(ctx, tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entity.ImportBatchRollback{BatchID: id, DryRun: dryRun}
+	for _, report := range reports {
+		if err := rollbackImportBatchReport(ctx, tx, id, report, result); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE import_batches SET rolled_back_at = CURRENT_TIMESTAMP WHERE id = ?`, id); err != nil {
+		return nil, fmt.Errorf("failed to update import batch: %w", err)
+	}
+
+	if dryRun {
+		return result, nil
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit rollback: %w", err)
+	}
+	return result, nil
+}
+
+func listImportBatchReports(ctx context.Context, tx *sql.Tx, batchID uint64) ([]importBatchReport, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT kind, report_id, created, previous_import_batch_id
+		FROM import_batch_reports
+		WHERE import_batch_id = ?
+		ORDER BY id
+	`, batchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query import batch reports: %w", err)
+	}
+	defer rows.Close()
+
+	var reports []importBatchReport
+	for rows.Next() {
+		var report importBatchReport
+		if err := rows.Scan(&report.kind, &report.reportID, &report.created, &report.previousBatchID); err != nil {
+			return nil, fmt.Errorf("failed to scan import batch report: %w", err)
+		}
+		reports = append(reports, report)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return reports, nil
+}
+
+// rollbackImportBatchReport バッチの明細を削除し、作成したレポートは削除、置き換えたレポートは置き換え前の明細に戻す
+func rollbackImportBatchReport(ctx context.Context, tx *sql.Tx, batchID uint64, report importBatchReport, result *entity.ImportBatchRollback) error {
+	t, ok := reportTablesByKind[report.kind]
+	if !ok {
+		return fmt.Errorf("unknown report kind: %s", report.kind)
+	}
+
+	// 後続のバッチが同じレポートを更新している場合は、そのバッチの内容を消さないように先にロールバックさせる
+	var currentBatchID sql.NullInt64
+	err := tx.QueryRowContext(ctx, `SELECT import_batch_id FROM `+t.reports+` WHERE id = ? FOR UPDATE`, report.reportID).Scan(&currentBatchID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return fmt.Errorf("%s daily report %d no longer exists", report.kind, report.reportID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get %s daily report: %w", report.kind, err)
+	}
+	if !currentBatchID.Valid || uint64(currentBatchID.Int64) != batchID {
+		return fmt.Errorf("%s daily report %d was updated after import batch %d (current batch: %s): roll back the later batch first",
+			report.kind, report.reportID, batchID, formatBatchID(currentBatchID))
+	}
+
+	deleted, err := tx.ExecContext(ctx, `DELETE FROM `+t.items+` WHERE `+t.reportFK+` = ?`, report.reportID)
+	if err != nil {
+		return fmt.Errorf("failed to delete %s daily report items: %w", report.kind, err)
+	}
+	affected, err := deleted.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	result.DeletedItems += int(affected)
+
+	if report.created {
+		if _, err := tx.ExecContext(ctx, `DELETE FROM `+t.reports+` WHERE id = ?`, report.reportID); err != nil {
+			return fmt.Errorf("failed to delete %s daily report: %w", report.kind, err)
+		}
+		result.DeletedReports++
+		return nil
+	}
+
+	// 置き換え前の明細は元の ID・取り込みバッチ・作成日時のまま戻す
+	restored, err := tx.ExecContext(ctx, `
+		INSERT INTO `+t.items+`
+			(id, `+t.reportFK+`, size, quantity, `+t.priceColumn+`, `+t.amountColumn+`, import_batch_id, created_at, updated_at)
+		SELECT item_id, report_id, size, quantity, price, amount, item_import_batch_id, item_created_at, item_updated_at
+		FROM import_batch_replaced_items
+		WHERE import_batch_id = ? AND kind = ? AND report_id = ?
+		ORDER BY item_id
+	`, batchID, report.kind, report.reportID)
+	if err != nil {
+		return fmt.Errorf("failed to restore %s daily report items: %w", report.kind, err)
+	}
+	affected, err = restored.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+	result.RestoredItems += int(affected)
+
+	// 金額が変わるため、報告済みの値の修正チェック (corrections) で検出されるように updated_at は更新する
+	if _, err := tx.ExecContext(ctx, `UPDATE `+t.reports+` SET import_batch_id = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?`,
+		report.previousBatchID, report.reportID); err != nil {
+		return fmt.Errorf("failed to restore %s daily report: %w", report.kind, err)
+	}
+	result.RestoredReports++
+	return nil
+}
+
+func formatBatchID(id sql.NullInt64) string {
+	if !id.Valid {
+		return "none"
+	}
+	return fmt.Sprintf("%d", id.Int64)
+}
+
+func scanImportBatch(row rowScanner) (*entity.ImportBatch, error) {
+	var batch entity.ImportBatch
+	var rolledBackAt sql.NullTime
+	if err := row.Scan(
+		&batch.ID,
+		&batch.Source,
+		&batch.CreatedBy,
+		&batch.ReportCount,
+		&batch.ItemCount,
+		&rolledBackAt,
+		&batch.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+	if rolledBackAt.Valid {
+		batch.RolledBackAt = &rolledBackAt.Time
+	}
+	return &batch, nil
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+)
+
+type importRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewImportRepository(db *sql.DB) repository.ImportRepository {
+	return &importRepositoryImpl{db: db}
+}
+
+func (r *importRepositoryImpl) ImportDailyReports(ctx context.Context, kind entity.ReportKind, batch *entity.ImportBatch, reports []entity.DailyReportImport, dryRun bool) error {
+	// 担当会社の指定がある利用者（API の ingest 権限など）は担当会社のレポートのみ取り込める
+	for _, report := range reports {
+		if err := checkCompanyAccess(ctx, report.CompanyID); err != nil {
+			return err
+		}
+	}
+	t, ok := reportTablesByKind[kind]
+	if !ok {
+		return fmt.Errorf("unknown report kind: %s", kind)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, `INSERT INTO import_batches (source, created_by) VALUES (?, ?)`, batch.Source, batch.CreatedBy)
+	if err != nil {
+		return fmt.Errorf("failed to create import batch: %w", err)
+	}
+	batchID, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get import batch id: %w", err)
+	}
+	batch.ID = uint64(batchID)
+
+	itemCount := 0
+	for i := range reports {
+		report := &reports[i]
+		if err := importDailyReport(ctx, tx, kind, t, batch.ID, report); err != nil {
+			return err
+		}
+		itemCount += len(report.Rows)
+	}
+
+	if _, err := tx.ExecContext(ctx, `UPDATE import_batches SET report_count = ?, item_count = ? WHERE id = ?`,
+		len(reports), itemCount, batch.ID); err != nil {
+		return fmt.Errorf("failed to update import batch: %w", err)
+	}
+	batch.ReportCount = len(reports)
+	batch.ItemCount = itemCount
+
+	if dryRun {
+		return nil
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit import: %w", err)
+	}
+	return nil
+}
+
+// importDailyReport レポートを作成または更新して明細を置き換え、ロールバックに必要な置き換え前の状態をバッチに記録する
+func importDailyReport(ctx context.Context, tx *sql.Tx, kind entity.ReportKind, t reportTables, batchID uint64, report *entity.DailyReportImport) error {
+	date := report.Date.Format("2006-01-02")
+
+	var previousBatchID sql.NullInt64
+	err := tx.QueryRowContext(ctx, `
+		SELECT id, import_batch_id FROM `+t.reports+`
+		WHERE company_id = ? AND warehouse_base_id = ? AND target_date = ? AND `+t.titleFK+` = ?
+		FOR UPDATE
+	`, report.CompanyID, report.WarehouseID, date, report.AccountTitleID).Scan(&report.ReportID, &previousBatchID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		result, err := tx.ExecContext(ctx, `
+			INSERT INTO `+t.reports+` (company_id, warehouse_base_id, target_date, `+t.titleFK+`, import_batch_id)
+			VALUES (?, ?, ?, ?, ?)
+		`, report.CompanyID, report.WarehouseID, date, report.AccountTitleID, batchID)
+		if err != nil {
+			return fmt.Errorf("failed to insert %s daily report: %w", kind, err)
+		}
+		id, err := result.LastInsertId()
+		if err != nil {
+			return fmt.Errorf("failed to get %s daily report id: %w", kind, err)
+		}
+		report.ReportID = uint64(id)
+		report.Updated = false
+
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO import_batch_reports (import_batch_id, kind, report_id, created) VALUES (?, ?, ?, 1)
+		`, batchID, kind, report.ReportID); err != nil {
+			return fmt.Errorf("failed to record import batch report: %w", err)
+		}
+	case err != nil:
+		return fmt.Errorf("failed to get %s daily report: %w", kind, err)
+	default:
+		report.Updated = true
+
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO import_batch_reports (import_batch_id, kind, report_id, created, previous_import_batch_id)
+			VALUES (?, ?, ?, 0, ?)
+		`, batchID, kind, report.ReportID, previousBatchID); err != nil {
+			return fmt.Errorf("failed to record import batch report: %w", err)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			INSERT INTO import_batch_replaced_items
+				(import_batch_id, kind, report_id, item_id, item_import_batch_id, size, quantity, price, amount, item_created_at, item_updated_at)
+			SELECT ?, ?, `+t.reportFK+`, id, import_batch_id, size, quantity, `+t.priceColumn+`, `+t.amountColumn+`, created_at, updated_at
+			FROM `+t.items+` WHERE `+t.reportFK+` = ?
+		`, batchID, kind, report.ReportID); err != nil {
+			return fmt.Errorf("failed to save replaced %s daily report items: %w", kind, err)
+		}
+		if _, err := tx.ExecContext(ctx, `DELETE FROM `+t.items+` WHERE `+t.reportFK+` = ?`, report.ReportID); err != nil {
+			return fmt.Errorf("failed to delete %s daily report items: %w", kind, err)
+		}
+		if _, err := tx.ExecContext(ctx, `
+			UPDATE `+t.reports+` SET import_batch_id = ?, updated_at = CURRENT_TIMESTAMP WHERE id = ?
+		`, batchID, report.ReportID); err != nil {
+			return fmt.Errorf("failed to update %s daily report: %w", kind, err)
+		}
+	}
+
+	if err := insertImportItems(ctx, tx, t, batchID, report); err != nil {
+		return fmt.Errorf("failed to insert %s daily report items: %w", kind, err)
+	}
+	return nil
+}
+
+func insertImportItems(ctx context.Context, tx *sql.Tx, t reportTables, batchID uint64, report *entity.DailyReportImport) error {
+	if len(report.Rows) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, 0, len(report.Rows))
+	args := make([]interface{}, 0, len(report.Rows)*6)
+	for _, row := range report.Rows {
+		var size interface{}
+		if row.Size != "" {
+			size = row.Size
+		}
+		placeholders = append(placeholders, "(?, ?, ?, ?, ?, ?)")
+		args = append(args, report.ReportID, size, row.Quantity, row.Price, row.Amount, batchID)
+	}
+
+	query := `
+		INSERT INTO ` + t.items + ` (` + t.reportFK + `, size, quantity, ` + t.priceColumn + `, ` + t.amountColumn + `, import_batch_id)
+		VALUES ` + strings.Join(placeholders, ", ")
+	_, err := tx.ExecContext(ctx, query, args...)
+	return err
+}
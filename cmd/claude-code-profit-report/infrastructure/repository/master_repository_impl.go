@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+)
+
+var masterTables = map[entity.MasterKind]string{
+	entity.MasterCompany:           "companies",
+	entity.MasterWarehouse:         "warehouse_bases",
+	entity.MasterSalesAccountTitle: "sales_account_titles",
+	entity.MasterCostAccountTitle:  "cost_account_titles",
+}
+
+type masterRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewMasterRepository(db *sql.DB) repository.MasterRepository {
+	return &masterRepositoryImpl{db: db}
+}
+
+func (r *masterRepositoryImpl) List(ctx context.Context, kind entity.MasterKind, includeDisabled bool) ([]entity.MasterRecord, error) {
+	query, err := masterSelect(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	var conditions []string
+	var args []interface{}
+	if !includeDisabled {
+		conditions = append(conditions, "disabled = 0")
+	}
+	// 会社マスタは API の利用者が参照できる会社に限定する
+	if kind == entity.MasterCompany {
+		scope, scopeArgs, err := companyScope(ctx, "id", 0)
+		if err != nil {
+			return nil, err
+		}
+		if scope != "" {
+			conditions = append(conditions, scope)
+			args = append(args, scopeArgs...)
+		}
+	}
+	for i, condition := range conditions {
+		if i == 0 {
+			query += " WHERE " + condition
+		} else {
+			query += " AND " + condition
+		}
+	}
+	query += " ORDER BY code"
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s master: %w", kind, err)
+	}
+	defer rows.Close()
+
+	var records []entity.MasterRecord
+	for rows.Next() {
+		record, err := scanMasterRecord(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan %s master: %w", kind, err)
+		}
+		records = append(records, *record)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return records, nil
+}
+
+func (r *masterRepositoryImpl) GetByCode(ctx context.Context, kind entity.MasterKind, code string) (*entity.MasterRecord, error) {
+	query, err := masterSelect(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := scanMasterRecord(r.db.QueryRowContext(ctx, query+" WHERE code = ?", code))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get %s master: %w", kind, err)
+	}
+	if kind == entity.MasterCompany {
+		if err := checkCompanyAccess(ctx, record.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	return record, nil
+}
+
+func (r *masterRepositoryImpl) Apply(ctx context.Context, kind entity.MasterKind, changes []entity.MasterChange) error {
+	if err := requireAdmin(ctx); err != nil {
+		return err
+	}
+	table, ok := masterTables[kind]
+	if !ok {
+		return fmt.Errorf("unknown master kind: %s", kind)
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, change := range changes {
+		record := change.After
+		switch change.Action {
+		case entity.MasterActionCreate:
+			query := "INSERT INTO " + table + " (code, name, disabled) VALUES (?, ?, ?)"
+			args := []interface{}{record.Code, record.Name, record.Disabled}
+			if kind.IsAccountTitle() {
+				query = "INSERT INTO " + table + " (code, name, disabled, taxable) VALUES (?, ?, ?, ?)"
+				args = append(args, record.Taxable)
+			}
+			if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+				return fmt.Errorf("failed to insert %s master (code=%s): %w", kind, record.Code, err)
+			}
+		case entity.MasterActionUpdate:
+			query := "UPDATE " + table + " SET code = ?, name = ?, disabled = ? WHERE id = ?"
+			args := []interface{}{record.Code, record.Name, record.Disabled, change.Before.ID}
+			if kind.IsAccountTitle() {
+				query = "UPDATE " + table + " SET code = ?, name = ?, disabled = ?, taxable = ? WHERE id = ?"
+				args = []interface{}{record.Code, record.Name, record.Disabled, record.Taxable, change.Before.ID}
+			}
+			if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+				return fmt.Errorf("failed to update %s master (code=%s): %w", kind, change.Before.Code, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit %s master: %w", kind, err)
+	}
+
+	return nil
+}
+
+// masterSelect 会社・倉庫には課税区分がないため常に課税 (1) として取得する
+func masterSelect(kind entity.MasterKind) (string, error) {
+	table, ok := masterTables[kind]
+	if !ok {
+		return "", fmt.Errorf("unknown master kind: %s", kind)
+	}
+	taxable := "1"
+	if kind.IsAccountTitle() {
+		taxable = "taxable"
+	}
+	return "SELECT id, code, name, disabled, " + taxable + " FROM " + table, nil
+}
+
+func scanMasterRecord(row rowScanner) (*entity.MasterRecord, error) {
+	var record entity.MasterRecord
+	if err := row.Scan(
+		&record.ID,
+		&record.Code,
+		&record.Name,
+		&record.Disabled,
+		&record.Taxable,
+	); err != nil {
+		return nil, err
+	}
+	return &record, nil
+}
@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+)
+
+type reportRunRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewReportRunRepository(db *sql.DB) repository.ReportRunRepository {
+	return &reportRunRepositoryImpl{db: db}
+}
+
+func (r *reportRunRepositoryImpl) Create(ctx context.Context, run *entity.ReportRun) error {
+	if err := checkCompanyAccess(ctx, run.Report.CompanyID); err != nil {
+		return err
+	}
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	report := run.Report
+	var outputPath sql.NullString
+	if run.OutputPath != "" {
+		outputPath = sql.NullString{String: run.OutputPath, Valid: true}
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO report_runs (
+			company_id, company_name, warehouse_base_id, warehouse_name,
+			start_date, end_date,
+			total_sales, total_cost, gross_profit, gross_profit_rate, cost_allocated,
+			output_format, destination, output_path
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		report.CompanyID, report.CompanyName, report.WarehouseID, report.WarehouseName,
+		report.StartDate.Format("2006-01-02"), report.EndDate.Format("2006-01-02"),
+		report.TotalSales, report.TotalCost, report.GrossProfit, report.GrossProfitRate, report.CostAllocated,
+		run.OutputFormat, run.Destination, outputPath,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert report run: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return fmt.Errorf("failed to get report run id: %w", err)
+	}
+
+	if len(report.DailyReports) > 0 {
+		placeholders := make([]string, 0, len(report.DailyReports))
+		args := make([]interface{}, 0, len(report.DailyReports)*6)
+		for _, daily := range report.DailyReports {
+			placeholders = append(placeholders, "(?, ?, ?, ?, ?, ?)")
+			args = append(args, id, daily.Date.Format("2006-01-02"),
+				daily.Sales, daily.Cost, daily.GrossProfit, daily.GrossProfitRate)
+		}
+
+		query := `
+			INSERT INTO report_run_rows (
+				report_run_id, target_date, sales, cost, gross_profit, gross_profit_rate
+			) VALUES ` + strings.Join(placeholders, ", ")
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to insert report run rows: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit report run: %w", err)
+	}
+
+	run.ID = uint64(id)
+	return nil
+}
+
+func (r *reportRunRepositoryImpl) GetByID(ctx context.Context, id uint64) (*entity.ReportRun, error) {
+	query := reportRunSelect + ` WHERE id = ?`
+
+	run, err := scanReportRun(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("report run not found: id=%d", id)
+		}
+		return nil, fmt.Errorf("failed to get report run: %w", err)
+	}
+	if err := checkCompanyAccess(ctx, run.Report.CompanyID); err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT target_date, sales, cost, gross_profit, gross_profit_rate
+		FROM report_run_rows
+		WHERE report_run_id = ?
+		ORDER BY target_date
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query report run rows: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var daily entity.DailyProfitReport
+		if err := rows.Scan(
+			&daily.Date,
+			&daily.Sales,
+			&daily.Cost,
+			&daily.GrossProfit,
+			&daily.GrossProfitRate,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan report run row: %w", err)
+		}
+		daily.Date = toLocalDate(daily.Date)
+		run.Report.DailyReports = append(run.Report.DailyReports, daily)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return run, nil
+}
+
+func (r *reportRunRepositoryImpl) List(ctx context.Context, filter repository.ReportRunFilter) ([]entity.ReportRun, error) {
+	var conditions []string
+	var args []interface{}
+	if filter.CompanyID > 0 {
+		conditions = append(conditions, "company_id = ?")
+		args = append(args, filter.CompanyID)
+	}
+	if filter.WarehouseID > 0 {
+		conditions = append(conditions, "warehouse_base_id = ?")
+		args = append(args, filter.WarehouseID)
+	}
+	scope, scopeArgs, err := companyScope(ctx, "company_id", filter.CompanyID)
+	if err != nil {
+		return nil, err
+	}
+	if scope != "" {
+		conditions = append(conditions, scope)
+		args = append(args, scopeArgs...)
+	}
+
+	query := reportRunSelect
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY id DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query report runs: %w", err)
+	}
+	defer rows.Close()
+
+	var runs []entity.ReportRun
+	for rows.Next() {
+		run, err := scanReportRun(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan report run: %w", err)
+		}
+		runs = append(runs, *run)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return runs, nil
+}
+
+const reportRunSelect = `
+	SELECT
+		id, company_id, company_name, warehouse_base_id, warehouse_name,
+		start_date, end_date,
+		total_sales, total_cost, gross_profit, gross_profit_rate, cost_allocated,
+		output_format, destination, output_path, created_at
+	FROM report_runs`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanReportRun(row rowScanner) (*entity.ReportRun, error) {
+	var run entity.ReportRun
+	var outputPath sql.NullString
+	report := &run.Report
+	if err := row.Scan(
+		&run.ID,
+		&report.CompanyID,
+		&report.CompanyName,
+		&report.WarehouseID,
+		&report.WarehouseName,
+		&report.StartDate,
+		&report.EndDate,
+		&report.TotalSales,
+		&report.TotalCost,
+		&report.GrossProfit,
+		&report.GrossProfitRate,
+		&report.CostAllocated,
+		&run.OutputFormat,
+		&run.Destination,
+		&outputPath,
+		&run.CreatedAt,
+	); err != nil {
+		return nil, err
+	}
+
+	report.StartDate = toLocalDate(report.StartDate)
+	report.EndDate = toLocalDate(report.EndDate)
+	run.OutputPath = outputPath.String
+	return &run, nil
+}
+
+// DATE型の値をローカルタイムゾーンの0時に揃える
+func toLocalDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.Local)
+}
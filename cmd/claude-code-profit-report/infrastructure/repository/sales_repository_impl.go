@@ -20,6 +20,14 @@ func NewSalesRepository(db *sql.DB) repository.SalesRepository {
 }
 
 func (r *salesRepositoryImpl) GetDailyReportsByPeriod(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) ([]entity.SalesDailyReport, error) {
+	if err := checkCompanyAccess(ctx, companyID); err != nil {
+		return nil, err
+	}
+	titleCondition := ""
+	if title := activeTitleCondition(ctx, entity.ReportKindSales, "sdr"); title != "" {
+		titleCondition = " AND " + title
+	}
+
 	query := `
 		SELECT 
 			sdr.id,
@@ -30,7 +38,7 @@ func (r *salesRepositoryImpl) GetDailyReportsByPeriod(ctx context.Context, compa
 		FROM sales_daily_reports sdr
 		WHERE sdr.company_id = ?
 			AND sdr.warehouse_base_id = ?
-			AND sdr.target_date BETWEEN ? AND ?
+			AND sdr.target_date BETWEEN ? AND ?` + titleCondition + `
 		ORDER BY sdr.target_date, sdr.id
 	`
 
@@ -113,62 +121,21 @@ func (r *salesRepositoryImpl) getReportItems(ctx context.Context, reportID uint6
 }
 
 func (r *salesRepositoryImpl) GetDailySummaryByPeriod(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) (map[time.Time]float64, error) {
-	var query string
-	var args []interface{}
-
-	if companyID == 0 && warehouseID == 0 {
-		query = `
-			SELECT 
-				sdr.target_date,
-				COALESCE(SUM(sdri.amount), 0) as total_amount
-			FROM sales_daily_reports sdr
-			LEFT JOIN sales_daily_report_items sdri ON sdr.id = sdri.sales_daily_report_id
-			WHERE sdr.target_date BETWEEN ? AND ?
-			GROUP BY sdr.target_date
-			ORDER BY sdr.target_date
-		`
-		args = []interface{}{startDate, endDate}
-	} else if companyID > 0 && warehouseID == 0 {
-		query = `
-			SELECT 
-				sdr.target_date,
-				COALESCE(SUM(sdri.amount), 0) as total_amount
-			FROM sales_daily_reports sdr
-			LEFT JOIN sales_daily_report_items sdri ON sdr.id = sdri.sales_daily_report_id
-			WHERE sdr.company_id = ?
-				AND sdr.target_date BETWEEN ? AND ?
-			GROUP BY sdr.target_date
-			ORDER BY sdr.target_date
-		`
-		args = []interface{}{companyID, startDate, endDate}
-	} else if companyID == 0 && warehouseID > 0 {
-		query = `
-			SELECT 
-				sdr.target_date,
-				COALESCE(SUM(sdri.amount), 0) as total_amount
-			FROM sales_daily_reports sdr
-			LEFT JOIN sales_daily_report_items sdri ON sdr.id = sdri.sales_daily_report_id
-			WHERE sdr.warehouse_base_id = ?
-				AND sdr.target_date BETWEEN ? AND ?
-			GROUP BY sdr.target_date
-			ORDER BY sdr.target_date
-		`
-		args = []interface{}{warehouseID, startDate, endDate}
-	} else {
-		query = `
-			SELECT 
-				sdr.target_date,
-				COALESCE(SUM(sdri.amount), 0) as total_amount
-			FROM sales_daily_reports sdr
-			LEFT JOIN sales_daily_report_items sdri ON sdr.id = sdri.sales_daily_report_id
-			WHERE sdr.company_id = ?
-				AND sdr.warehouse_base_id = ?
-				AND sdr.target_date BETWEEN ? AND ?
-			GROUP BY sdr.target_date
-			ORDER BY sdr.target_date
-		`
-		args = []interface{}{companyID, warehouseID, startDate, endDate}
+	// 会社・倉庫の指定有無にかかわらず同じ条件の組み立てを通し、全社の集計も利用者の参照範囲に限定する
+	condition, args, err := buildPeriodCondition(ctx, entity.ReportKindSales, "sdr", companyID, warehouseID, startDate, endDate)
+	if err != nil {
+		return nil, err
 	}
+	query := `
+		SELECT 
+			sdr.target_date,
+			COALESCE(SUM(sdri.amount), 0) as total_amount
+		FROM sales_daily_reports sdr
+		LEFT JOIN sales_daily_report_items sdri ON sdr.id = sdri.sales_daily_report_id
+		WHERE ` + condition + `
+		GROUP BY sdr.target_date
+		ORDER BY sdr.target_date
+	`
 
 	rows, err := r.db.QueryContext(ctx, query, args...)
 	if err != nil {
@@ -198,4 +165,60 @@ func (r *salesRepositoryImpl) GetDailySummaryByPeriod(ctx context.Context, compa
 	}
 
 	return summary, nil
-}
\ No newline at end of file
+}
+func (r *salesRepositoryImpl) GetAccountTitleAmountsByPeriod(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) ([]entity.AccountTitleAmount, error) {
+	condition, args, err := buildPeriodCondition(ctx, entity.ReportKindSales, "sdr", companyID, warehouseID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	query := `
+		SELECT 
+			c.id,
+			c.name,
+			wb.id,
+			wb.name,
+			sdr.target_date,
+			sat.id,
+			sat.code,
+			sat.name,
+			sat.disabled,
+			COALESCE(SUM(sdri.amount), 0) as total_amount
+		FROM sales_daily_reports sdr
+		INNER JOIN companies c ON c.id = sdr.company_id
+		INNER JOIN warehouse_bases wb ON wb.id = sdr.warehouse_base_id
+		INNER JOIN sales_account_titles sat ON sat.id = sdr.sales_account_title_id
+		LEFT JOIN sales_daily_report_items sdri ON sdr.id = sdri.sales_daily_report_id
+		WHERE ` + condition + `
+		GROUP BY c.id, c.name, wb.id, wb.name, sdr.target_date, sat.id, sat.code, sat.name, sat.disabled
+		ORDER BY c.id, wb.id, sdr.target_date, sat.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sales account title amounts: %w", err)
+	}
+	defer rows.Close()
+
+	return scanAccountTitleAmounts(rows)
+}
+
+func (r *salesRepositoryImpl) GetDisabledTitleTotalByPeriod(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) (float64, error) {
+	condition, args, err := buildPeriodCondition(ctx, entity.ReportKindSales, "sdr", companyID, warehouseID, startDate, endDate)
+	if err != nil {
+		return 0, err
+	}
+	query := `
+		SELECT COALESCE(SUM(sdri.amount), 0)
+		FROM sales_daily_reports sdr
+		INNER JOIN sales_account_titles sat ON sat.id = sdr.sales_account_title_id
+		INNER JOIN sales_daily_report_items sdri ON sdri.sales_daily_report_id = sdr.id
+		WHERE ` + condition + `
+			AND sat.disabled = 1
+	`
+
+	var total float64
+	if err := r.db.QueryRowContext(ctx, query, args...).Scan(&total); err != nil {
+		return 0, fmt.Errorf("failed to query sales amount of disabled account titles: %w", err)
+	}
+	return total, nil
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+)
+
+type taxRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewTaxRepository(db *sql.DB) repository.TaxRepository {
+	return &taxRepositoryImpl{db: db}
+}
+
+func (r *taxRepositoryImpl) GetTaxRates(ctx context.Context) ([]entity.TaxRate, error) {
+	rows, err := r.db.QueryContext(ctx, `
+		SELECT id, name, rate, effective_from
+		FROM tax_rates
+		ORDER BY effective_from
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query tax rates: %w", err)
+	}
+	defer rows.Close()
+
+	var rates []entity.TaxRate
+	for rows.Next() {
+		var rate entity.TaxRate
+		if err := rows.Scan(&rate.ID, &rate.Name, &rate.Rate, &rate.EffectiveFrom); err != nil {
+			return nil, fmt.Errorf("failed to scan tax rate: %w", err)
+		}
+		rate.EffectiveFrom = toLocalDate(rate.EffectiveFrom)
+		rates = append(rates, rate)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return rates, nil
+}
+
+func (r *taxRepositoryImpl) GetTaxableAmounts(ctx context.Context, kind entity.ReportKind, companyID, warehouseID uint, startDate, endDate time.Time) ([]entity.TaxableAmount, error) {
+	t, ok := reportTablesByKind[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown report kind: %s", kind)
+	}
+	condition, args, err := buildPeriodCondition(ctx, kind, "dr", companyID, warehouseID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT
+			dr.target_date,
+			at.taxable,
+			COALESCE(SUM(i.` + t.amountColumn + `), 0)
+		FROM ` + t.reports + ` dr
+		INNER JOIN ` + t.items + ` i ON i.` + t.reportFK + ` = dr.id
+		INNER JOIN ` + t.titles + ` at ON at.id = dr.` + t.titleFK + `
+		WHERE ` + condition + `
+		GROUP BY dr.target_date, at.taxable
+		ORDER BY dr.target_date, at.taxable
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s taxable amounts: %w", kind, err)
+	}
+	defer rows.Close()
+
+	var amounts []entity.TaxableAmount
+	for rows.Next() {
+		var amount entity.TaxableAmount
+		if err := rows.Scan(&amount.Date, &amount.Taxable, &amount.Amount); err != nil {
+			return nil, fmt.Errorf("failed to scan %s taxable amount: %w", kind, err)
+		}
+		amount.Date = toLocalDate(amount.Date)
+		amounts = append(amounts, amount)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return amounts, nil
+}
@@ -0,0 +1,190 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+)
+
+// 売上・コストで異なるテーブル名・カラム名
+type reportTables struct {
+	reports      string
+	items        string
+	reportFK     string
+	titles       string
+	titleFK      string
+	priceColumn  string
+	amountColumn string
+}
+
+var reportTablesByKind = map[entity.ReportKind]reportTables{
+	entity.ReportKindSales: {
+		reports:      "sales_daily_reports",
+		items:        "sales_daily_report_items",
+		reportFK:     "sales_daily_report_id",
+		titles:       "sales_account_titles",
+		titleFK:      "sales_account_title_id",
+		priceColumn:  "price",
+		amountColumn: "amount",
+	},
+	entity.ReportKindCost: {
+		reports:      "cost_daily_reports",
+		items:        "cost_daily_report_items",
+		reportFK:     "cost_daily_report_id",
+		titles:       "cost_account_titles",
+		titleFK:      "cost_account_title_id",
+		priceColumn:  "cost_price",
+		amountColumn: "cost_amount",
+	},
+}
+
+type validationRepositoryImpl struct {
+	db *sql.DB
+}
+
+func NewValidationRepository(db *sql.DB) repository.ValidationRepository {
+	return &validationRepositoryImpl{db: db}
+}
+
+func (r *validationRepositoryImpl) GetDailyReportHeaders(ctx context.Context, kind entity.ReportKind, companyID, warehouseID uint, startDate, endDate time.Time) ([]entity.DailyReportHeader, error) {
+	t, ok := reportTablesByKind[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown report kind: %s", kind)
+	}
+	condition, args, err := buildPeriodCondition(ctx, kind, "dr", companyID, warehouseID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT
+			dr.id,
+			dr.company_id,
+			c.name,
+			dr.warehouse_base_id,
+			wb.name,
+			dr.target_date,
+			dr.` + t.titleFK + `,
+			at.code,
+			at.name,
+			(SELECT COUNT(*) FROM ` + t.items + ` i WHERE i.` + t.reportFK + ` = dr.id)
+		FROM ` + t.reports + ` dr
+		INNER JOIN companies c ON c.id = dr.company_id
+		INNER JOIN warehouse_bases wb ON wb.id = dr.warehouse_base_id
+		INNER JOIN ` + t.titles + ` at ON at.id = dr.` + t.titleFK + `
+		WHERE ` + condition + `
+		ORDER BY dr.company_id, dr.warehouse_base_id, dr.` + t.titleFK + `, dr.target_date
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s daily reports: %w", kind, err)
+	}
+	defer rows.Close()
+
+	var headers []entity.DailyReportHeader
+	for rows.Next() {
+		header := entity.DailyReportHeader{Kind: kind}
+		if err := rows.Scan(
+			&header.ReportID,
+			&header.CompanyID,
+			&header.CompanyName,
+			&header.WarehouseID,
+			&header.WarehouseName,
+			&header.Date,
+			&header.AccountTitleID,
+			&header.AccountTitleCode,
+			&header.AccountTitleName,
+			&header.ItemCount,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan %s daily report: %w", kind, err)
+		}
+		header.Date = toLocalDate(header.Date)
+		headers = append(headers, header)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return headers, nil
+}
+
+func (r *validationRepositoryImpl) FindInvalidItems(ctx context.Context, kind entity.ReportKind, companyID, warehouseID uint, startDate, endDate time.Time, tolerance float64) ([]entity.DailyReportItem, error) {
+	t, ok := reportTablesByKind[kind]
+	if !ok {
+		return nil, fmt.Errorf("unknown report kind: %s", kind)
+	}
+	condition, args, err := buildPeriodCondition(ctx, kind, "dr", companyID, warehouseID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT
+			dr.id,
+			dr.company_id,
+			c.name,
+			dr.warehouse_base_id,
+			wb.name,
+			dr.target_date,
+			dr.` + t.titleFK + `,
+			at.code,
+			at.name,
+			i.id,
+			COALESCE(i.size, ''),
+			i.quantity,
+			i.` + t.priceColumn + `,
+			i.` + t.amountColumn + `
+		FROM ` + t.reports + ` dr
+		INNER JOIN ` + t.items + ` i ON i.` + t.reportFK + ` = dr.id
+		INNER JOIN companies c ON c.id = dr.company_id
+		INNER JOIN warehouse_bases wb ON wb.id = dr.warehouse_base_id
+		INNER JOIN ` + t.titles + ` at ON at.id = dr.` + t.titleFK + `
+		WHERE ` + condition + `
+			AND (i.quantity <= 0 OR ABS(i.` + t.amountColumn + ` - i.quantity * i.` + t.priceColumn + `) > ?)
+		ORDER BY dr.target_date, dr.company_id, dr.warehouse_base_id, dr.` + t.titleFK + `, i.id
+	`
+
+	rows, err := r.db.QueryContext(ctx, query, append(args, tolerance)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s daily report items: %w", kind, err)
+	}
+	defer rows.Close()
+
+	var items []entity.DailyReportItem
+	for rows.Next() {
+		item := entity.DailyReportItem{Header: entity.DailyReportHeader{Kind: kind}}
+		header := &item.Header
+		if err := rows.Scan(
+			&header.ReportID,
+			&header.CompanyID,
+			&header.CompanyName,
+			&header.WarehouseID,
+			&header.WarehouseName,
+			&header.Date,
+			&header.AccountTitleID,
+			&header.AccountTitleCode,
+			&header.AccountTitleName,
+			&item.ItemID,
+			&item.Size,
+			&item.Quantity,
+			&item.Price,
+			&item.Amount,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan %s daily report item: %w", kind, err)
+		}
+		header.Date = toLocalDate(header.Date)
+		items = append(items, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	return items, nil
+}
@@ -0,0 +1,101 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+const serviceName = "claude-code-profit-report"
+
+// ShutdownFunc 未送信のスパンを書き出してトレーサーを停止する
+type ShutdownFunc func(ctx context.Context) error
+
+// Setup 出力先の指定に応じてグローバルのトレーサープロバイダーを設定する
+//
+// 出力先: "" / none (無効), stdout, file:<パス>, otlp
+// otlp の送信先やヘッダーは OTEL_EXPORTER_OTLP_ENDPOINT などの標準の環境変数で指定する
+func Setup(ctx context.Context, target string) (ShutdownFunc, error) {
+	var exporter sdktrace.SpanExporter
+	var file *os.File
+
+	switch {
+	case target == "" || target == "none":
+		return func(context.Context) error { return nil }, nil
+	case target == "stdout":
+		exp, err := newWriterExporter(os.Stdout)
+		if err != nil {
+			return nil, err
+		}
+		exporter = exp
+	case strings.HasPrefix(target, "file:"):
+		path := strings.TrimPrefix(target, "file:")
+		if path == "" {
+			return nil, fmt.Errorf("trace file path is empty")
+		}
+		f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open trace file: %w", err)
+		}
+		exp, err := newWriterExporter(f)
+		if err != nil {
+			f.Close()
+			return nil, err
+		}
+		exporter, file = exp, f
+	case target == "otlp":
+		exp, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create otlp exporter: %w", err)
+		}
+		exporter = exp
+	default:
+		return nil, fmt.Errorf("unsupported trace exporter: %s", target)
+	}
+
+	provider := NewProvider(exporter, sdktrace.WithBatcher(exporter))
+	otel.SetTracerProvider(provider)
+
+	return func(ctx context.Context) error {
+		err := provider.Shutdown(ctx)
+		if file != nil {
+			if closeErr := file.Close(); err == nil && closeErr != nil {
+				err = fmt.Errorf("failed to close trace file: %w", closeErr)
+			}
+		}
+		return err
+	}, nil
+}
+
+// NewProvider サービス名を設定したトレーサープロバイダーを作成する
+// opts を省略した場合はスパンを終了時に同期的に exporter へ渡す（tracetest.InMemoryExporter での検証用）
+func NewProvider(exporter sdktrace.SpanExporter, opts ...sdktrace.TracerProviderOption) *sdktrace.TracerProvider {
+	if len(opts) == 0 {
+		opts = []sdktrace.TracerProviderOption{sdktrace.WithSyncer(exporter)}
+	}
+
+	res, err := resource.Merge(resource.Default(),
+		resource.NewSchemaless(attribute.String("service.name", serviceName)))
+	if err != nil {
+		res = resource.Default()
+	}
+
+	return sdktrace.NewTracerProvider(append(opts, sdktrace.WithResource(res))...)
+}
+
+func newWriterExporter(w io.Writer) (sdktrace.SpanExporter, error) {
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(w))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create stdout exporter: %w", err)
+	}
+	return exporter, nil
+}
@@ -0,0 +1,206 @@
+package tracing
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/usecase"
+)
+
+var (
+	testStartDate = time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local)
+	testEndDate   = time.Date(2025, 1, 3, 0, 0, 0, 0, time.Local)
+)
+
+// 期間の1日目と3日目だけ金額を返す
+func testSummary(amount float64) map[time.Time]float64 {
+	return map[time.Time]float64{
+		testStartDate:                  amount,
+		testStartDate.AddDate(0, 0, 2): amount,
+	}
+}
+
+type stubSalesRepository struct {
+	repository.SalesRepository
+}
+
+func (stubSalesRepository) GetDailySummaryByPeriod(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) (map[time.Time]float64, error) {
+	return testSummary(100), nil
+}
+
+func (stubSalesRepository) GetDisabledTitleTotalByPeriod(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) (float64, error) {
+	return 10, nil
+}
+
+type stubCostRepository struct {
+	repository.CostRepository
+}
+
+func (stubCostRepository) GetDailySummaryByPeriod(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) (map[time.Time]float64, error) {
+	return testSummary(60), nil
+}
+
+func (stubCostRepository) GetDisabledTitleTotalByPeriod(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) (float64, error) {
+	return 5, nil
+}
+
+type stubCompanyRepository struct {
+	repository.CompanyRepository
+}
+
+func (stubCompanyRepository) GetCompanyByID(ctx context.Context, id uint) (*repository.Company, error) {
+	return &repository.Company{ID: id, Name: "company"}, nil
+}
+
+func (stubCompanyRepository) GetWarehouseByID(ctx context.Context, id uint) (*repository.WarehouseBase, error) {
+	return &repository.WarehouseBase{ID: id, Name: "warehouse"}, nil
+}
+
+func TestGenerateProfitReportSpans(t *testing.T) {
+	// 絞り込み条件（会社・倉庫・期間）の属性
+	filters := map[attribute.Key]attribute.Value{
+		AttrCompanyID:   attribute.Int64Value(1),
+		AttrWarehouseID: attribute.Int64Value(2),
+		AttrStartDate:   attribute.StringValue("2025-01-01"),
+		AttrEndDate:     attribute.StringValue("2025-01-03"),
+	}
+
+	tests := []struct {
+		name            string
+		includeDisabled bool
+		// 終了した順のスパン名と、記録された件数（-1: 記録しない）
+		wantSpans []string
+		wantRows  []int64
+	}{
+		{
+			name: "default",
+			wantSpans: []string{
+				"CompanyRepository.GetCompanyByID",
+				"CompanyRepository.GetWarehouseByID",
+				"SalesRepository.GetDailySummaryByPeriod",
+				"CostRepository.GetDailySummaryByPeriod",
+				"ProfitReportUseCase.GenerateProfitReport",
+			},
+			wantRows: []int64{1, 1, 2, 2, -1},
+		},
+		{
+			name:            "include disabled account titles",
+			includeDisabled: true,
+			wantSpans: []string{
+				"CompanyRepository.GetCompanyByID",
+				"CompanyRepository.GetWarehouseByID",
+				"SalesRepository.GetDailySummaryByPeriod",
+				"CostRepository.GetDailySummaryByPeriod",
+				"SalesRepository.GetDisabledTitleTotalByPeriod",
+				"CostRepository.GetDisabledTitleTotalByPeriod",
+				"ProfitReportUseCase.GenerateProfitReport",
+			},
+			wantRows: []int64{1, 1, 2, 2, -1, -1, -1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			exporter := tracetest.NewInMemoryExporter()
+			provider := NewProvider(exporter)
+			previous := otel.GetTracerProvider()
+			otel.SetTracerProvider(provider)
+			t.Cleanup(func() {
+				otel.SetTracerProvider(previous)
+				provider.Shutdown(context.Background())
+			})
+
+			uc := usecase.NewProfitReportUseCase(
+				WrapSalesRepository(stubSalesRepository{}),
+				WrapCostRepository(stubCostRepository{}),
+				WrapCompanyRepository(stubCompanyRepository{}),
+			)
+			ctx := context.Background()
+			if tt.includeDisabled {
+				ctx = entity.WithDisabledAccountTitles(ctx)
+			}
+			if _, err := uc.GenerateProfitReport(ctx, 1, 2, testStartDate, testEndDate); err != nil {
+				t.Fatalf("GenerateProfitReport() error = %v", err)
+			}
+
+			spans := exporter.GetSpans()
+			if len(spans) != len(tt.wantSpans) {
+				t.Fatalf("spans = %d, want %d", len(spans), len(tt.wantSpans))
+			}
+			root := spans[len(spans)-1]
+
+			for i, span := range spans {
+				if span.Name != tt.wantSpans[i] {
+					t.Errorf("spans[%d].Name = %q, want %q", i, span.Name, tt.wantSpans[i])
+				}
+				if !hasAttribute(span.Resource.Attributes(), attribute.String("service.name", serviceName)) {
+					t.Errorf("%s: resource has no service.name %q", span.Name, serviceName)
+				}
+
+				attrs := attributeMap(span.Attributes)
+				if span.Name == root.Name {
+					if got := attrs["report.days"]; got.AsInt64() != 3 {
+						t.Errorf("%s: report.days = %v, want 3", span.Name, got.Emit())
+					}
+				} else {
+					if span.Parent.SpanID() != root.SpanContext.SpanID() {
+						t.Errorf("%s: parent is not %s", span.Name, root.Name)
+					}
+					if _, ok := attrs[AttrDurationMs]; !ok {
+						t.Errorf("%s: %s is not recorded", span.Name, AttrDurationMs)
+					}
+					rows, ok := attrs[AttrRows]
+					switch {
+					case tt.wantRows[i] < 0 && ok:
+						t.Errorf("%s: %s = %v, want not recorded", span.Name, AttrRows, rows.Emit())
+					case tt.wantRows[i] >= 0 && rows.AsInt64() != tt.wantRows[i]:
+						t.Errorf("%s: %s = %v, want %d", span.Name, AttrRows, rows.Emit(), tt.wantRows[i])
+					}
+					if got := attrs["report.include_disabled"].AsBool(); got != tt.includeDisabled {
+						t.Errorf("%s: report.include_disabled = %v, want %v", span.Name, got, tt.includeDisabled)
+					}
+				}
+
+				// 会社・倉庫の取得は ID のみ、それ以外は期間を含めた条件を記録する
+				for key, want := range filters {
+					got, ok := attrs[key]
+					if !ok {
+						if (span.Name == "CompanyRepository.GetCompanyByID" && key != AttrCompanyID) ||
+							(span.Name == "CompanyRepository.GetWarehouseByID" && key != AttrWarehouseID) {
+							continue
+						}
+						t.Errorf("%s: %s is not recorded", span.Name, key)
+						continue
+					}
+					if got != want {
+						t.Errorf("%s: %s = %v, want %v", span.Name, key, got.Emit(), want.Emit())
+					}
+				}
+			}
+		})
+	}
+}
+
+func attributeMap(attrs []attribute.KeyValue) map[attribute.Key]attribute.Value {
+	m := make(map[attribute.Key]attribute.Value, len(attrs))
+	for _, kv := range attrs {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
+
+func hasAttribute(attrs []attribute.KeyValue, want attribute.KeyValue) bool {
+	for _, kv := range attrs {
+		if kv == want {
+			return true
+		}
+	}
+	return false
+}
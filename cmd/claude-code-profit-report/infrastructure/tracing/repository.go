@@ -0,0 +1,579 @@
+package tracing
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/infrastructure/database"
+)
+
+const tracerName = "github.com/taka512/golang/cmd/claude-code-profit-report/infrastructure/tracing"
+
+// スパンに付与する属性のキー
+const (
+	AttrCompanyID   = attribute.Key("report.company_id")
+	AttrWarehouseID = attribute.Key("report.warehouse_id")
+	AttrStartDate   = attribute.Key("report.start_date")
+	AttrEndDate     = attribute.Key("report.end_date")
+	AttrReportKind  = attribute.Key("report.kind")
+	AttrRows        = attribute.Key("db.rows")
+	AttrDurationMs  = attribute.Key("db.duration_ms")
+)
+
+// PeriodAttributes 会社・倉庫・期間の絞り込み条件を属性にする
+func PeriodAttributes(companyID, warehouseID uint, startDate, endDate time.Time) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		AttrCompanyID.Int64(int64(companyID)),
+		AttrWarehouseID.Int64(int64(warehouseID)),
+		AttrStartDate.String(startDate.Format("2006-01-02")),
+		AttrEndDate.String(endDate.Format("2006-01-02")),
+	}
+}
+
+// querySpan リポジトリメソッド1回分のスパン
+type querySpan struct {
+	span  trace.Span
+	start time.Time
+}
+
+// startQuery スパンを開始する（APIの利用者とクエリのタイムアウトも属性に記録する）
+func startQuery(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, *querySpan) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("db.system", "mysql"), attribute.String("db.operation", name)),
+		trace.WithAttributes(attrs...),
+	)
+	if timeout := database.QueryTimeout(ctx); timeout > 0 {
+		span.SetAttributes(attribute.Float64("db.timeout_ms", float64(timeout.Milliseconds())))
+	}
+	if principal, ok := entity.PrincipalFromContext(ctx); ok {
+		span.SetAttributes(attribute.String("enduser.id", principal.Name), attribute.String("enduser.role", string(principal.Role)))
+	}
+	if entity.IncludesDisabledAccountTitles(ctx) {
+		span.SetAttributes(attribute.Bool("report.include_disabled", true))
+	}
+	return ctx, &querySpan{span: span, start: time.Now()}
+}
+
+// end 件数と所要時間を記録してスパンを終了する（rows が負の場合は件数を記録しない）
+func (q *querySpan) end(rows int, err error) {
+	q.span.SetAttributes(AttrDurationMs.Float64(float64(time.Since(q.start).Microseconds()) / 1000))
+	if rows >= 0 {
+		q.span.SetAttributes(AttrRows.Int(rows))
+	}
+	if err != nil {
+		q.span.RecordError(err)
+		q.span.SetStatus(codes.Error, err.Error())
+	}
+	q.span.End()
+}
+
+func found(err error) int {
+	if err != nil {
+		return 0
+	}
+	return 1
+}
+
+type salesRepository struct {
+	next repository.SalesRepository
+}
+
+// WrapSalesRepository 各メソッドをスパンで計測する SalesRepository を返す
+func WrapSalesRepository(next repository.SalesRepository) repository.SalesRepository {
+	return &salesRepository{next: next}
+}
+
+func (r *salesRepository) GetDailyReportsByPeriod(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) ([]entity.SalesDailyReport, error) {
+	ctx, q := startQuery(ctx, "SalesRepository.GetDailyReportsByPeriod", PeriodAttributes(companyID, warehouseID, startDate, endDate)...)
+	reports, err := r.next.GetDailyReportsByPeriod(ctx, companyID, warehouseID, startDate, endDate)
+	q.end(len(reports), err)
+	return reports, err
+}
+
+func (r *salesRepository) GetDailySummaryByPeriod(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) (map[time.Time]float64, error) {
+	ctx, q := startQuery(ctx, "SalesRepository.GetDailySummaryByPeriod", PeriodAttributes(companyID, warehouseID, startDate, endDate)...)
+	summary, err := r.next.GetDailySummaryByPeriod(ctx, companyID, warehouseID, startDate, endDate)
+	q.end(len(summary), err)
+	return summary, err
+}
+
+func (r *salesRepository) GetAccountTitleAmountsByPeriod(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) ([]entity.AccountTitleAmount, error) {
+	ctx, q := startQuery(ctx, "SalesRepository.GetAccountTitleAmountsByPeriod", PeriodAttributes(companyID, warehouseID, startDate, endDate)...)
+	amounts, err := r.next.GetAccountTitleAmountsByPeriod(ctx, companyID, warehouseID, startDate, endDate)
+	q.end(len(amounts), err)
+	return amounts, err
+}
+
+func (r *salesRepository) GetDisabledTitleTotalByPeriod(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) (float64, error) {
+	ctx, q := startQuery(ctx, "SalesRepository.GetDisabledTitleTotalByPeriod", PeriodAttributes(companyID, warehouseID, startDate, endDate)...)
+	total, err := r.next.GetDisabledTitleTotalByPeriod(ctx, companyID, warehouseID, startDate, endDate)
+	q.end(-1, err)
+	return total, err
+}
+
+type costRepository struct {
+	next repository.CostRepository
+}
+
+// WrapCostRepository 各メソッドをスパンで計測する CostRepository を返す
+func WrapCostRepository(next repository.CostRepository) repository.CostRepository {
+	return &costRepository{next: next}
+}
+
+func (r *costRepository) GetDailyReportsByPeriod(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) ([]entity.CostDailyReport, error) {
+	ctx, q := startQuery(ctx, "CostRepository.GetDailyReportsByPeriod", PeriodAttributes(companyID, warehouseID, startDate, endDate)...)
+	reports, err := r.next.GetDailyReportsByPeriod(ctx, companyID, warehouseID, startDate, endDate)
+	q.end(len(reports), err)
+	return reports, err
+}
+
+func (r *costRepository) GetDailySummaryByPeriod(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) (map[time.Time]float64, error) {
+	ctx, q := startQuery(ctx, "CostRepository.GetDailySummaryByPeriod", PeriodAttributes(companyID, warehouseID, startDate, endDate)...)
+	summary, err := r.next.GetDailySummaryByPeriod(ctx, companyID, warehouseID, startDate, endDate)
+	q.end(len(summary), err)
+	return summary, err
+}
+
+func (r *costRepository) GetAccountTitleAmountsByPeriod(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) ([]entity.AccountTitleAmount, error) {
+	ctx, q := startQuery(ctx, "CostRepository.GetAccountTitleAmountsByPeriod", PeriodAttributes(companyID, warehouseID, startDate, endDate)...)
+	amounts, err := r.next.GetAccountTitleAmountsByPeriod(ctx, companyID, warehouseID, startDate, endDate)
+	q.end(len(amounts), err)
+	return amounts, err
+}
+
+func (r *costRepository) GetDisabledTitleTotalByPeriod(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) (float64, error) {
+	ctx, q := startQuery(ctx, "CostRepository.GetDisabledTitleTotalByPeriod", PeriodAttributes(companyID, warehouseID, startDate, endDate)...)
+	total, err := r.next.GetDisabledTitleTotalByPeriod(ctx, companyID, warehouseID, startDate, endDate)
+	q.end(-1, err)
+	return total, err
+}
+
+type companyRepository struct {
+	next repository.CompanyRepository
+}
+
+// WrapCompanyRepository 各メソッドをスパンで計測する CompanyRepository を返す
+func WrapCompanyRepository(next repository.CompanyRepository) repository.CompanyRepository {
+	return &companyRepository{next: next}
+}
+
+func (r *companyRepository) GetCompanyByID(ctx context.Context, id uint) (*repository.Company, error) {
+	ctx, q := startQuery(ctx, "CompanyRepository.GetCompanyByID", AttrCompanyID.Int64(int64(id)))
+	company, err := r.next.GetCompanyByID(ctx, id)
+	q.end(found(err), err)
+	return company, err
+}
+
+func (r *companyRepository) GetWarehouseByID(ctx context.Context, id uint) (*repository.WarehouseBase, error) {
+	ctx, q := startQuery(ctx, "CompanyRepository.GetWarehouseByID", AttrWarehouseID.Int64(int64(id)))
+	warehouse, err := r.next.GetWarehouseByID(ctx, id)
+	q.end(found(err), err)
+	return warehouse, err
+}
+
+func (r *companyRepository) GetAllCompanies(ctx context.Context) ([]repository.Company, error) {
+	ctx, q := startQuery(ctx, "CompanyRepository.GetAllCompanies")
+	companies, err := r.next.GetAllCompanies(ctx)
+	q.end(len(companies), err)
+	return companies, err
+}
+
+func (r *companyRepository) GetWarehousesByCompanyID(ctx context.Context, companyID uint, startDate, endDate time.Time) ([]repository.WarehouseBase, error) {
+	ctx, q := startQuery(ctx, "CompanyRepository.GetWarehousesByCompanyID", PeriodAttributes(companyID, 0, startDate, endDate)...)
+	warehouses, err := r.next.GetWarehousesByCompanyID(ctx, companyID, startDate, endDate)
+	q.end(len(warehouses), err)
+	return warehouses, err
+}
+
+type reportRunRepository struct {
+	next repository.ReportRunRepository
+}
+
+// WrapReportRunRepository 各メソッドをスパンで計測する ReportRunRepository を返す
+func WrapReportRunRepository(next repository.ReportRunRepository) repository.ReportRunRepository {
+	return &reportRunRepository{next: next}
+}
+
+func (r *reportRunRepository) Create(ctx context.Context, run *entity.ReportRun) error {
+	report := run.Report
+	ctx, q := startQuery(ctx, "ReportRunRepository.Create",
+		PeriodAttributes(report.CompanyID, report.WarehouseID, report.StartDate, report.EndDate)...)
+	err := r.next.Create(ctx, run)
+	q.end(len(report.DailyReports), err)
+	return err
+}
+
+func (r *reportRunRepository) GetByID(ctx context.Context, id uint64) (*entity.ReportRun, error) {
+	ctx, q := startQuery(ctx, "ReportRunRepository.GetByID", attribute.Int64("report_run.id", int64(id)))
+	run, err := r.next.GetByID(ctx, id)
+	rows := 0
+	if run != nil {
+		rows = len(run.Report.DailyReports)
+	}
+	q.end(rows, err)
+	return run, err
+}
+
+func (r *reportRunRepository) List(ctx context.Context, filter repository.ReportRunFilter) ([]entity.ReportRun, error) {
+	ctx, q := startQuery(ctx, "ReportRunRepository.List",
+		AttrCompanyID.Int64(int64(filter.CompanyID)),
+		AttrWarehouseID.Int64(int64(filter.WarehouseID)),
+		attribute.Int("report_run.limit", filter.Limit),
+	)
+	runs, err := r.next.List(ctx, filter)
+	q.end(len(runs), err)
+	return runs, err
+}
+
+type correctionRepository struct {
+	next repository.CorrectionRepository
+}
+
+// WrapCorrectionRepository 各メソッドをスパンで計測する CorrectionRepository を返す
+func WrapCorrectionRepository(next repository.CorrectionRepository) repository.CorrectionRepository {
+	return &correctionRepository{next: next}
+}
+
+func (r *correctionRepository) GetDailyTotals(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) ([]entity.DailyTotal, error) {
+	ctx, q := startQuery(ctx, "CorrectionRepository.GetDailyTotals", PeriodAttributes(companyID, warehouseID, startDate, endDate)...)
+	totals, err := r.next.GetDailyTotals(ctx, companyID, warehouseID, startDate, endDate)
+	q.end(len(totals), err)
+	return totals, err
+}
+
+func (r *correctionRepository) SaveReportedDailyTotals(ctx context.Context, totals []entity.DailyTotal) error {
+	ctx, q := startQuery(ctx, "CorrectionRepository.SaveReportedDailyTotals")
+	err := r.next.SaveReportedDailyTotals(ctx, totals)
+	q.end(len(totals), err)
+	return err
+}
+
+func (r *correctionRepository) FindCorrections(ctx context.Context, since, until time.Time) ([]entity.Correction, error) {
+	ctx, q := startQuery(ctx, "CorrectionRepository.FindCorrections",
+		attribute.String("correction.since", since.Format(time.RFC3339)),
+		attribute.String("correction.until", until.Format(time.RFC3339)),
+	)
+	corrections, err := r.next.FindCorrections(ctx, since, until)
+	q.end(len(corrections), err)
+	return corrections, err
+}
+
+func (r *correctionRepository) GetLastCheckedAt(ctx context.Context) (time.Time, bool, error) {
+	ctx, q := startQuery(ctx, "CorrectionRepository.GetLastCheckedAt")
+	checkedAt, ok, err := r.next.GetLastCheckedAt(ctx)
+	rows := 0
+	if ok {
+		rows = 1
+	}
+	q.end(rows, err)
+	return checkedAt, ok, err
+}
+
+func (r *correctionRepository) SaveCheck(ctx context.Context, since, checkedAt time.Time, corrections int) error {
+	ctx, q := startQuery(ctx, "CorrectionRepository.SaveCheck",
+		attribute.String("correction.since", since.Format(time.RFC3339)),
+		attribute.Int("correction.count", corrections),
+	)
+	err := r.next.SaveCheck(ctx, since, checkedAt, corrections)
+	q.end(found(err), err)
+	return err
+}
+
+type validationRepository struct {
+	next repository.ValidationRepository
+}
+
+// WrapValidationRepository 各メソッドをスパンで計測する ValidationRepository を返す
+func WrapValidationRepository(next repository.ValidationRepository) repository.ValidationRepository {
+	return &validationRepository{next: next}
+}
+
+func (r *validationRepository) GetDailyReportHeaders(ctx context.Context, kind entity.ReportKind, companyID, warehouseID uint, startDate, endDate time.Time) ([]entity.DailyReportHeader, error) {
+	ctx, q := startQuery(ctx, "ValidationRepository.GetDailyReportHeaders",
+		append(PeriodAttributes(companyID, warehouseID, startDate, endDate), AttrReportKind.String(string(kind)))...)
+	headers, err := r.next.GetDailyReportHeaders(ctx, kind, companyID, warehouseID, startDate, endDate)
+	q.end(len(headers), err)
+	return headers, err
+}
+
+func (r *validationRepository) FindInvalidItems(ctx context.Context, kind entity.ReportKind, companyID, warehouseID uint, startDate, endDate time.Time, tolerance float64) ([]entity.DailyReportItem, error) {
+	ctx, q := startQuery(ctx, "ValidationRepository.FindInvalidItems",
+		append(PeriodAttributes(companyID, warehouseID, startDate, endDate),
+			AttrReportKind.String(string(kind)), attribute.Float64("validation.tolerance", tolerance))...)
+	items, err := r.next.FindInvalidItems(ctx, kind, companyID, warehouseID, startDate, endDate, tolerance)
+	q.end(len(items), err)
+	return items, err
+}
+
+type taxRepository struct {
+	next repository.TaxRepository
+}
+
+// WrapTaxRepository 各メソッドをスパンで計測する TaxRepository を返す
+func WrapTaxRepository(next repository.TaxRepository) repository.TaxRepository {
+	return &taxRepository{next: next}
+}
+
+func (r *taxRepository) GetTaxRates(ctx context.Context) ([]entity.TaxRate, error) {
+	ctx, q := startQuery(ctx, "TaxRepository.GetTaxRates")
+	rates, err := r.next.GetTaxRates(ctx)
+	q.end(len(rates), err)
+	return rates, err
+}
+
+func (r *taxRepository) GetTaxableAmounts(ctx context.Context, kind entity.ReportKind, companyID, warehouseID uint, startDate, endDate time.Time) ([]entity.TaxableAmount, error) {
+	ctx, q := startQuery(ctx, "TaxRepository.GetTaxableAmounts",
+		append(PeriodAttributes(companyID, warehouseID, startDate, endDate), AttrReportKind.String(string(kind)))...)
+	amounts, err := r.next.GetTaxableAmounts(ctx, kind, companyID, warehouseID, startDate, endDate)
+	q.end(len(amounts), err)
+	return amounts, err
+}
+
+type costAllocationRepository struct {
+	next repository.CostAllocationRepository
+}
+
+// WrapCostAllocationRepository 各メソッドをスパンで計測する CostAllocationRepository を返す
+func WrapCostAllocationRepository(next repository.CostAllocationRepository) repository.CostAllocationRepository {
+	return &costAllocationRepository{next: next}
+}
+
+func (r *costAllocationRepository) GetAllocationRules(ctx context.Context) ([]entity.AllocationRule, error) {
+	ctx, q := startQuery(ctx, "CostAllocationRepository.GetAllocationRules")
+	rules, err := r.next.GetAllocationRules(ctx)
+	q.end(len(rules), err)
+	return rules, err
+}
+
+func (r *costAllocationRepository) GetShippedQuantities(ctx context.Context, warehouseID uint, startDate, endDate time.Time) ([]entity.CompanyQuantity, error) {
+	ctx, q := startQuery(ctx, "CostAllocationRepository.GetShippedQuantities", PeriodAttributes(0, warehouseID, startDate, endDate)...)
+	quantities, err := r.next.GetShippedQuantities(ctx, warehouseID, startDate, endDate)
+	q.end(len(quantities), err)
+	return quantities, err
+}
+
+type apiKeyRepository struct {
+	next repository.APIKeyRepository
+}
+
+// WrapAPIKeyRepository 各メソッドをスパンで計測する APIKeyRepository を返す（キーのハッシュは記録しない）
+func WrapAPIKeyRepository(next repository.APIKeyRepository) repository.APIKeyRepository {
+	return &apiKeyRepository{next: next}
+}
+
+func (r *apiKeyRepository) Create(ctx context.Context, key *entity.APIKey, keyHash string) error {
+	ctx, q := startQuery(ctx, "APIKeyRepository.Create",
+		attribute.String("api_key.role", string(key.Role)), attribute.Int("api_key.companies", len(key.CompanyIDs)))
+	err := r.next.Create(ctx, key, keyHash)
+	q.end(found(err), err)
+	return err
+}
+
+func (r *apiKeyRepository) FindActiveByHash(ctx context.Context, keyHash string) (*entity.APIKey, error) {
+	ctx, q := startQuery(ctx, "APIKeyRepository.FindActiveByHash")
+	key, err := r.next.FindActiveByHash(ctx, keyHash)
+	rows := 0
+	if key != nil {
+		rows = 1
+	}
+	q.end(rows, err)
+	return key, err
+}
+
+func (r *apiKeyRepository) List(ctx context.Context) ([]entity.APIKey, error) {
+	ctx, q := startQuery(ctx, "APIKeyRepository.List")
+	keys, err := r.next.List(ctx)
+	q.end(len(keys), err)
+	return keys, err
+}
+
+func (r *apiKeyRepository) Revoke(ctx context.Context, id uint64) error {
+	ctx, q := startQuery(ctx, "APIKeyRepository.Revoke", attribute.Int64("api_key.id", int64(id)))
+	err := r.next.Revoke(ctx, id)
+	q.end(found(err), err)
+	return err
+}
+
+func (r *apiKeyRepository) TouchLastUsed(ctx context.Context, id uint64) error {
+	ctx, q := startQuery(ctx, "APIKeyRepository.TouchLastUsed", attribute.Int64("api_key.id", int64(id)))
+	err := r.next.TouchLastUsed(ctx, id)
+	q.end(-1, err)
+	return err
+}
+
+type auditLogRepository struct {
+	next repository.AuditLogRepository
+}
+
+// WrapAuditLogRepository 各メソッドをスパンで計測する AuditLogRepository を返す
+func WrapAuditLogRepository(next repository.AuditLogRepository) repository.AuditLogRepository {
+	return &auditLogRepository{next: next}
+}
+
+func (r *auditLogRepository) Create(ctx context.Context, log *entity.AuditLog) error {
+	ctx, q := startQuery(ctx, "AuditLogRepository.Create",
+		attribute.Int64("api_key.id", int64(log.APIKeyID)), AttrCompanyID.Int64(int64(log.CompanyID)))
+	err := r.next.Create(ctx, log)
+	q.end(found(err), err)
+	return err
+}
+
+func (r *auditLogRepository) List(ctx context.Context, filter repository.AuditLogFilter) ([]entity.AuditLog, error) {
+	ctx, q := startQuery(ctx, "AuditLogRepository.List",
+		attribute.Int64("api_key.id", int64(filter.APIKeyID)),
+		AttrCompanyID.Int64(int64(filter.CompanyID)),
+		attribute.Int("audit_log.limit", filter.Limit),
+	)
+	logs, err := r.next.List(ctx, filter)
+	q.end(len(logs), err)
+	return logs, err
+}
+
+type masterRepository struct {
+	next repository.MasterRepository
+}
+
+// WrapMasterRepository 各メソッドをスパンで計測する MasterRepository を返す
+func WrapMasterRepository(next repository.MasterRepository) repository.MasterRepository {
+	return &masterRepository{next: next}
+}
+
+func (r *masterRepository) List(ctx context.Context, kind entity.MasterKind, includeDisabled bool) ([]entity.MasterRecord, error) {
+	ctx, q := startQuery(ctx, "MasterRepository.List",
+		attribute.String("master.kind", string(kind)), attribute.Bool("master.include_disabled", includeDisabled))
+	records, err := r.next.List(ctx, kind, includeDisabled)
+	q.end(len(records), err)
+	return records, err
+}
+
+func (r *masterRepository) GetByCode(ctx context.Context, kind entity.MasterKind, code string) (*entity.MasterRecord, error) {
+	ctx, q := startQuery(ctx, "MasterRepository.GetByCode",
+		attribute.String("master.kind", string(kind)), attribute.String("master.code", code))
+	record, err := r.next.GetByCode(ctx, kind, code)
+	rows := 0
+	if record != nil {
+		rows = 1
+	}
+	q.end(rows, err)
+	return record, err
+}
+
+func (r *masterRepository) Apply(ctx context.Context, kind entity.MasterKind, changes []entity.MasterChange) error {
+	ctx, q := startQuery(ctx, "MasterRepository.Apply", attribute.String("master.kind", string(kind)))
+	err := r.next.Apply(ctx, kind, changes)
+	q.end(len(changes), err)
+	return err
+}
+
+type contractRepository struct {
+	next repository.ContractRepository
+}
+
+// WrapContractRepository 各メソッドをスパンで計測する ContractRepository を返す
+func WrapContractRepository(next repository.ContractRepository) repository.ContractRepository {
+	return &contractRepository{next: next}
+}
+
+func (r *contractRepository) List(ctx context.Context, filter repository.ContractFilter) ([]entity.Contract, error) {
+	attrs := []attribute.KeyValue{
+		AttrCompanyID.Int64(int64(filter.CompanyID)),
+		AttrWarehouseID.Int64(int64(filter.WarehouseID)),
+	}
+	if filter.StartDate != nil && filter.EndDate != nil {
+		attrs = append(attrs,
+			AttrStartDate.String(filter.StartDate.Format("2006-01-02")),
+			AttrEndDate.String(filter.EndDate.Format("2006-01-02")),
+		)
+	}
+	ctx, q := startQuery(ctx, "ContractRepository.List", attrs...)
+	contracts, err := r.next.List(ctx, filter)
+	q.end(len(contracts), err)
+	return contracts, err
+}
+
+func (r *contractRepository) GetByID(ctx context.Context, id uint64) (*entity.Contract, error) {
+	ctx, q := startQuery(ctx, "ContractRepository.GetByID", attribute.Int64("contract.id", int64(id)))
+	contract, err := r.next.GetByID(ctx, id)
+	rows := 0
+	if contract != nil {
+		rows = 1
+	}
+	q.end(rows, err)
+	return contract, err
+}
+
+func (r *contractRepository) Create(ctx context.Context, contract *entity.Contract) error {
+	ctx, q := startQuery(ctx, "ContractRepository.Create",
+		AttrCompanyID.Int64(int64(contract.CompanyID)), AttrWarehouseID.Int64(int64(contract.WarehouseID)))
+	err := r.next.Create(ctx, contract)
+	q.end(-1, err)
+	return err
+}
+
+func (r *contractRepository) End(ctx context.Context, id uint64, endDate time.Time) error {
+	ctx, q := startQuery(ctx, "ContractRepository.End",
+		attribute.Int64("contract.id", int64(id)), AttrEndDate.String(endDate.Format("2006-01-02")))
+	err := r.next.End(ctx, id, endDate)
+	q.end(-1, err)
+	return err
+}
+
+type importRepository struct {
+	next repository.ImportRepository
+}
+
+// WrapImportRepository 各メソッドをスパンで計測する ImportRepository を返す
+func WrapImportRepository(next repository.ImportRepository) repository.ImportRepository {
+	return &importRepository{next: next}
+}
+
+func (r *importRepository) ImportDailyReports(ctx context.Context, kind entity.ReportKind, batch *entity.ImportBatch, reports []entity.DailyReportImport, dryRun bool) error {
+	ctx, q := startQuery(ctx, "ImportRepository.ImportDailyReports",
+		AttrReportKind.String(string(kind)), attribute.String("import.source", batch.Source), attribute.Bool("import.dry_run", dryRun))
+	err := r.next.ImportDailyReports(ctx, kind, batch, reports, dryRun)
+	q.end(len(reports), err)
+	return err
+}
+
+type importBatchRepository struct {
+	next repository.ImportBatchRepository
+}
+
+// WrapImportBatchRepository 各メソッドをスパンで計測する ImportBatchRepository を返す
+func WrapImportBatchRepository(next repository.ImportBatchRepository) repository.ImportBatchRepository {
+	return &importBatchRepository{next: next}
+}
+
+func (r *importBatchRepository) List(ctx context.Context, limit int) ([]entity.ImportBatch, error) {
+	ctx, q := startQuery(ctx, "ImportBatchRepository.List", attribute.Int("import_batch.limit", limit))
+	batches, err := r.next.List(ctx, limit)
+	q.end(len(batches), err)
+	return batches, err
+}
+
+func (r *importBatchRepository) GetByID(ctx context.Context, id uint64) (*entity.ImportBatch, error) {
+	ctx, q := startQuery(ctx, "ImportBatchRepository.GetByID", attribute.Int64("import_batch.id", int64(id)))
+	batch, err := r.next.GetByID(ctx, id)
+	q.end(-1, err)
+	return batch, err
+}
+
+func (r *importBatchRepository) Rollback(ctx context.Context, id uint64, dryRun bool) (*entity.ImportBatchRollback, error) {
+	ctx, q := startQuery(ctx, "ImportBatchRepository.Rollback",
+		attribute.Int64("import_batch.id", int64(id)), attribute.Bool("import.dry_run", dryRun))
+	result, err := r.next.Rollback(ctx, id, dryRun)
+	n := -1
+	if result != nil {
+		n = result.DeletedReports + result.RestoredReports
+	}
+	q.end(n, err)
+	return result, err
+}
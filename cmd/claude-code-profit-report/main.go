@@ -5,64 +5,190 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"strings"
+	"syscall"
 	"time"
 
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/taka512/golang/cmd/claude-code-profit-report/config"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
 	"github.com/taka512/golang/cmd/claude-code-profit-report/infrastructure/database"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/infrastructure/tracing"
 	"github.com/taka512/golang/cmd/claude-code-profit-report/presentation/cli"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/presentation/excel"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/presentation/pdf"
 	"github.com/taka512/golang/cmd/claude-code-profit-report/presentation/slack"
 )
 
 var (
-	companyID   uint
-	warehouseID uint
-	startDate   string
-	endDate     string
-	outputSlack bool
+	companyID    uint
+	warehouseID  uint
+	startDate    string
+	endDate      string
+	outputSlack  bool
+	format       string
+	outputPath   string
+	fontPath     string
+	noArchive    bool
+	showTax      bool
+	noAllocation bool
+	taxBasis     string
+	traceTarget  string
+	timeout      time.Duration
+	queryTimeout time.Duration
+	// 無効化された勘定科目（master list の場合は無効化したマスタ）も対象にする
+	includeDisabled bool
+)
+
+// コマンド全体のスパンと、終了時に未送信のスパンを書き出すための関数
+var (
+	commandSpan   trace.Span
+	traceShutdown tracing.ShutdownFunc
+	cancelTimeout context.CancelFunc
 )
 
+// annotationLongRunning 常駐するコマンドに付与し、--timeout を適用しない
+const annotationLongRunning = "long-running"
+
 func main() {
 	rootCmd := &cobra.Command{
 		Use:   "claude-code-profit-report",
 		Short: "売上・コスト・粗利のトレンドを表示するコマンド",
 		Long:  `指定期間の売上・コスト・粗利のトレンドを表示します。出力先は標準出力またはSlackです。`,
 		RunE:  runCommand,
+		// エラーは中断理由と合わせて reportFailure で表示する
+		SilenceErrors: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			shutdown, err := tracing.Setup(cmd.Context(), traceTarget)
+			if err != nil {
+				return fmt.Errorf("failed to set up tracing: %w", err)
+			}
+			traceShutdown = shutdown
+
+			ctx := cmd.Context()
+			if timeout > 0 && cmd.Annotations[annotationLongRunning] == "" {
+				ctx, cancelTimeout = context.WithTimeout(ctx, timeout)
+			}
+			ctx = database.WithQueryTimeout(ctx, queryTimeout)
+			if includeDisabled {
+				ctx = entity.WithDisabledAccountTitles(ctx)
+			}
+
+			ctx, span := otel.Tracer("github.com/taka512/golang/cmd/claude-code-profit-report").Start(ctx, cmd.CommandPath())
+			commandSpan = span
+			cmd.SetContext(ctx)
+			return nil
+		},
 	}
 
+	rootCmd.PersistentFlags().DurationVar(&timeout, "timeout", 30*time.Minute, "コマンド全体のタイムアウト (0: 無制限、metrics・serve には適用しない)")
+	rootCmd.PersistentFlags().DurationVar(&queryTimeout, "query-timeout", 5*time.Minute, "データベースへの1回の問い合わせのタイムアウト (0: 無制限)")
+
+	rootCmd.PersistentFlags().BoolVar(&includeDisabled, "include-disabled", false, "無効化された勘定科目の日報も集計に含める (合計のうち無効な科目の金額を別に表示)")
+
+	rootCmd.PersistentFlags().StringVar(&traceTarget, "trace", os.Getenv("PROFIT_REPORT_TRACE"),
+		"トレースの出力先 (stdout / file:<パス> / otlp、未指定時: 環境変数PROFIT_REPORT_TRACE、空なら出力しない)")
+
 	rootCmd.Flags().UintVarP(&companyID, "company", "c", 0, "会社ID (オプション: 未指定時は全社)")
 	rootCmd.Flags().UintVarP(&warehouseID, "warehouse", "w", 0, "倉庫ID (オプション: 未指定時は全倉庫)")
 	rootCmd.Flags().StringVarP(&startDate, "start", "s", "", "開始日 (YYYY-MM-DD) (必須)")
 	rootCmd.Flags().StringVarP(&endDate, "end", "e", "", "終了日 (YYYY-MM-DD) (必須)")
 	rootCmd.Flags().BoolVar(&outputSlack, "slack", false, "Slackに出力する")
+	rootCmd.Flags().StringVar(&format, "format", "text", "出力形式 (text / xlsx / pdf)")
+	rootCmd.Flags().StringVarP(&outputPath, "output", "o", "", "出力ファイル (xlsx/pdf時 未指定: profit_report_<開始日>_<終了日>.<形式>)")
+	rootCmd.Flags().StringVar(&fontPath, "font", "", "PDFに埋め込む日本語TrueTypeフォント (未指定時: 環境変数PDF_FONT_PATH)")
+
+	rootCmd.Flags().BoolVar(&noAllocation, "no-allocation", false, "共通費配賦ルールを適用せず計上どおりのコストで集計する")
+	rootCmd.Flags().BoolVar(&showTax, "tax", false, "税抜・税込の金額と税率別の消費税額を表示する (text / xlsx)")
+	rootCmd.Flags().StringVar(&taxBasis, "tax-basis", "exclusive", "元データの金額の扱い (exclusive: 税抜 / inclusive: 税込)")
+	rootCmd.Flags().BoolVar(&noArchive, "no-archive", false, "出力したレポートを report_runs に保存しない (--slack とは併用できない)")
 
 	rootCmd.MarkFlagRequired("start")
 	rootCmd.MarkFlagRequired("end")
 
-	if err := rootCmd.Execute(); err != nil {
-		log.Fatal(err)
+	rootCmd.AddCommand(newRunsCommand())
+	rootCmd.AddCommand(newCorrectionsCommand())
+	rootCmd.AddCommand(newValidateCommand())
+	rootCmd.AddCommand(newAllocationCommand())
+	rootCmd.AddCommand(newABCCommand())
+	rootCmd.AddCommand(newMetricsCommand())
+	rootCmd.AddCommand(newServeCommand())
+	rootCmd.AddCommand(newAPIKeyCommand())
+	rootCmd.AddCommand(newMasterCommand())
+	rootCmd.AddCommand(newContractCommand())
+	rootCmd.AddCommand(newImportCommand())
+	rootCmd.AddCommand(newImportBatchCommand())
+
+	// SIGINT/SIGTERM で実行中のクエリ・送信を中断する
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	err := rootCmd.ExecuteContext(ctx)
+	interrupted := ctx.Err() != nil
+	stop()
+	if cancelTimeout != nil {
+		cancelTimeout()
+	}
+	finishTracing(err)
+	if err != nil {
+		os.Exit(reportFailure(err, interrupted))
+	}
+}
+
+// finishTracing コマンドのスパンを終了し、未送信のスパンを書き出す
+func finishTracing(err error) {
+	if commandSpan != nil {
+		if err != nil {
+			commandSpan.RecordError(err)
+			commandSpan.SetStatus(codes.Error, err.Error())
+		}
+		commandSpan.End()
+	}
+	if traceShutdown != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := traceShutdown(ctx); err != nil {
+			log.Printf("トレース出力エラー: %v", err)
+		}
 	}
 }
 
 func runCommand(cmd *cobra.Command, args []string) error {
-	ctx := context.Background()
+	ctx := cmd.Context()
 
-	start, err := time.Parse("2006-01-02", startDate)
+	start, end, err := parsePeriod(startDate, endDate)
 	if err != nil {
-		return fmt.Errorf("invalid start date format: %w", err)
+		return err
 	}
 
-	end, err := time.Parse("2006-01-02", endDate)
-	if err != nil {
-		return fmt.Errorf("invalid end date format: %w", err)
+	if err := validateFormat(format); err != nil {
+		return err
 	}
 
-	if start.After(end) {
-		return fmt.Errorf("start date must be before or equal to end date")
+	basis := entity.TaxBasis(taxBasis)
+	if basis != entity.TaxBasisExclusive && basis != entity.TaxBasisInclusive {
+		return fmt.Errorf("unsupported tax basis: %s", taxBasis)
+	}
+
+	// Slackに送信したレポートは後から再現・訂正検出できるように必ず保存する
+	if noArchive && outputSlack {
+		return fmt.Errorf("--no-archive cannot be used with --slack")
+	}
+
+	// フォントが見つからない場合はDB接続前にエラーにする
+	var pdfRenderer *pdf.Renderer
+	if format == "pdf" {
+		pdfRenderer, err = pdf.NewRenderer(fontPath)
+		if err != nil {
+			return fmt.Errorf("failed to prepare pdf renderer: %w", err)
+		}
 	}
 
 	dbConfig := database.NewDBConfig()
-	db, err := database.NewDB(dbConfig)
+	db, err := database.NewDB(ctx, dbConfig)
 	if err != nil {
 		return fmt.Errorf("failed to connect to database: %w", err)
 	}
@@ -70,27 +196,100 @@ func runCommand(cmd *cobra.Command, args []string) error {
 
 	container := config.NewContainer(db)
 
-	report, err := container.ProfitReportUseCase.GenerateProfitReport(ctx, companyID, warehouseID, start, end)
+	var report *entity.ProfitReport
+	switch format {
+	case "xlsx":
+		report, err = container.ProfitReportUseCase.GenerateProfitReportWithDetails(ctx, companyID, warehouseID, start, end)
+	default:
+		report, err = container.ProfitReportUseCase.GenerateProfitReport(ctx, companyID, warehouseID, start, end)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to generate profit report: %w", err)
 	}
+	markDone("レポート集計")
 
-	formatter := cli.NewTextFormatter()
-	output := formatter.FormatProfitReport(report)
-	fmt.Print(output)
+	if !noAllocation {
+		if err := container.CostAllocationUseCase.ApplyAllocation(ctx, report); err != nil {
+			return fmt.Errorf("failed to allocate shared cost: %w", err)
+		}
+	}
 
+	if showTax {
+		if err := container.TaxUseCase.ApplyTax(ctx, report, basis); err != nil {
+			return fmt.Errorf("failed to calculate consumption tax: %w", err)
+		}
+	}
+
+	destinations := []string{"stdout"}
+	path := ""
+	if format != "text" {
+		destinations = []string{"file"}
+		path = outputPath
+		if path == "" {
+			path = fmt.Sprintf("profit_report_%s_%s.%s", start.Format("20060102"), end.Format("20060102"), format)
+		}
+	}
+
+	var webhookURL string
 	if outputSlack {
-		webhookURL := os.Getenv("SLACK_HOOK")
+		webhookURL = os.Getenv("SLACK_HOOK")
 		if webhookURL == "" {
 			return fmt.Errorf("SLACK_HOOK environment variable is not set")
 		}
+		destinations = append(destinations, "slack")
+	}
+
+	if err := writeReport(report, format, path, pdfRenderer); err != nil {
+		return err
+	}
+	markDone("レポート出力")
+
+	// 出力内容を後から再現できるように保存する
+	// Slackへの送信より前に保存し、保存できない場合は送信しない（記録のない送信済みレポートを残さない）
+	if !noArchive {
+		run, err := container.ReportRunUseCase.SaveReportRun(ctx, report, format, strings.Join(destinations, ","), path)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("\nレポートを保存しました (実行ID: %d)\n", run.ID)
+		markDone("レポート保存")
+	}
 
+	if outputSlack {
 		slackClient := slack.NewClient(webhookURL)
-		if err := slackClient.SendProfitReport(report); err != nil {
+		if err := slackClient.SendProfitReport(ctx, report); err != nil {
 			return fmt.Errorf("failed to send to slack: %w", err)
 		}
 		fmt.Println("\nSlackに送信しました。")
+		markDone("Slack送信")
 	}
 
 	return nil
-}
\ No newline at end of file
+}
+
+func writeReport(report *entity.ProfitReport, format, path string, pdfRenderer *pdf.Renderer) error {
+	switch format {
+	case "xlsx":
+		if err := excel.NewExporter().WriteFile(path, report); err != nil {
+			return fmt.Errorf("failed to export xlsx: %w", err)
+		}
+		fmt.Printf("Excelファイルを出力しました: %s\n", path)
+	case "pdf":
+		if err := pdfRenderer.WriteFile(path, report); err != nil {
+			return fmt.Errorf("failed to export pdf: %w", err)
+		}
+		fmt.Printf("PDFファイルを出力しました: %s\n", path)
+	default:
+		formatter := cli.NewTextFormatter()
+		output := formatter.FormatProfitReport(report)
+		fmt.Print(output)
+	}
+	return nil
+}
+
+func validateFormat(format string) error {
+	if format != "text" && format != "xlsx" && format != "pdf" {
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+	return nil
+}
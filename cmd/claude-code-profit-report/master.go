@@ -0,0 +1,197 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/config"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/presentation/cli"
+)
+
+func newMasterCommand() *cobra.Command {
+	masterCmd := &cobra.Command{
+		Use:   "master",
+		Short: "会社・倉庫・売上科目・原価科目マスタの表示・登録・更新・無効化",
+		Long: `会社 (company)・倉庫 (warehouse)・売上科目 (sales-title)・原価科目 (cost-title) のマスタを管理します。
+コードの重複と文字数 (コード16文字、名称は会社・倉庫36文字/科目32文字) を検証し、
+--dry-run を指定すると変更内容の表示のみ行います。`,
+	}
+
+	var dryRun bool
+	masterCmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "変更内容を表示するだけで反映しない")
+
+	listCmd := &cobra.Command{
+		Use:   "list <種別>",
+		Short: "マスタをコード順に表示する（--include-disabled で無効化したデータも表示）",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kind, err := entity.ParseMasterKind(args[0])
+			if err != nil {
+				return err
+			}
+
+			return withContainer(cmd, func(ctx context.Context, container *config.Container) error {
+				records, err := container.MasterUseCase.ListMasters(ctx, kind, includeDisabled)
+				if err != nil {
+					return err
+				}
+				fmt.Print(cli.NewTextFormatter().FormatMasterRecords(kind, records))
+				return nil
+			})
+		},
+	}
+
+	var (
+		createCode    string
+		createName    string
+		createTaxable bool
+	)
+	createCmd := &cobra.Command{
+		Use:   "create <種別>",
+		Short: "マスタを登録する",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kind, err := entity.ParseMasterKind(args[0])
+			if err != nil {
+				return err
+			}
+
+			input := entity.MasterInput{
+				LookupCode:   createCode,
+				Code:         &createCode,
+				Name:         &createName,
+				MustNotExist: true,
+			}
+			if cmd.Flags().Changed("taxable") {
+				if !kind.IsAccountTitle() {
+					return fmt.Errorf("--taxable is only available for account titles")
+				}
+				input.Taxable = &createTaxable
+			}
+			return planAndApplyMaster(cmd, kind, []entity.MasterInput{input}, dryRun)
+		},
+	}
+	createCmd.Flags().StringVar(&createCode, "code", "", "コード (必須)")
+	createCmd.Flags().StringVar(&createName, "name", "", "名称 (必須)")
+	createCmd.Flags().BoolVar(&createTaxable, "taxable", true, "課税対象か (科目のみ)")
+	createCmd.MarkFlagRequired("code")
+	createCmd.MarkFlagRequired("name")
+
+	var (
+		updateCode    string
+		updateName    string
+		updateTaxable bool
+	)
+	updateCmd := &cobra.Command{
+		Use:   "update <種別> <コード>",
+		Short: "マスタのコード・名称・課税区分を変更する（指定した項目のみ）",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kind, err := entity.ParseMasterKind(args[0])
+			if err != nil {
+				return err
+			}
+
+			input := entity.MasterInput{LookupCode: args[1], MustExist: true}
+			if cmd.Flags().Changed("code") {
+				input.Code = &updateCode
+			}
+			if cmd.Flags().Changed("name") {
+				input.Name = &updateName
+			}
+			if cmd.Flags().Changed("taxable") {
+				if !kind.IsAccountTitle() {
+					return fmt.Errorf("--taxable is only available for account titles")
+				}
+				input.Taxable = &updateTaxable
+			}
+			if input.Code == nil && input.Name == nil && input.Taxable == nil {
+				return fmt.Errorf("nothing to update: specify --code, --name or --taxable")
+			}
+			return planAndApplyMaster(cmd, kind, []entity.MasterInput{input}, dryRun)
+		},
+	}
+	updateCmd.Flags().StringVar(&updateCode, "code", "", "新しいコード")
+	updateCmd.Flags().StringVar(&updateName, "name", "", "新しい名称")
+	updateCmd.Flags().BoolVar(&updateTaxable, "taxable", true, "課税対象か (科目のみ)")
+
+	disableCmd := newMasterDisabledCommand("disable", "マスタを無効化する（データは削除しない）", true, &dryRun)
+	enableCmd := newMasterDisabledCommand("enable", "無効化したマスタを有効に戻す", false, &dryRun)
+
+	importCmd := &cobra.Command{
+		Use:   "import <種別> <CSVファイル>",
+		Short: "CSVからマスタを一括で登録・更新する（エラーが1行でもあれば何も反映しない）",
+		Long: `CSVからマスタを一括で登録・更新します。
+1行目はヘッダーで code (必須)・name・disabled・taxable (科目のみ) の列を指定できます。
+code が既存のデータは更新、ない場合は登録します。空欄の項目は既存の値を引き継ぎます。`,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kind, err := entity.ParseMasterKind(args[0])
+			if err != nil {
+				return err
+			}
+
+			file, err := os.Open(args[1])
+			if err != nil {
+				return fmt.Errorf("failed to open csv: %w", err)
+			}
+			defer file.Close()
+
+			inputs, err := cli.ReadMasterCSV(file, kind)
+			if err != nil {
+				return err
+			}
+			if len(inputs) == 0 {
+				return fmt.Errorf("csv has no rows: %s", args[1])
+			}
+			return planAndApplyMaster(cmd, kind, inputs, dryRun)
+		},
+	}
+
+	masterCmd.AddCommand(listCmd, createCmd, updateCmd, disableCmd, enableCmd, importCmd)
+	return masterCmd
+}
+
+func newMasterDisabledCommand(use, short string, disabled bool, dryRun *bool) *cobra.Command {
+	return &cobra.Command{
+		Use:   use + " <種別> <コード>",
+		Short: short,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			kind, err := entity.ParseMasterKind(args[0])
+			if err != nil {
+				return err
+			}
+
+			input := entity.MasterInput{LookupCode: args[1], Disabled: &disabled, MustExist: true}
+			return planAndApplyMaster(cmd, kind, []entity.MasterInput{input}, *dryRun)
+		},
+	}
+}
+
+// planAndApplyMaster 変更内容を表示し、エラーがなく dry-run でなければ反映する
+func planAndApplyMaster(cmd *cobra.Command, kind entity.MasterKind, inputs []entity.MasterInput, dryRun bool) error {
+	return withContainer(cmd, func(ctx context.Context, container *config.Container) error {
+		plan, err := container.MasterUseCase.Plan(ctx, kind, inputs)
+		if err != nil {
+			return err
+		}
+		fmt.Print(cli.NewTextFormatter().FormatMasterPlan(plan, dryRun))
+
+		if plan.HasErrors() {
+			return fmt.Errorf("%d invalid rows: nothing was applied", plan.Count(entity.MasterActionInvalid))
+		}
+		if dryRun {
+			return nil
+		}
+
+		if err := container.MasterUseCase.Apply(ctx, plan); err != nil {
+			return err
+		}
+		fmt.Println("\n変更を反映しました。")
+		return nil
+	})
+}
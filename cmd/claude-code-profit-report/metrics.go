@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/config"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/presentation/metrics"
+)
+
+func newMetricsCommand() *cobra.Command {
+	var (
+		listenAddr          string
+		refreshInterval     time.Duration
+		metricsNoAllocation bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "metrics",
+		Short: "売上・コスト・粗利のKPIを Prometheus 形式で公開する",
+		Long: `当日・前日・当月累計の会社・倉庫・勘定科目別の売上・コストと、会社・倉庫別の粗利・粗利率を
+/metrics で公開します。値は --interval ごとにデータベースから再取得します。`,
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{annotationLongRunning: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if refreshInterval <= 0 {
+				return fmt.Errorf("interval must be positive")
+			}
+
+			// 停止シグナルで ctx がキャンセルされるまで公開を続ける
+			return withContainer(cmd, func(ctx context.Context, container *config.Container) error {
+				exporter := metrics.NewExporter()
+				refresh := func() {
+					startedAt := time.Now()
+					// 次の更新までに終わらない場合は打ち切る
+					refreshCtx, cancel := context.WithTimeout(ctx, refreshInterval)
+					defer cancel()
+
+					snapshots, err := container.KPIUseCase.CollectKPIs(refreshCtx, startedAt, !metricsNoAllocation)
+					if err == nil {
+						exporter.Update(snapshots)
+					} else {
+						log.Printf("メトリクス更新エラー: %v", err)
+					}
+					exporter.RecordRefresh(startedAt, err)
+				}
+
+				mux := http.NewServeMux()
+				mux.Handle("/metrics", exporter.Handler())
+				server := &http.Server{Addr: listenAddr, Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+				serverErr := make(chan error, 1)
+				go func() {
+					if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+						serverErr <- err
+					}
+					close(serverErr)
+				}()
+				log.Printf("メトリクスを公開しました: http://%s/metrics (更新間隔: %s)", listenAddr, refreshInterval)
+
+				refresh()
+				ticker := time.NewTicker(refreshInterval)
+				defer ticker.Stop()
+
+				for {
+					select {
+					case <-ticker.C:
+						refresh()
+					case err := <-serverErr:
+						return fmt.Errorf("failed to serve metrics: %w", err)
+					case <-ctx.Done():
+						log.Println("停止シグナルを受信しました。サーバーを停止します...")
+						shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+						defer cancel()
+						return server.Shutdown(shutdownCtx)
+					}
+				}
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&listenAddr, "listen", ":2112", "待ち受けアドレス")
+	cmd.Flags().DurationVar(&refreshInterval, "interval", time.Minute, "データベースからの更新間隔")
+	cmd.Flags().BoolVar(&metricsNoAllocation, "no-allocation", false, "共通費配賦ルールを適用せず計上どおりのコストで集計する")
+
+	return cmd
+}
@@ -0,0 +1,180 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+const (
+	// 金額と数量×単価の許容誤差（import コマンドの既定値と同じ）
+	dailyReportAmountTolerance = 0.01
+	// 取り込みバッチに記録する取り込み元
+	dailyReportSource = "api"
+)
+
+// POST /api/v1/daily-reports のリクエスト（1リクエストで会社・倉庫・日・科目の1レポート）
+type dailyReportRequest struct {
+	Kind          string                   `json:"kind"`
+	CompanyCode   string                   `json:"company_code"`
+	WarehouseCode string                   `json:"warehouse_code"`
+	Date          string                   `json:"date"`
+	TitleCode     string                   `json:"title_code"`
+	Items         []dailyReportItemRequest `json:"items"`
+}
+
+// 単価・金額は未指定と 0 を区別するためポインタで受け取る
+type dailyReportItemRequest struct {
+	Size     string   `json:"size"`
+	Quantity int      `json:"quantity"`
+	Price    *float64 `json:"price"`
+	Amount   *float64 `json:"amount"`
+}
+
+type dailyReportResponse struct {
+	ReportID uint64            `json:"report_id"`
+	BatchID  uint64            `json:"batch_id"`
+	Kind     entity.ReportKind `json:"kind"`
+	Updated  bool              `json:"updated"`
+	Items    int               `json:"items"`
+}
+
+// 明細の番号は items の1始まりの位置
+type dailyReportItemError struct {
+	Item  int    `json:"item"`
+	Error string `json:"error"`
+}
+
+type dailyReportErrorResponse struct {
+	Error string                 `json:"error"`
+	Items []dailyReportItemError `json:"items"`
+}
+
+// handleDailyReport POST /api/v1/daily-reports 日次レポートを登録する
+// 同じ会社・倉庫・日・科目のレポートがある場合は明細を置き換えるため、同じ内容の再送は結果を変えない
+func (s *Server) handleDailyReport(w http.ResponseWriter, r *http.Request) {
+	if mediaType, _, err := mime.ParseMediaType(r.Header.Get("Content-Type")); err != nil || mediaType != "application/json" {
+		writeError(w, http.StatusUnsupportedMediaType, fmt.Errorf("content type must be application/json"))
+		return
+	}
+
+	req, err := s.decodeDailyReportRequest(w, r)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeError(w, http.StatusRequestEntityTooLarge, fmt.Errorf("request body must be at most %d bytes", tooLarge.Limit))
+			return
+		}
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	kind, err := entity.ParseReportKind(req.Kind)
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	rows, err := req.rows()
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	result, err := s.imports.ImportDailyReports(r.Context(), kind, dailyReportSource, rows, dailyReportAmountTolerance, false)
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	if len(result.Rejected) > 0 {
+		res := dailyReportErrorResponse{Error: "daily report has invalid items"}
+		for _, rejection := range result.Rejected {
+			// 他の明細のエラーで巻き添えになった明細は返さない
+			if rejection.CausedBy == 0 {
+				res.Items = append(res.Items, dailyReportItemError{Item: rejection.Line, Error: rejection.Reason})
+			}
+		}
+		writeJSON(w, http.StatusUnprocessableEntity, res)
+		return
+	}
+
+	report := result.Reports[0]
+	status := http.StatusCreated
+	if report.Updated {
+		status = http.StatusOK
+	}
+	writeJSON(w, status, dailyReportResponse{
+		ReportID: report.ReportID,
+		BatchID:  result.BatchID,
+		Kind:     kind,
+		Updated:  report.Updated,
+		Items:    len(report.Rows),
+	})
+}
+
+// decodeDailyReportRequest 本文を maxBodyBytes までに制限し、1つの JSON オブジェクトとして読み込む
+func (s *Server) decodeDailyReportRequest(w http.ResponseWriter, r *http.Request) (*dailyReportRequest, error) {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, s.maxBodyBytes))
+	decoder.DisallowUnknownFields()
+
+	var req dailyReportRequest
+	if err := decoder.Decode(&req); err != nil {
+		return nil, fmt.Errorf("invalid request body: %w", err)
+	}
+	if err := decoder.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("request body must contain a single json object")
+	}
+	return &req, nil
+}
+
+// rows 明細を取り込み用の行に変換する（明細ごとの値の不備は取り込み時に明細のエラーとして返す）
+func (req *dailyReportRequest) rows() ([]entity.DailyReportRow, error) {
+	switch {
+	case req.CompanyCode == "":
+		return nil, fmt.Errorf("company_code is required")
+	case req.WarehouseCode == "":
+		return nil, fmt.Errorf("warehouse_code is required")
+	case req.TitleCode == "":
+		return nil, fmt.Errorf("title_code is required")
+	case len(req.Items) == 0:
+		return nil, fmt.Errorf("items must not be empty")
+	}
+	date, err := time.ParseInLocation(dateLayout, req.Date, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("invalid date: %q", req.Date)
+	}
+
+	rows := make([]entity.DailyReportRow, 0, len(req.Items))
+	for i, item := range req.Items {
+		row := entity.DailyReportRow{
+			Line:          i + 1,
+			CompanyCode:   req.CompanyCode,
+			WarehouseCode: req.WarehouseCode,
+			Date:          date,
+			TitleCode:     req.TitleCode,
+			Size:          item.Size,
+			Quantity:      item.Quantity,
+		}
+		switch {
+		case item.Price == nil:
+			row.ParseError = "price is required"
+		case item.Amount == nil:
+			row.ParseError = "amount is required"
+		default:
+			row.Price = *item.Price
+			row.Amount = *item.Amount
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
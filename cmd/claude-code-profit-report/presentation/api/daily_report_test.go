@@ -0,0 +1,140 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/usecase"
+)
+
+// ingest キーとして認証し、監査ログは捨てる
+type stubAccess struct {
+	usecase.AccessUseCase
+}
+
+func (stubAccess) Authenticate(ctx context.Context, rawKey string) (*entity.Principal, error) {
+	return &entity.Principal{APIKeyID: 1, Name: "ingest", Role: entity.RoleIngest, CompanyIDs: []uint{1}}, nil
+}
+
+func (stubAccess) RecordAudit(ctx context.Context, log *entity.AuditLog) error {
+	return nil
+}
+
+type stubMasterRepository struct {
+	repository.MasterRepository
+}
+
+func (stubMasterRepository) List(ctx context.Context, kind entity.MasterKind, includeDisabled bool) ([]entity.MasterRecord, error) {
+	codes := map[entity.MasterKind]string{
+		entity.MasterCompany:           "AK787",
+		entity.MasterWarehouse:         "AAA",
+		entity.MasterSalesAccountTitle: "S001",
+		entity.MasterCostAccountTitle:  "C001",
+	}
+	return []entity.MasterRecord{{ID: 1, Code: codes[kind]}}, nil
+}
+
+type stubContractRepository struct {
+	repository.ContractRepository
+}
+
+func (stubContractRepository) List(ctx context.Context, filter repository.ContractFilter) ([]entity.Contract, error) {
+	return []entity.Contract{{ID: 1, CompanyID: 1, WarehouseID: 1, StartDate: time.Date(2024, 1, 1, 0, 0, 0, 0, time.Local)}}, nil
+}
+
+// 反映したレポートを記録する
+type stubImportRepository struct {
+	imported []entity.DailyReportImport
+}
+
+func (r *stubImportRepository) ImportDailyReports(ctx context.Context, kind entity.ReportKind, batch *entity.ImportBatch, reports []entity.DailyReportImport, dryRun bool) error {
+	batch.ID = 1
+	for i := range reports {
+		reports[i].ReportID = 1
+	}
+	r.imported = append(r.imported, reports...)
+	return nil
+}
+
+func TestHandleDailyReport(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+		// 反映されたレポートの明細数（0: 反映されない）
+		wantImportedItems int
+		wantItemErrors    []dailyReportItemError
+	}{
+		{
+			name: "all items are valid",
+			body: `{"kind":"sales","company_code":"AK787","warehouse_code":"AAA","date":"2025-01-10","title_code":"S001",
+				"items":[{"size":"S","quantity":2,"price":10,"amount":20},{"size":"M","quantity":1,"price":15,"amount":15}]}`,
+			wantStatus:        http.StatusCreated,
+			wantImportedItems: 2,
+		},
+		{
+			// 価格のない明細があるレポートは、他の明細で既存の明細を置き換えずに 422 を返す
+			name: "item without price rejects the whole report",
+			body: `{"kind":"sales","company_code":"AK787","warehouse_code":"AAA","date":"2025-01-10","title_code":"S001",
+				"items":[{"size":"S","quantity":2,"price":10,"amount":20},{"size":"M","quantity":1,"amount":15}]}`,
+			wantStatus:     http.StatusUnprocessableEntity,
+			wantItemErrors: []dailyReportItemError{{Item: 2, Error: "price is required"}},
+		},
+		{
+			name: "item without amount rejects the whole report",
+			body: `{"kind":"cost","company_code":"AK787","warehouse_code":"AAA","date":"2025-01-10","title_code":"C001",
+				"items":[{"size":"S","quantity":2,"price":10},{"size":"M","quantity":1,"price":15,"amount":15}]}`,
+			wantStatus:     http.StatusUnprocessableEntity,
+			wantItemErrors: []dailyReportItemError{{Item: 1, Error: "amount is required"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			importRepo := &stubImportRepository{}
+			imports := usecase.NewImportUseCase(importRepo, nil, stubMasterRepository{}, stubContractRepository{})
+			server := NewServer(stubAccess{}, nil, nil, nil, imports, nil, 1<<20)
+
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/daily-reports", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			req.Header.Set("Authorization", "Bearer test")
+			rec := httptest.NewRecorder()
+			server.Handler().ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d (body: %s)", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+
+			importedItems := 0
+			for _, report := range importRepo.imported {
+				importedItems += len(report.Rows)
+			}
+			if importedItems != tt.wantImportedItems {
+				t.Errorf("imported items = %d, want %d", importedItems, tt.wantImportedItems)
+			}
+
+			if tt.wantStatus != http.StatusUnprocessableEntity {
+				return
+			}
+			var res dailyReportErrorResponse
+			if err := json.Unmarshal(rec.Body.Bytes(), &res); err != nil {
+				t.Fatalf("failed to decode response: %v", err)
+			}
+			if len(res.Items) != len(tt.wantItemErrors) {
+				t.Fatalf("item errors = %+v, want %+v", res.Items, tt.wantItemErrors)
+			}
+			for i, want := range tt.wantItemErrors {
+				if res.Items[i] != want {
+					t.Errorf("item errors[%d] = %+v, want %+v", i, res.Items[i], want)
+				}
+			}
+		})
+	}
+}
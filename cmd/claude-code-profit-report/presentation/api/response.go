@@ -0,0 +1,165 @@
+package api
+
+import (
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+)
+
+const dateLayout = "2006-01-02"
+
+type profitReportResponse struct {
+	CompanyID                uint                   `json:"company_id"`
+	CompanyName              string                 `json:"company_name"`
+	WarehouseID              uint                   `json:"warehouse_id"`
+	WarehouseName            string                 `json:"warehouse_name"`
+	StartDate                string                 `json:"start_date"`
+	EndDate                  string                 `json:"end_date"`
+	TotalSales               float64                `json:"total_sales"`
+	TotalCost                float64                `json:"total_cost"`
+	GrossProfit              float64                `json:"gross_profit"`
+	GrossProfitRate          float64                `json:"gross_profit_rate"`
+	CostAllocated            bool                   `json:"cost_allocated"`
+	CostAllocationAdjustment float64                `json:"cost_allocation_adjustment"`
+	Inactive                 *inactiveResponse      `json:"inactive,omitempty"`
+	Daily                    []dailyResponse        `json:"daily"`
+	SalesDetails             []accountTitleResponse `json:"sales_details,omitempty"`
+	CostDetails              []accountTitleResponse `json:"cost_details,omitempty"`
+	Tax                      *taxResponse           `json:"tax,omitempty"`
+}
+
+// 無効化された勘定科目の金額（include_disabled=true の場合のみ、合計に含まれる）
+type inactiveResponse struct {
+	Sales float64 `json:"sales"`
+	Cost  float64 `json:"cost"`
+}
+
+type dailyResponse struct {
+	Date            string  `json:"date"`
+	Sales           float64 `json:"sales"`
+	Cost            float64 `json:"cost"`
+	GrossProfit     float64 `json:"gross_profit"`
+	GrossProfitRate float64 `json:"gross_profit_rate"`
+}
+
+type accountTitleResponse struct {
+	CompanyID        uint    `json:"company_id"`
+	CompanyName      string  `json:"company_name"`
+	WarehouseID      uint    `json:"warehouse_id"`
+	WarehouseName    string  `json:"warehouse_name"`
+	Date             string  `json:"date"`
+	AccountTitleCode string  `json:"account_title_code"`
+	AccountTitleName string  `json:"account_title_name"`
+	Disabled         bool    `json:"disabled"`
+	Amount           float64 `json:"amount"`
+}
+
+type taxResponse struct {
+	Basis             entity.TaxBasis        `json:"basis"`
+	SalesExcludingTax float64                `json:"sales_excluding_tax"`
+	SalesTax          float64                `json:"sales_tax"`
+	CostExcludingTax  float64                `json:"cost_excluding_tax"`
+	CostTax           float64                `json:"cost_tax"`
+	NonTaxableSales   float64                `json:"non_taxable_sales"`
+	NonTaxableCost    float64                `json:"non_taxable_cost"`
+	ByRate            []taxBreakdownResponse `json:"by_rate"`
+}
+
+type taxBreakdownResponse struct {
+	Name      string  `json:"name"`
+	Rate      float64 `json:"rate"`
+	SalesBase float64 `json:"sales_base"`
+	SalesTax  float64 `json:"sales_tax"`
+	CostBase  float64 `json:"cost_base"`
+	CostTax   float64 `json:"cost_tax"`
+}
+
+type companyResponse struct {
+	ID   uint   `json:"id"`
+	Name string `json:"name"`
+	Code string `json:"code"`
+}
+
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+func newProfitReportResponse(report *entity.ProfitReport) profitReportResponse {
+	res := profitReportResponse{
+		CompanyID:                report.CompanyID,
+		CompanyName:              report.CompanyName,
+		WarehouseID:              report.WarehouseID,
+		WarehouseName:            report.WarehouseName,
+		StartDate:                report.StartDate.Format(dateLayout),
+		EndDate:                  report.EndDate.Format(dateLayout),
+		TotalSales:               report.TotalSales,
+		TotalCost:                report.TotalCost,
+		GrossProfit:              report.GrossProfit,
+		GrossProfitRate:          report.GrossProfitRate,
+		CostAllocated:            report.CostAllocated,
+		CostAllocationAdjustment: report.CostAllocationAdjustment,
+		Daily:                    make([]dailyResponse, 0, len(report.DailyReports)),
+		SalesDetails:             newAccountTitleResponses(report.SalesDetails),
+		CostDetails:              newAccountTitleResponses(report.CostDetails),
+	}
+
+	if report.IncludesDisabledTitles {
+		res.Inactive = &inactiveResponse{Sales: report.InactiveSales, Cost: report.InactiveCost}
+	}
+
+	for _, daily := range report.DailyReports {
+		res.Daily = append(res.Daily, dailyResponse{
+			Date:            daily.Date.Format(dateLayout),
+			Sales:           daily.Sales,
+			Cost:            daily.Cost,
+			GrossProfit:     daily.GrossProfit,
+			GrossProfitRate: daily.GrossProfitRate,
+		})
+	}
+
+	if tax := report.Tax; tax != nil {
+		res.Tax = &taxResponse{
+			Basis:             tax.Basis,
+			SalesExcludingTax: tax.SalesExcludingTax,
+			SalesTax:          tax.SalesTax,
+			CostExcludingTax:  tax.CostExcludingTax,
+			CostTax:           tax.CostTax,
+			NonTaxableSales:   tax.NonTaxableSales,
+			NonTaxableCost:    tax.NonTaxableCost,
+		}
+		for _, rate := range tax.ByRate {
+			res.Tax.ByRate = append(res.Tax.ByRate, taxBreakdownResponse(rate))
+		}
+	}
+
+	return res
+}
+
+func newAccountTitleResponses(amounts []entity.AccountTitleAmount) []accountTitleResponse {
+	if len(amounts) == 0 {
+		return nil
+	}
+
+	res := make([]accountTitleResponse, 0, len(amounts))
+	for _, amount := range amounts {
+		res = append(res, accountTitleResponse{
+			CompanyID:        amount.CompanyID,
+			CompanyName:      amount.CompanyName,
+			WarehouseID:      amount.WarehouseID,
+			WarehouseName:    amount.WarehouseName,
+			Date:             amount.Date.Format(dateLayout),
+			AccountTitleCode: amount.AccountTitleCode,
+			AccountTitleName: amount.AccountTitleName,
+			Disabled:         amount.AccountTitleDisabled,
+			Amount:           amount.Amount,
+		})
+	}
+	return res
+}
+
+func newCompanyResponses(companies []repository.Company) []companyResponse {
+	res := make([]companyResponse, 0, len(companies))
+	for _, company := range companies {
+		res = append(res, companyResponse(company))
+	}
+	return res
+}
@@ -0,0 +1,269 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/usecase"
+)
+
+// 監査ログの保存に使う時間（リクエストの切断・タイムアウト後も記録する）
+const auditTimeout = 5 * time.Second
+
+// Server 粗利レポートを JSON で返し、日次レポートの登録を受け付ける HTTP API
+// 全てのリクエストで APIキーを確認し、利用者を ctx に設定してリポジトリに参照範囲を適用させる
+type Server struct {
+	access       usecase.AccessUseCase
+	profitReport usecase.ProfitReportUseCase
+	allocation   usecase.CostAllocationUseCase
+	tax          usecase.TaxUseCase
+	imports      usecase.ImportUseCase
+	companies    repository.CompanyRepository
+	// POST の本文の上限（バイト）
+	maxBodyBytes int64
+}
+
+func NewServer(
+	access usecase.AccessUseCase,
+	profitReport usecase.ProfitReportUseCase,
+	allocation usecase.CostAllocationUseCase,
+	tax usecase.TaxUseCase,
+	imports usecase.ImportUseCase,
+	companies repository.CompanyRepository,
+	maxBodyBytes int64,
+) *Server {
+	return &Server{
+		access:       access,
+		profitReport: profitReport,
+		allocation:   allocation,
+		tax:          tax,
+		imports:      imports,
+		companies:    companies,
+		maxBodyBytes: maxBodyBytes,
+	}
+}
+
+// Handler ルーティング済みのハンドラーを返す（/healthz 以外は認証必須）
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.Handle("/api/v1/profit-report", s.authenticated(http.MethodGet, (*entity.Principal).CanRead, http.HandlerFunc(s.handleProfitReport)))
+	mux.Handle("/api/v1/companies", s.authenticated(http.MethodGet, (*entity.Principal).CanRead, http.HandlerFunc(s.handleCompanies)))
+	mux.Handle("/api/v1/daily-reports", s.authenticated(http.MethodPost, (*entity.Principal).CanIngest, http.HandlerFunc(s.handleDailyReport)))
+	return mux
+}
+
+// authenticated APIキーを確認して利用者を ctx に設定し、結果にかかわらず監査ログを記録する
+// method 以外のメソッドは 405、allowed が false の権限のキーは 403 を返す
+func (s *Server) authenticated(method string, allowed func(*entity.Principal) bool, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		audit := &entity.AuditLog{
+			Method:     r.Method,
+			Path:       r.URL.Path,
+			Query:      r.URL.RawQuery,
+			RemoteAddr: remoteHost(r),
+		}
+		if companyID, err := parseID(r.URL.Query().Get("company")); err == nil {
+			audit.CompanyID = companyID
+		}
+		defer func() {
+			audit.Status = rec.status
+			s.recordAudit(r.Context(), audit)
+		}()
+
+		if r.Method != method {
+			rec.Header().Set("Allow", method)
+			writeError(rec, http.StatusMethodNotAllowed, fmt.Errorf("method not allowed: %s", r.Method))
+			return
+		}
+
+		principal, err := s.access.Authenticate(r.Context(), apiKeyFromRequest(r))
+		if err != nil {
+			writeUseCaseError(rec, err)
+			return
+		}
+		audit.APIKeyID = principal.APIKeyID
+		audit.PrincipalName = principal.Name
+		if !allowed(principal) {
+			writeError(rec, http.StatusForbidden, fmt.Errorf("%s api key is not allowed to %s %s", principal.Role, r.Method, r.URL.Path))
+			return
+		}
+
+		next.ServeHTTP(rec, r.WithContext(entity.WithPrincipal(r.Context(), principal)))
+	})
+}
+
+func (s *Server) recordAudit(ctx context.Context, audit *entity.AuditLog) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), auditTimeout)
+	defer cancel()
+	if err := s.access.RecordAudit(ctx, audit); err != nil {
+		log.Printf("監査ログの記録に失敗しました (%s %s, principal=%q, status=%d): %v",
+			audit.Method, audit.Path, audit.PrincipalName, audit.Status, err)
+	}
+}
+
+// handleProfitReport GET /api/v1/profit-report?start=YYYY-MM-DD&end=YYYY-MM-DD[&company=&warehouse=&details=&tax=&tax_basis=&allocation=&include_disabled=]
+func (s *Server) handleProfitReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	query := r.URL.Query()
+
+	companyID, err := parseID(query.Get("company"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid company: %w", err))
+		return
+	}
+	warehouseID, err := parseID(query.Get("warehouse"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid warehouse: %w", err))
+		return
+	}
+	start, end, err := parsePeriod(query.Get("start"), query.Get("end"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	basis := entity.TaxBasis(query.Get("tax_basis"))
+	if basis == "" {
+		basis = entity.TaxBasisExclusive
+	}
+	if query.Get("include_disabled") == "true" {
+		ctx = entity.WithDisabledAccountTitles(ctx)
+	}
+
+	var report *entity.ProfitReport
+	if query.Get("details") == "true" {
+		report, err = s.profitReport.GenerateProfitReportWithDetails(ctx, companyID, warehouseID, start, end)
+	} else {
+		report, err = s.profitReport.GenerateProfitReport(ctx, companyID, warehouseID, start, end)
+	}
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+
+	// 配賦の基準は全社の実績から求めるため、管理者以外が指定した場合はリポジトリが拒否する
+	if query.Get("allocation") == "true" {
+		if err := s.allocation.ApplyAllocation(ctx, report); err != nil {
+			writeUseCaseError(w, err)
+			return
+		}
+	}
+
+	if query.Get("tax") == "true" {
+		if err := s.tax.ApplyTax(ctx, report, basis); err != nil {
+			writeUseCaseError(w, err)
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, newProfitReportResponse(report))
+}
+
+// handleCompanies GET /api/v1/companies 利用者が参照できる会社の一覧
+func (s *Server) handleCompanies(w http.ResponseWriter, r *http.Request) {
+	companies, err := s.companies.GetAllCompanies(r.Context())
+	if err != nil {
+		writeUseCaseError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, newCompanyResponses(companies))
+}
+
+// apiKeyFromRequest Authorization: Bearer <キー> または X-API-Key ヘッダーからキーを取り出す
+// （URLに含めるとアクセスログ・監査ログに残るため、クエリ文字列では受け付けない）
+func apiKeyFromRequest(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimSpace(strings.TrimPrefix(auth, "Bearer "))
+	}
+	return strings.TrimSpace(r.Header.Get("X-API-Key"))
+}
+
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// parseID 未指定は 0（全件）として扱う
+func parseID(value string) (uint, error) {
+	if value == "" {
+		return 0, nil
+	}
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint(id), nil
+}
+
+func parsePeriod(startValue, endValue string) (time.Time, time.Time, error) {
+	start, err := time.Parse(dateLayout, startValue)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start date format: %w", err)
+	}
+
+	end, err := time.Parse(dateLayout, endValue)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid end date format: %w", err)
+	}
+
+	if start.After(end) {
+		return time.Time{}, time.Time{}, fmt.Errorf("start date must be before or equal to end date")
+	}
+
+	return start, end, nil
+}
+
+// writeUseCaseError 認証・認可・タイムアウトはステータスで区別し、それ以外の詳細はログにのみ出力する
+func writeUseCaseError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, entity.ErrUnauthenticated):
+		w.Header().Set("WWW-Authenticate", `Bearer realm="profit-report"`)
+		writeError(w, http.StatusUnauthorized, entity.ErrUnauthenticated)
+	case errors.Is(err, entity.ErrForbidden):
+		writeError(w, http.StatusForbidden, entity.ErrForbidden)
+	case errors.Is(err, context.DeadlineExceeded):
+		writeError(w, http.StatusGatewayTimeout, fmt.Errorf("query timed out"))
+	default:
+		log.Printf("APIエラー: %v", err)
+		writeError(w, http.StatusInternalServerError, fmt.Errorf("internal server error"))
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("レスポンスの書き込みに失敗しました: %v", err)
+	}
+}
+
+// statusRecorder 監査ログに記録するためにレスポンスのステータスを保持する
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
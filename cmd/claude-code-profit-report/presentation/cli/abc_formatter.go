@@ -0,0 +1,146 @@
+package cli
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+var abcGroupByLabels = map[entity.ABCGroupBy]string{
+	entity.ABCByCompany:          "会社",
+	entity.ABCByCompanyWarehouse: "会社・倉庫",
+}
+
+func (f *TextFormatter) FormatABCAnalysis(analysis *entity.ABCAnalysis) string {
+	var sb strings.Builder
+
+	sb.WriteString("ABC分析（売上・粗利）\n")
+	sb.WriteString(fmt.Sprintf("%s\n", strings.Repeat("=", 60)))
+	sb.WriteString(fmt.Sprintf("期間: %s ~ %s\n", analysis.StartDate.Format("2006-01-02"), analysis.EndDate.Format("2006-01-02")))
+	sb.WriteString(fmt.Sprintf("集計単位: %s\n", abcGroupByLabels[analysis.GroupBy]))
+	sb.WriteString(fmt.Sprintf("区分: A 累積%g%%まで / B 累積%g%%まで / C それ以外\n", analysis.Cutoffs.A, analysis.Cutoffs.B))
+	if analysis.CostAllocated {
+		sb.WriteString("コスト: 共通費配賦後\n")
+	}
+	sb.WriteString(fmt.Sprintf("%s\n\n", strings.Repeat("=", 60)))
+
+	sb.WriteString("【区分別集計】\n")
+	sb.WriteString(fmt.Sprintf("%-6s %8s %15s %8s %15s\n", "区分", "売上:件数", "売上", "粗利:件数", "粗利"))
+	for _, class := range []entity.ABCClass{entity.ABCClassA, entity.ABCClassB, entity.ABCClassC} {
+		var salesCount, profitCount int
+		var sales, profit float64
+		for _, item := range analysis.Items {
+			if item.SalesRank.Class == class {
+				salesCount++
+				sales += item.Sales
+			}
+			if item.ProfitRank.Class == class {
+				profitCount++
+				profit += item.GrossProfit()
+			}
+		}
+		sb.WriteString(fmt.Sprintf("%-6s %8d %15s %8d %15s\n", class, salesCount, formatCurrency(sales), profitCount, formatCurrency(profit)))
+	}
+	sb.WriteString(fmt.Sprintf("%-6s %8d %15s %8d %15s\n\n", "合計",
+		len(analysis.Items), formatCurrency(analysis.TotalSales()), len(analysis.Items), formatCurrency(analysis.TotalGrossProfit())))
+
+	sb.WriteString("【売上順】 (* は売上上位・低粗利)\n")
+	sb.WriteString(fmt.Sprintf("%4s %-28s %15s %7s %7s %2s %15s %7s %4s %7s %2s\n",
+		"順位", "取引先", "売上", "構成比", "累積", "区分", "粗利", "粗利率", "粗利順", "累積", "区分"))
+	sb.WriteString(fmt.Sprintf("%s\n", strings.Repeat("-", 120)))
+	for _, item := range analysis.Items {
+		mark := " "
+		if item.HighSalesLowProfit {
+			mark = "*"
+		}
+		sb.WriteString(fmt.Sprintf("%4d %s%-27s %15s %6.1f%% %6.1f%% %2s %15s %6.1f%% %4d %6.1f%% %2s\n",
+			item.SalesRank.Rank,
+			mark,
+			abcItemName(item),
+			formatCurrency(item.Sales),
+			item.SalesRank.Share,
+			item.SalesRank.CumulativeShare,
+			item.SalesRank.Class,
+			formatCurrency(item.GrossProfit()),
+			item.GrossProfitRate(),
+			item.ProfitRank.Rank,
+			item.ProfitRank.CumulativeShare,
+			item.ProfitRank.Class,
+		))
+	}
+
+	var flagged []entity.ABCItem
+	for _, item := range analysis.Items {
+		if item.HighSalesLowProfit {
+			flagged = append(flagged, item)
+		}
+	}
+	sb.WriteString(fmt.Sprintf("\n【売上上位・低粗利】 (売上A区分で、粗利C区分または粗利率%g%%未満)\n", analysis.MinMargin))
+	if len(flagged) == 0 {
+		sb.WriteString("該当なし\n")
+	}
+	for _, item := range flagged {
+		sb.WriteString(fmt.Sprintf("- %s: 売上 %s (%d位) / 粗利 %s (%d位, %s区分) / 粗利率 %.2f%%\n",
+			abcItemName(item),
+			formatCurrency(item.Sales), item.SalesRank.Rank,
+			formatCurrency(item.GrossProfit()), item.ProfitRank.Rank, item.ProfitRank.Class,
+			item.GrossProfitRate(),
+		))
+	}
+
+	return sb.String()
+}
+
+func abcItemName(item entity.ABCItem) string {
+	if item.WarehouseID > 0 {
+		return fmt.Sprintf("%s / %s", item.CompanyName, item.WarehouseName)
+	}
+	return item.CompanyName
+}
+
+// WriteABCAnalysisCSV ABC分析の結果を売上順にCSVで出力する
+func WriteABCAnalysisCSV(w io.Writer, analysis *entity.ABCAnalysis) error {
+	writer := csv.NewWriter(w)
+
+	header := []string{"会社ID", "会社名"}
+	if analysis.GroupBy == entity.ABCByCompanyWarehouse {
+		header = append(header, "倉庫ID", "倉庫名")
+	}
+	header = append(header,
+		"売上", "コスト", "粗利", "粗利率",
+		"売上順位", "売上構成比", "売上累積構成比", "売上区分",
+		"粗利順位", "粗利構成比", "粗利累積構成比", "粗利区分",
+		"売上上位・低粗利",
+	)
+	if err := writer.Write(header); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+
+	amount := func(v float64) string { return strconv.FormatFloat(v, 'f', 3, 64) }
+	percent := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+	for _, item := range analysis.Items {
+		record := []string{strconv.FormatUint(uint64(item.CompanyID), 10), item.CompanyName}
+		if analysis.GroupBy == entity.ABCByCompanyWarehouse {
+			record = append(record, strconv.FormatUint(uint64(item.WarehouseID), 10), item.WarehouseName)
+		}
+		record = append(record,
+			amount(item.Sales), amount(item.Cost), amount(item.GrossProfit()), percent(item.GrossProfitRate()),
+			strconv.Itoa(item.SalesRank.Rank), percent(item.SalesRank.Share), percent(item.SalesRank.CumulativeShare), string(item.SalesRank.Class),
+			strconv.Itoa(item.ProfitRank.Rank), percent(item.ProfitRank.Share), percent(item.ProfitRank.CumulativeShare), string(item.ProfitRank.Class),
+			strconv.FormatBool(item.HighSalesLowProfit),
+		)
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write csv: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write csv: %w", err)
+	}
+	return nil
+}
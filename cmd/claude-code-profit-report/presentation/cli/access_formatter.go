@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+func (f *TextFormatter) FormatAPIKeys(keys []entity.APIKey) string {
+	if len(keys) == 0 {
+		return "発行済みのAPIキーはありません\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%-6s %-24s %-8s %-24s %-19s %-19s %s\n",
+		"ID", "利用者", "権限", "参照可能な会社ID", "発行日時", "最終利用日時", "状態"))
+	sb.WriteString(fmt.Sprintf("%s\n", strings.Repeat("-", 120)))
+
+	for _, key := range keys {
+		companies := "全社"
+		if key.Role != entity.RoleAdmin {
+			ids := make([]string, 0, len(key.CompanyIDs))
+			for _, id := range key.CompanyIDs {
+				ids = append(ids, strconv.FormatUint(uint64(id), 10))
+			}
+			companies = strings.Join(ids, ",")
+		}
+
+		lastUsed := "-"
+		if key.LastUsedAt != nil {
+			lastUsed = key.LastUsedAt.Format("2006-01-02 15:04:05")
+		}
+		status := "有効"
+		if key.RevokedAt != nil {
+			status = "失効 (" + key.RevokedAt.Format("2006-01-02 15:04:05") + ")"
+		}
+
+		sb.WriteString(fmt.Sprintf("%-6d %-24s %-8s %-24s %-19s %-19s %s\n",
+			key.ID,
+			key.Name,
+			key.Role,
+			companies,
+			key.CreatedAt.Format("2006-01-02 15:04:05"),
+			lastUsed,
+			status,
+		))
+	}
+
+	return sb.String()
+}
+
+func (f *TextFormatter) FormatAuditLogs(logs []entity.AuditLog) string {
+	if len(logs) == 0 {
+		return "監査ログはありません\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%-8s %-19s %-24s %-6s %-6s %-15s %s\n",
+		"ID", "日時", "利用者", "会社ID", "結果", "接続元", "リクエスト"))
+	sb.WriteString(fmt.Sprintf("%s\n", strings.Repeat("-", 120)))
+
+	for _, log := range logs {
+		principal := log.PrincipalName
+		if log.APIKeyID == 0 {
+			principal = "(認証失敗)"
+		}
+		request := log.Method + " " + log.Path
+		if log.Query != "" {
+			request += "?" + log.Query
+		}
+
+		sb.WriteString(fmt.Sprintf("%-8d %-19s %-24s %-6d %-6d %-15s %s\n",
+			log.ID,
+			log.CreatedAt.Format("2006-01-02 15:04:05"),
+			principal,
+			log.CompanyID,
+			log.Status,
+			log.RemoteAddr,
+			request,
+		))
+	}
+
+	return sb.String()
+}
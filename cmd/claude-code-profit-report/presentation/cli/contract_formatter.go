@@ -0,0 +1,41 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+func (f *TextFormatter) FormatContracts(contracts []entity.Contract) string {
+	if len(contracts) == 0 {
+		return "該当する契約はありません\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%-6s %-6s %-24s %-6s %-24s %-10s %s\n",
+		"ID", "会社ID", "会社名", "倉庫ID", "倉庫名", "開始日", "終了日"))
+	sb.WriteString(fmt.Sprintf("%s\n", strings.Repeat("-", 100)))
+
+	for _, contract := range contracts {
+		sb.WriteString(formatContract(contract))
+	}
+
+	return sb.String()
+}
+
+func formatContract(contract entity.Contract) string {
+	endDate := "-"
+	if contract.EndDate != nil {
+		endDate = contract.EndDate.Format("2006-01-02")
+	}
+	return fmt.Sprintf("%-6d %-6d %-24s %-6d %-24s %-10s %s\n",
+		contract.ID,
+		contract.CompanyID,
+		contract.CompanyName,
+		contract.WarehouseID,
+		contract.WarehouseName,
+		contract.StartDate.Format("2006-01-02"),
+		endDate,
+	)
+}
@@ -0,0 +1,54 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+func (f *TextFormatter) FormatCorrections(report *entity.CorrectionReport) string {
+	var sb strings.Builder
+
+	sb.WriteString("報告済みデータの修正チェック\n")
+	sb.WriteString(fmt.Sprintf("%s\n", strings.Repeat("=", 60)))
+	sb.WriteString(fmt.Sprintf("対象: %s より後 ~ %s に更新されたデータ\n",
+		report.Since.Format("2006-01-02 15:04:05"), report.CheckedAt.Format("2006-01-02 15:04:05")))
+	sb.WriteString(fmt.Sprintf("%s\n\n", strings.Repeat("=", 60)))
+
+	if len(report.Corrections) == 0 {
+		sb.WriteString("報告済み期間の修正はありません。\n")
+		return sb.String()
+	}
+
+	sb.WriteString(fmt.Sprintf("【修正件数】 %d件\n", len(report.Corrections)))
+	sb.WriteString(fmt.Sprintf("売上差分: %s / コスト差分: %s\n\n",
+		formatSignedCurrency(report.TotalSalesDelta()), formatSignedCurrency(report.TotalCostDelta())))
+
+	sb.WriteString(fmt.Sprintf("%-12s %-16s %-16s %15s %15s %15s %15s %15s %15s\n",
+		"日付", "会社", "倉庫", "売上(旧)", "売上(新)", "コスト(旧)", "コスト(新)", "粗利(旧)", "粗利(新)"))
+	sb.WriteString(fmt.Sprintf("%s\n", strings.Repeat("-", 140)))
+
+	for _, correction := range report.Corrections {
+		current := correction.Current
+		oldSales, oldCost, oldProfit := "不明", "不明", "不明"
+		if correction.OldKnown {
+			oldSales = formatCurrency(correction.OldSales)
+			oldCost = formatCurrency(correction.OldCost)
+			oldProfit = formatCurrency(correction.OldGrossProfit())
+		}
+		sb.WriteString(fmt.Sprintf("%-12s %-16s %-16s %15s %15s %15s %15s %15s %15s\n",
+			current.Date.Format("2006-01-02"),
+			current.CompanyName,
+			current.WarehouseName,
+			oldSales,
+			formatCurrency(current.Sales),
+			oldCost,
+			formatCurrency(current.Cost),
+			oldProfit,
+			formatCurrency(current.GrossProfit()),
+		))
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+var allocationMethodLabels = map[entity.AllocationMethod]string{
+	entity.AllocationByShippedQuantity: "出荷数量比",
+	entity.AllocationBySales:           "売上比",
+	entity.AllocationFixed:             "固定比率",
+}
+
+func (f *TextFormatter) FormatAllocationRules(rules []entity.AllocationRule) string {
+	if len(rules) == 0 {
+		return "有効な配賦ルールはありません。\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%-6s %-16s %-16s %-12s %s\n", "ID", "倉庫", "原価科目", "配賦方法", "固定比率"))
+	sb.WriteString(fmt.Sprintf("%s\n", strings.Repeat("-", 80)))
+	for _, rule := range rules {
+		var shares []string
+		for _, share := range rule.Shares {
+			shares = append(shares, fmt.Sprintf("%s %g%%", share.CompanyName, share.Percentage))
+		}
+		sb.WriteString(fmt.Sprintf("%-6d %-16s %-16s %-12s %s\n",
+			rule.ID,
+			rule.WarehouseName,
+			rule.CostAccountTitleName,
+			allocationMethodLabels[rule.Method],
+			strings.Join(shares, ", "),
+		))
+	}
+	return sb.String()
+}
+
+// FormatAllocationAdjustments 配賦による調整額を会社・倉庫・科目別に期間合計で表示する
+func (f *TextFormatter) FormatAllocationAdjustments(adjustments []entity.AccountTitleAmount) string {
+	if len(adjustments) == 0 {
+		return "配賦による調整はありません。\n"
+	}
+
+	type key struct {
+		companyID      uint
+		warehouseID    uint
+		accountTitleID uint
+	}
+	totals := make(map[key]*entity.AccountTitleAmount)
+	var keys []key
+	for _, adjustment := range adjustments {
+		k := key{adjustment.CompanyID, adjustment.WarehouseID, adjustment.AccountTitleID}
+		if total, ok := totals[k]; ok {
+			total.Amount += adjustment.Amount
+			continue
+		}
+		total := adjustment
+		totals[k] = &total
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		a, b := keys[i], keys[j]
+		if a.warehouseID != b.warehouseID {
+			return a.warehouseID < b.warehouseID
+		}
+		if a.accountTitleID != b.accountTitleID {
+			return a.accountTitleID < b.accountTitleID
+		}
+		return a.companyID < b.companyID
+	})
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%-16s %-16s %-16s %15s\n", "倉庫", "原価科目", "会社", "調整額"))
+	sb.WriteString(fmt.Sprintf("%s\n", strings.Repeat("-", 70)))
+	for _, k := range keys {
+		total := totals[k]
+		sb.WriteString(fmt.Sprintf("%-16s %-16s %-16s %15s\n",
+			total.WarehouseName,
+			total.AccountTitleName,
+			total.CompanyName,
+			formatSignedCurrency(total.Amount),
+		))
+	}
+	return sb.String()
+}
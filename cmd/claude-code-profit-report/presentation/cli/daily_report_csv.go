@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+// 日次レポート取り込み用CSVの列（size 以外は必須）
+var dailyReportCSVColumns = []string{"company_code", "warehouse_code", "date", "title_code", "size", "quantity", "price", "amount"}
+
+// ReadDailyReportCSV 日次レポート取り込み用のCSVを読み込む
+// 1行目はヘッダー。値を解釈できない行は ParseError を設定して返す（取り込み時に除外する）
+func ReadDailyReportCSV(r io.Reader) ([]entity.DailyReportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+	// 列数の違う行もエラーの行として扱う
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("csv is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			// Excel で保存したCSVの BOM を取り除く
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		known := false
+		for _, column := range dailyReportCSVColumns {
+			known = known || column == name
+		}
+		if !known {
+			return nil, fmt.Errorf("unknown csv column: %s", name)
+		}
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("duplicate csv column: %s", name)
+		}
+		columns[name] = i
+	}
+	for _, column := range dailyReportCSVColumns {
+		if _, ok := columns[column]; !ok && column != "size" {
+			return nil, fmt.Errorf("csv header must have %s column", column)
+		}
+	}
+
+	var rows []entity.DailyReportRow
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read csv: %w", err)
+		}
+
+		row := entity.DailyReportRow{Line: line}
+		if err := parseDailyReportRecord(record, columns, &row); err != nil {
+			row.ParseError = err.Error()
+		}
+		rows = append(rows, row)
+	}
+
+	return rows, nil
+}
+
+func parseDailyReportRecord(record []string, columns map[string]int, row *entity.DailyReportRow) error {
+	cell := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+	required := func(name string) (string, error) {
+		value := cell(name)
+		if value == "" {
+			return "", fmt.Errorf("%s is required", name)
+		}
+		return value, nil
+	}
+
+	var err error
+	if row.CompanyCode, err = required("company_code"); err != nil {
+		return err
+	}
+	if row.WarehouseCode, err = required("warehouse_code"); err != nil {
+		return err
+	}
+	if row.TitleCode, err = required("title_code"); err != nil {
+		return err
+	}
+	row.Size = cell("size")
+
+	value, err := required("date")
+	if err != nil {
+		return err
+	}
+	if row.Date, err = time.ParseInLocation("2006-01-02", value, time.Local); err != nil {
+		return fmt.Errorf("invalid date: %s", value)
+	}
+	if value, err = required("quantity"); err != nil {
+		return err
+	}
+	if row.Quantity, err = strconv.Atoi(value); err != nil {
+		return fmt.Errorf("invalid quantity: %s", value)
+	}
+	if value, err = required("price"); err != nil {
+		return err
+	}
+	if row.Price, err = strconv.ParseFloat(value, 64); err != nil {
+		return fmt.Errorf("invalid price: %s", value)
+	}
+	if value, err = required("amount"); err != nil {
+		return err
+	}
+	if row.Amount, err = strconv.ParseFloat(value, 64); err != nil {
+		return fmt.Errorf("invalid amount: %s", value)
+	}
+	return nil
+}
@@ -9,6 +9,22 @@ import (
 
 type Formatter interface {
 	FormatProfitReport(report *entity.ProfitReport) string
+	FormatReportRuns(runs []entity.ReportRun) string
+	FormatReportRunHeader(run *entity.ReportRun) string
+	FormatReportDiff(diff *entity.ReportDiff) string
+	FormatCorrections(report *entity.CorrectionReport) string
+	FormatValidation(result *entity.ValidationResult, maxDetails int) string
+	FormatAllocationRules(rules []entity.AllocationRule) string
+	FormatAllocationAdjustments(adjustments []entity.AccountTitleAmount) string
+	FormatABCAnalysis(analysis *entity.ABCAnalysis) string
+	FormatAPIKeys(keys []entity.APIKey) string
+	FormatAuditLogs(logs []entity.AuditLog) string
+	FormatMasterRecords(kind entity.MasterKind, records []entity.MasterRecord) string
+	FormatMasterPlan(plan *entity.MasterPlan, dryRun bool) string
+	FormatContracts(contracts []entity.Contract) string
+	FormatImportResult(source string, result *entity.ImportResult) string
+	FormatImportBatches(batches []entity.ImportBatch) string
+	FormatImportBatchRollback(result *entity.ImportBatchRollback) string
 }
 
 type TextFormatter struct{}
@@ -30,8 +46,20 @@ func (f *TextFormatter) FormatProfitReport(report *entity.ProfitReport) string {
 	sb.WriteString(fmt.Sprintf("【期間合計】\n"))
 	sb.WriteString(fmt.Sprintf("売上高: %s\n", formatCurrency(report.TotalSales)))
 	sb.WriteString(fmt.Sprintf("コスト: %s\n", formatCurrency(report.TotalCost)))
+	if report.CostAllocated && report.CostAllocationAdjustment != 0 {
+		sb.WriteString(fmt.Sprintf("  (うち共通費配賦による調整: %s)\n", formatSignedCurrency(report.CostAllocationAdjustment)))
+	}
 	sb.WriteString(fmt.Sprintf("粗利益: %s\n", formatCurrency(report.GrossProfit)))
-	sb.WriteString(fmt.Sprintf("粗利率: %.2f%%\n\n", report.GrossProfitRate))
+	sb.WriteString(fmt.Sprintf("粗利率: %.2f%%\n", report.GrossProfitRate))
+	if report.IncludesDisabledTitles {
+		sb.WriteString(fmt.Sprintf("無効科目: 売上 %s / コスト %s (上記の合計に含む)\n",
+			formatCurrency(report.InactiveSales), formatCurrency(report.InactiveCost)))
+	}
+	sb.WriteString("\n")
+
+	if report.Tax != nil {
+		sb.WriteString(formatTaxSummary(report.Tax))
+	}
 
 	sb.WriteString(fmt.Sprintf("【日別詳細】\n"))
 	sb.WriteString(fmt.Sprintf("%-12s %15s %15s %15s %8s\n", "日付", "売上", "コスト", "粗利", "粗利率"))
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+// FormatImportResult 取り込んだレポートの件数と除外した行を表示する
+func (f *TextFormatter) FormatImportResult(source string, result *entity.ImportResult) string {
+	var sb strings.Builder
+
+	title := fmt.Sprintf("%s日次レポートの取り込み: %s", reportKindLabels[result.Kind], source)
+	if result.DryRun {
+		title += " (dry-run: 反映しません)"
+	}
+	sb.WriteString(title + "\n")
+	sb.WriteString(fmt.Sprintf("%s\n", strings.Repeat("=", 60)))
+
+	for _, rejection := range result.Rejected {
+		sb.WriteString(fmt.Sprintf("[除外] %d行目: %s\n", rejection.Line, rejection.Reason))
+	}
+	if len(result.Rejected) > 0 {
+		sb.WriteString(fmt.Sprintf("%s\n", strings.Repeat("-", 60)))
+	}
+
+	sb.WriteString(fmt.Sprintf("新規: %d件 / 更新: %d件 (明細 %d行) / 除外: %d行\n",
+		result.Inserted(),
+		result.Updated(),
+		result.ImportedRows(),
+		len(result.Rejected),
+	))
+	if result.BatchID > 0 {
+		sb.WriteString(fmt.Sprintf("取り込みバッチ: %d (取り消す場合: import-batch rollback %d)\n", result.BatchID, result.BatchID))
+	}
+
+	return sb.String()
+}
+
+func (f *TextFormatter) FormatImportBatches(batches []entity.ImportBatch) string {
+	if len(batches) == 0 {
+		return "取り込みバッチはありません\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%-8s %-19s %-40s %-16s %8s %8s %s\n",
+		"ID", "取り込み日時", "取り込み元", "実行者", "レポート", "明細", "ロールバック"))
+	sb.WriteString(fmt.Sprintf("%s\n", strings.Repeat("-", 120)))
+
+	for _, batch := range batches {
+		createdBy := batch.CreatedBy
+		if createdBy == "" {
+			createdBy = "-"
+		}
+		rolledBack := "-"
+		if batch.RolledBack() {
+			rolledBack = batch.RolledBackAt.Format("2006-01-02 15:04:05")
+		}
+		sb.WriteString(fmt.Sprintf("%-8d %-19s %-40s %-16s %8d %8d %s\n",
+			batch.ID,
+			batch.CreatedAt.Format("2006-01-02 15:04:05"),
+			batch.Source,
+			createdBy,
+			batch.ReportCount,
+			batch.ItemCount,
+			rolledBack,
+		))
+	}
+
+	return sb.String()
+}
+
+// FormatImportBatchRollback ロールバックで削除・復元したレポートと明細の件数を表示する
+func (f *TextFormatter) FormatImportBatchRollback(result *entity.ImportBatchRollback) string {
+	var sb strings.Builder
+
+	title := fmt.Sprintf("取り込みバッチ %d のロールバック", result.BatchID)
+	if result.DryRun {
+		title += " (dry-run: 反映しません)"
+	}
+	sb.WriteString(title + "\n")
+	sb.WriteString(fmt.Sprintf("%s\n", strings.Repeat("=", 60)))
+	sb.WriteString(fmt.Sprintf("削除したレポート: %d件 / 置き換え前に戻したレポート: %d件\n", result.DeletedReports, result.RestoredReports))
+	sb.WriteString(fmt.Sprintf("削除した明細: %d行 / 復元した明細: %d行\n", result.DeletedItems, result.RestoredItems))
+
+	return sb.String()
+}
@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+// ReadMasterCSV マスタ一括登録用のCSVを読み込む
+// 1行目はヘッダー（code 必須、name / disabled / taxable は任意、taxable は科目のみ）
+// 空欄の項目は既存の値を引き継ぐ（新規作成時は既定値）
+func ReadMasterCSV(r io.Reader, kind entity.MasterKind) ([]entity.MasterInput, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("csv is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		if i == 0 {
+			// Excel で保存したCSVの BOM を取り除く
+			name = strings.TrimPrefix(name, "\ufeff")
+		}
+		name = strings.ToLower(strings.TrimSpace(name))
+		switch name {
+		case "code", "name", "disabled":
+		case "taxable":
+			if !kind.IsAccountTitle() {
+				return nil, fmt.Errorf("column taxable is only available for account titles")
+			}
+		default:
+			return nil, fmt.Errorf("unknown csv column: %s", name)
+		}
+		if _, ok := columns[name]; ok {
+			return nil, fmt.Errorf("duplicate csv column: %s", name)
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["code"]; !ok {
+		return nil, fmt.Errorf("csv header must have code column")
+	}
+
+	var inputs []entity.MasterInput
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read csv: %w", err)
+		}
+
+		cell := func(name string) *string {
+			i, ok := columns[name]
+			if !ok || i >= len(record) || record[i] == "" {
+				return nil
+			}
+			value := record[i]
+			return &value
+		}
+		flag := func(name string) (*bool, error) {
+			value := cell(name)
+			if value == nil {
+				return nil, nil
+			}
+			b, err := strconv.ParseBool(strings.TrimSpace(*value))
+			if err != nil {
+				return nil, fmt.Errorf("line %d: invalid %s: %s", line, name, *value)
+			}
+			return &b, nil
+		}
+
+		input := entity.MasterInput{Line: line, Name: cell("name")}
+		if code := cell("code"); code != nil {
+			input.LookupCode = *code
+			input.Code = code
+		}
+		if input.Disabled, err = flag("disabled"); err != nil {
+			return nil, err
+		}
+		if input.Taxable, err = flag("taxable"); err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, input)
+	}
+
+	return inputs, nil
+}
@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+func (f *TextFormatter) FormatMasterRecords(kind entity.MasterKind, records []entity.MasterRecord) string {
+	if len(records) == 0 {
+		return fmt.Sprintf("%sマスタのデータはありません\n", kind.Label())
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%sマスタ\n", kind.Label()))
+	if kind.IsAccountTitle() {
+		sb.WriteString(fmt.Sprintf("%-6s %-16s %-32s %-6s %s\n", "ID", "コード", "名称", "課税", "状態"))
+	} else {
+		sb.WriteString(fmt.Sprintf("%-6s %-16s %-36s %s\n", "ID", "コード", "名称", "状態"))
+	}
+	sb.WriteString(fmt.Sprintf("%s\n", strings.Repeat("-", 80)))
+
+	for _, record := range records {
+		if kind.IsAccountTitle() {
+			sb.WriteString(fmt.Sprintf("%-6d %-16s %-32s %-6s %s\n",
+				record.ID, record.Code, record.Name, formatTaxable(record.Taxable), formatDisabled(record.Disabled)))
+		} else {
+			sb.WriteString(fmt.Sprintf("%-6d %-16s %-36s %s\n",
+				record.ID, record.Code, record.Name, formatDisabled(record.Disabled)))
+		}
+	}
+
+	return sb.String()
+}
+
+// FormatMasterPlan 作成・更新の内容を表示する（変更のない行は件数のみ）
+func (f *TextFormatter) FormatMasterPlan(plan *entity.MasterPlan, dryRun bool) string {
+	var sb strings.Builder
+
+	title := fmt.Sprintf("%sマスタの変更内容", plan.Kind.Label())
+	if dryRun {
+		title += " (dry-run: 反映しません)"
+	}
+	sb.WriteString(title + "\n")
+	sb.WriteString(fmt.Sprintf("%s\n", strings.Repeat("=", 60)))
+
+	for _, change := range plan.Changes {
+		if change.Action == entity.MasterActionUnchanged {
+			continue
+		}
+
+		location := ""
+		if change.Line > 0 {
+			location = fmt.Sprintf("%d行目: ", change.Line)
+		}
+
+		switch change.Action {
+		case entity.MasterActionCreate:
+			sb.WriteString(fmt.Sprintf("[作成] %s%s\n", location, formatMasterRecord(plan.Kind, change.After)))
+		case entity.MasterActionUpdate:
+			sb.WriteString(fmt.Sprintf("[更新] %s%s\n", location, formatMasterRecord(plan.Kind, *change.Before)))
+			sb.WriteString(fmt.Sprintf("    -> %s\n", formatMasterRecord(plan.Kind, change.After)))
+		case entity.MasterActionInvalid:
+			code := change.After.Code
+			if code == "" && change.Before != nil {
+				code = change.Before.Code
+			}
+			sb.WriteString(fmt.Sprintf("[エラー] %sコード %q: %v\n", location, code, change.Error))
+		}
+	}
+
+	sb.WriteString(fmt.Sprintf("%s\n", strings.Repeat("-", 60)))
+	sb.WriteString(fmt.Sprintf("作成: %d件 / 更新: %d件 / 変更なし: %d件 / エラー: %d件\n",
+		plan.Count(entity.MasterActionCreate),
+		plan.Count(entity.MasterActionUpdate),
+		plan.Count(entity.MasterActionUnchanged),
+		plan.Count(entity.MasterActionInvalid),
+	))
+
+	return sb.String()
+}
+
+func formatMasterRecord(kind entity.MasterKind, record entity.MasterRecord) string {
+	text := fmt.Sprintf("コード=%s 名称=%s 状態=%s", record.Code, record.Name, formatDisabled(record.Disabled))
+	if kind.IsAccountTitle() {
+		text += " 課税=" + formatTaxable(record.Taxable)
+	}
+	return text
+}
+
+func formatDisabled(disabled bool) string {
+	if disabled {
+		return "無効"
+	}
+	return "有効"
+}
+
+func formatTaxable(taxable bool) string {
+	if taxable {
+		return "課税"
+	}
+	return "非課税"
+}
@@ -0,0 +1,114 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+func (f *TextFormatter) FormatReportRuns(runs []entity.ReportRun) string {
+	if len(runs) == 0 {
+		return "保存されたレポートはありません\n"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("%-6s %-19s %-23s %-16s %-16s %15s %15s %8s %-6s %s\n",
+		"ID", "作成日時", "期間", "会社", "倉庫", "売上", "粗利", "粗利率", "形式", "出力先"))
+	sb.WriteString(fmt.Sprintf("%s\n", strings.Repeat("-", 140)))
+
+	for _, run := range runs {
+		report := run.Report
+		sb.WriteString(fmt.Sprintf("%-6d %-19s %-23s %-16s %-16s %15s %15s %7.2f%% %-6s %s\n",
+			run.ID,
+			run.CreatedAt.Format("2006-01-02 15:04:05"),
+			report.StartDate.Format("2006-01-02")+" ~ "+report.EndDate.Format("2006-01-02"),
+			report.CompanyName,
+			report.WarehouseName,
+			formatCurrency(report.TotalSales),
+			formatCurrency(report.GrossProfit),
+			report.GrossProfitRate,
+			run.OutputFormat,
+			run.Destination,
+		))
+	}
+
+	return sb.String()
+}
+
+func (f *TextFormatter) FormatReportRunHeader(run *entity.ReportRun) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("保存済みレポート #%d\n", run.ID))
+	sb.WriteString(fmt.Sprintf("作成日時: %s / 出力形式: %s / 出力先: %s\n",
+		run.CreatedAt.Format("2006-01-02 15:04:05"), run.OutputFormat, run.Destination))
+	if run.OutputPath != "" {
+		sb.WriteString(fmt.Sprintf("出力ファイル: %s\n", run.OutputPath))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+func (f *TextFormatter) FormatReportDiff(diff *entity.ReportDiff) string {
+	var sb strings.Builder
+	report := diff.Run.Report
+
+	sb.WriteString(fmt.Sprintf("保存済みレポート #%d と現在のデータの差分\n", diff.Run.ID))
+	sb.WriteString(fmt.Sprintf("%s\n", strings.Repeat("=", 60)))
+	sb.WriteString(fmt.Sprintf("会社: %s (ID: %d)\n", report.CompanyName, report.CompanyID))
+	sb.WriteString(fmt.Sprintf("倉庫: %s (ID: %d)\n", report.WarehouseName, report.WarehouseID))
+	sb.WriteString(fmt.Sprintf("期間: %s ~ %s\n", report.StartDate.Format("2006-01-02"), report.EndDate.Format("2006-01-02")))
+	sb.WriteString(fmt.Sprintf("保存日時: %s\n", diff.Run.CreatedAt.Format("2006-01-02 15:04:05")))
+	sb.WriteString(fmt.Sprintf("%s\n\n", strings.Repeat("=", 60)))
+
+	if !diff.HasChanges() {
+		sb.WriteString("差分はありません。保存時から数値は変わっていません。\n")
+		return sb.String()
+	}
+
+	sb.WriteString("【期間合計】\n")
+	sb.WriteString(fmt.Sprintf("%-8s %15s %15s %15s\n", "項目", "保存時", "現在", "差分"))
+	sb.WriteString(fmt.Sprintf("%s\n", strings.Repeat("-", 60)))
+	totals := diff.Totals
+	sb.WriteString(formatDiffLine("売上高", totals.Archived.Sales, totals.Current.Sales))
+	sb.WriteString(formatDiffLine("コスト", totals.Archived.Cost, totals.Current.Cost))
+	sb.WriteString(formatDiffLine("粗利益", totals.Archived.GrossProfit, totals.Current.GrossProfit))
+	sb.WriteString(fmt.Sprintf("%-8s %14.2f%% %14.2f%% %+14.2fpt\n\n", "粗利率",
+		totals.Archived.GrossProfitRate, totals.Current.GrossProfitRate,
+		totals.Current.GrossProfitRate-totals.Archived.GrossProfitRate))
+
+	sb.WriteString(fmt.Sprintf("【変更のあった日】 %d日\n", len(diff.Daily)))
+	sb.WriteString(fmt.Sprintf("%-12s %15s %15s %15s %15s %15s\n", "日付", "売上差分", "コスト差分", "粗利(保存時)", "粗利(現在)", "粗利差分"))
+	sb.WriteString(fmt.Sprintf("%s\n", strings.Repeat("-", 95)))
+	for _, d := range diff.Daily {
+		note := ""
+		switch {
+		case d.OnlyArchived:
+			note = " (現在のデータにない日)"
+		case d.OnlyCurrent:
+			note = " (保存時にない日)"
+		}
+		sb.WriteString(fmt.Sprintf("%-12s %15s %15s %15s %15s %15s%s\n",
+			d.Date.Format("2006-01-02"),
+			formatSignedCurrency(d.SalesDelta()),
+			formatSignedCurrency(d.CostDelta()),
+			formatCurrency(d.Archived.GrossProfit),
+			formatCurrency(d.Current.GrossProfit),
+			formatSignedCurrency(d.GrossProfitDelta()),
+			note,
+		))
+	}
+
+	return sb.String()
+}
+
+func formatDiffLine(label string, archived, current float64) string {
+	return fmt.Sprintf("%-8s %15s %15s %15s\n", label,
+		formatCurrency(archived), formatCurrency(current), formatSignedCurrency(current-archived))
+}
+
+func formatSignedCurrency(amount float64) string {
+	if amount > 0 {
+		return "+" + formatCurrency(amount)
+	}
+	return formatCurrency(amount)
+}
@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+var taxBasisLabels = map[entity.TaxBasis]string{
+	entity.TaxBasisExclusive: "税抜",
+	entity.TaxBasisInclusive: "税込",
+}
+
+func formatTaxSummary(tax *entity.TaxSummary) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("【消費税】 (元データ: %s)\n", taxBasisLabels[tax.Basis]))
+	sb.WriteString(fmt.Sprintf("%-8s %15s %15s\n", "", "税抜", "税込"))
+	sb.WriteString(fmt.Sprintf("%-8s %15s %15s\n", "売上高", formatCurrency(tax.SalesExcludingTax), formatCurrency(tax.SalesIncludingTax())))
+	sb.WriteString(fmt.Sprintf("%-8s %15s %15s\n", "コスト", formatCurrency(tax.CostExcludingTax), formatCurrency(tax.CostIncludingTax())))
+	sb.WriteString(fmt.Sprintf("%-8s %15s %15s\n", "粗利益", formatCurrency(tax.GrossProfitExcludingTax()), formatCurrency(tax.GrossProfitIncludingTax())))
+	if tax.NonTaxableSales != 0 || tax.NonTaxableCost != 0 {
+		sb.WriteString(fmt.Sprintf("非課税: 売上 %s / コスト %s\n", formatCurrency(tax.NonTaxableSales), formatCurrency(tax.NonTaxableCost)))
+	}
+	sb.WriteString("\n")
+
+	sb.WriteString("【税率別内訳】\n")
+	sb.WriteString(fmt.Sprintf("%-12s %15s %15s %15s %15s\n", "税率", "売上(税抜)", "売上消費税", "コスト(税抜)", "コスト消費税"))
+	sb.WriteString(fmt.Sprintf("%s\n", strings.Repeat("-", 77)))
+	for _, breakdown := range tax.ByRate {
+		sb.WriteString(fmt.Sprintf("%-12s %15s %15s %15s %15s\n",
+			formatTaxRate(breakdown.Rate),
+			formatCurrency(breakdown.SalesBase),
+			formatCurrency(breakdown.SalesTax),
+			formatCurrency(breakdown.CostBase),
+			formatCurrency(breakdown.CostTax),
+		))
+	}
+	sb.WriteString(fmt.Sprintf("%-12s %15s %15s %15s %15s\n\n", "合計",
+		formatCurrency(tax.SalesExcludingTax-tax.NonTaxableSales),
+		formatCurrency(tax.SalesTax),
+		formatCurrency(tax.CostExcludingTax-tax.NonTaxableCost),
+		formatCurrency(tax.CostTax),
+	))
+
+	return sb.String()
+}
+
+func formatTaxRate(rate float64) string {
+	return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", rate*100), "0"), ".") + "%"
+}
@@ -0,0 +1,181 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+var validationCheckLabels = map[entity.ValidationCheck]string{
+	entity.CheckMissingDay:          "レポート欠損日",
+	entity.CheckEmptyReport:         "明細のないレポート",
+	entity.CheckAmountMismatch:      "金額と数量×単価の不一致",
+	entity.CheckCostWithoutSales:    "売上のないコスト",
+	entity.CheckSalesWithoutCost:    "コストのない売上",
+	entity.CheckNonPositiveQuantity: "数量が0以下の明細",
+}
+
+var reportKindLabels = map[entity.ReportKind]string{
+	entity.ReportKindSales: "売上",
+	entity.ReportKindCost:  "コスト",
+}
+
+// FormatValidation 検証結果を検証項目ごとに表示する（maxDetails が 0 以下の場合は全件表示）
+func (f *TextFormatter) FormatValidation(result *entity.ValidationResult, maxDetails int) string {
+	var sb strings.Builder
+
+	sb.WriteString("データ品質チェック\n")
+	sb.WriteString(fmt.Sprintf("%s\n", strings.Repeat("=", 60)))
+	sb.WriteString(fmt.Sprintf("会社ID: %s / 倉庫ID: %s\n", formatScopeID(result.CompanyID), formatScopeID(result.WarehouseID)))
+	sb.WriteString(fmt.Sprintf("期間: %s ~ %s\n", result.StartDate.Format("2006-01-02"), result.EndDate.Format("2006-01-02")))
+	sb.WriteString(fmt.Sprintf("金額の許容誤差: %g\n", result.Tolerance))
+	sb.WriteString(fmt.Sprintf("%s\n\n", strings.Repeat("=", 60)))
+
+	counts := result.CountByCheck()
+	sb.WriteString("【検証結果】\n")
+	for _, check := range entity.ValidationChecks {
+		status := "OK"
+		if counts[check] > 0 {
+			status = fmt.Sprintf("NG (%d件)", counts[check])
+		}
+		sb.WriteString(fmt.Sprintf("%-24s %s\n", validationCheckLabels[check], status))
+	}
+
+	for _, check := range entity.ValidationChecks {
+		issues := result.IssuesByCheck(check)
+		if len(issues) == 0 {
+			continue
+		}
+
+		sb.WriteString(fmt.Sprintf("\n【%s】 %d件\n", validationCheckLabels[check], len(issues)))
+		sb.WriteString(fmt.Sprintf("%s\n", strings.Repeat("-", 100)))
+		for i, issue := range issues {
+			if maxDetails > 0 && i >= maxDetails {
+				sb.WriteString(fmt.Sprintf("... 他 %d件\n", len(issues)-maxDetails))
+				break
+			}
+			sb.WriteString(formatValidationIssue(issue))
+			sb.WriteString("\n")
+		}
+	}
+
+	if result.Passed() {
+		sb.WriteString("\n問題は見つかりませんでした。\n")
+	} else {
+		sb.WriteString(fmt.Sprintf("\n%d件の問題が見つかりました。\n", len(result.Issues)))
+	}
+
+	return sb.String()
+}
+
+func formatValidationIssue(issue entity.ValidationIssue) string {
+	header := issue.Header
+	location := fmt.Sprintf("%s %s(%d) %s(%d)",
+		header.Date.Format("2006-01-02"), header.CompanyName, header.CompanyID, header.WarehouseName, header.WarehouseID)
+
+	switch issue.Check {
+	case entity.CheckCostWithoutSales, entity.CheckSalesWithoutCost:
+		return location
+	case entity.CheckMissingDay:
+		return fmt.Sprintf("%s %s科目: %s", location, reportKindLabels[header.Kind], header.AccountTitleName)
+	case entity.CheckEmptyReport:
+		return fmt.Sprintf("%s %s科目: %s レポートID: %d", location, reportKindLabels[header.Kind], header.AccountTitleName, header.ReportID)
+	}
+
+	item := issue.Item
+	line := fmt.Sprintf("%s %s科目: %s 明細ID: %d 数量: %d", location, reportKindLabels[header.Kind], header.AccountTitleName, item.ItemID, item.Quantity)
+	if issue.Check == entity.CheckAmountMismatch {
+		line += fmt.Sprintf(" 単価: %.3f 金額: %.3f 数量×単価: %.3f 差額: %+.3f",
+			item.Price, item.Amount, item.ExpectedAmount(), item.Amount-item.ExpectedAmount())
+	}
+	return line
+}
+
+func formatScopeID(id uint) string {
+	if id == 0 {
+		return "全て"
+	}
+	return fmt.Sprintf("%d", id)
+}
+
+type validationJSON struct {
+	CompanyID   uint                  `json:"company_id"`
+	WarehouseID uint                  `json:"warehouse_id"`
+	StartDate   string                `json:"start_date"`
+	EndDate     string                `json:"end_date"`
+	Tolerance   float64               `json:"tolerance"`
+	Passed      bool                  `json:"passed"`
+	Counts      map[string]int        `json:"counts"`
+	Issues      []validationIssueJSON `json:"issues"`
+}
+
+type validationIssueJSON struct {
+	Check            string   `json:"check"`
+	Kind             string   `json:"kind,omitempty"`
+	Date             string   `json:"date"`
+	CompanyID        uint     `json:"company_id"`
+	CompanyName      string   `json:"company_name"`
+	WarehouseID      uint     `json:"warehouse_id"`
+	WarehouseName    string   `json:"warehouse_name"`
+	AccountTitleCode string   `json:"account_title_code,omitempty"`
+	AccountTitleName string   `json:"account_title_name,omitempty"`
+	ReportID         uint64   `json:"report_id,omitempty"`
+	ItemID           uint64   `json:"item_id,omitempty"`
+	Quantity         *int     `json:"quantity,omitempty"`
+	Price            *float64 `json:"price,omitempty"`
+	Amount           *float64 `json:"amount,omitempty"`
+	ExpectedAmount   *float64 `json:"expected_amount,omitempty"`
+}
+
+// FormatValidationJSON 検証結果をJSONで出力する（問題は全件含める）
+func FormatValidationJSON(result *entity.ValidationResult) (string, error) {
+	out := validationJSON{
+		CompanyID:   result.CompanyID,
+		WarehouseID: result.WarehouseID,
+		StartDate:   result.StartDate.Format("2006-01-02"),
+		EndDate:     result.EndDate.Format("2006-01-02"),
+		Tolerance:   result.Tolerance,
+		Passed:      result.Passed(),
+		Counts:      make(map[string]int, len(entity.ValidationChecks)),
+		Issues:      make([]validationIssueJSON, 0, len(result.Issues)),
+	}
+
+	counts := result.CountByCheck()
+	for _, check := range entity.ValidationChecks {
+		out.Counts[string(check)] = counts[check]
+	}
+
+	for _, issue := range result.Issues {
+		header := issue.Header
+		issueJSON := validationIssueJSON{
+			Check: string(issue.Check),
+			// 売上とコストの突き合わせでは、相手側のレポートがない方の種別になる
+			Kind:             string(header.Kind),
+			Date:             header.Date.Format("2006-01-02"),
+			CompanyID:        header.CompanyID,
+			CompanyName:      header.CompanyName,
+			WarehouseID:      header.WarehouseID,
+			WarehouseName:    header.WarehouseName,
+			AccountTitleCode: header.AccountTitleCode,
+			AccountTitleName: header.AccountTitleName,
+			ReportID:         header.ReportID,
+		}
+		if item := issue.Item; item != nil {
+			quantity, price, amount, expected := item.Quantity, item.Price, item.Amount, item.ExpectedAmount()
+			issueJSON.ItemID = item.ItemID
+			issueJSON.Quantity = &quantity
+			issueJSON.Price = &price
+			issueJSON.Amount = &amount
+			issueJSON.ExpectedAmount = &expected
+		}
+		out.Issues = append(out.Issues, issueJSON)
+	}
+
+	data, err := json.MarshalIndent(out, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode validation result: %w", err)
+	}
+	return string(data) + "\n", nil
+}
@@ -0,0 +1,719 @@
+package excel
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+const (
+	summarySheet      = "サマリー"
+	dailySheet        = "日別明細"
+	accountTitleSheet = "勘定科目別"
+	taxSheet          = "消費税"
+
+	// 円表示（マイナスは赤字）
+	yenFormat  = `"¥"#,##0;[Red]"¥"-#,##0`
+	dateFormat = "yyyy-mm-dd"
+	// 組み込み書式 10: 0.00%
+	percentFormat = 10
+
+	maxSheetNameLength = 31
+)
+
+type Exporter struct{}
+
+func NewExporter() *Exporter {
+	return &Exporter{}
+}
+
+type styles struct {
+	title        int
+	header       int
+	label        int
+	date         int
+	yen          int
+	percent      int
+	totalLabel   int
+	totalYen     int
+	totalPercent int
+}
+
+// 会社シートの1行（会社・倉庫・日付単位）
+type companyRow struct {
+	Date          time.Time
+	WarehouseID   uint
+	WarehouseName string
+	Sales         float64
+	Cost          float64
+}
+
+type companySheet struct {
+	CompanyID   uint
+	CompanyName string
+	SheetName   string
+	Rows        []companyRow
+	TotalRow    int
+}
+
+// 勘定科目別シートの1行
+type accountTitleRow struct {
+	Kind   string
+	Code   string
+	Name   string
+	Amount float64
+}
+
+func (e *Exporter) WriteFile(path string, report *entity.ProfitReport) error {
+	f, err := e.build(report)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := f.SaveAs(path); err != nil {
+		return fmt.Errorf("failed to save workbook: %w", err)
+	}
+	return nil
+}
+
+func (e *Exporter) Write(w io.Writer, report *entity.ProfitReport) error {
+	f, err := e.build(report)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	if err := f.Write(w); err != nil {
+		return fmt.Errorf("failed to write workbook: %w", err)
+	}
+	return nil
+}
+
+func (e *Exporter) build(report *entity.ProfitReport) (*excelize.File, error) {
+	f := excelize.NewFile()
+
+	st, err := newStyles(f)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	// 既定の Sheet1 をサマリーシートとして使う
+	if err := f.SetSheetName("Sheet1", summarySheet); err != nil {
+		f.Close()
+		return nil, fmt.Errorf("failed to rename sheet: %w", err)
+	}
+
+	dailyTotalRow, err := writeDailySheet(f, st, report)
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	companies := groupByCompany(report)
+	for i := range companies {
+		if err := writeCompanySheet(f, st, &companies[i]); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+
+	if err := writeAccountTitleSheet(f, st, report); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	if report.Tax != nil {
+		if err := writeTaxSheet(f, st, report.Tax); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+
+	if err := writeSummarySheet(f, st, report, dailyTotalRow, companies); err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	f.SetActiveSheet(0)
+	return f, nil
+}
+
+func newStyles(f *excelize.File) (*styles, error) {
+	yen := yenFormat
+	date := dateFormat
+	border := []excelize.Border{
+		{Type: "left", Color: "BFBFBF", Style: 1},
+		{Type: "right", Color: "BFBFBF", Style: 1},
+		{Type: "top", Color: "BFBFBF", Style: 1},
+		{Type: "bottom", Color: "BFBFBF", Style: 1},
+	}
+	totalFill := excelize.Fill{Type: "pattern", Color: []string{"F2F2F2"}, Pattern: 1}
+	bold := &excelize.Font{Bold: true}
+
+	st := &styles{}
+	definitions := []struct {
+		target *int
+		style  *excelize.Style
+	}{
+		{&st.title, &excelize.Style{Font: &excelize.Font{Bold: true, Size: 14}}},
+		{&st.header, &excelize.Style{
+			Font:      bold,
+			Fill:      excelize.Fill{Type: "pattern", Color: []string{"DDEBF7"}, Pattern: 1},
+			Border:    border,
+			Alignment: &excelize.Alignment{Horizontal: "center"},
+		}},
+		{&st.label, &excelize.Style{Border: border}},
+		{&st.date, &excelize.Style{Border: border, CustomNumFmt: &date}},
+		{&st.yen, &excelize.Style{Border: border, CustomNumFmt: &yen}},
+		{&st.percent, &excelize.Style{Border: border, NumFmt: percentFormat}},
+		{&st.totalLabel, &excelize.Style{Border: border, Font: bold, Fill: totalFill}},
+		{&st.totalYen, &excelize.Style{Border: border, Font: bold, Fill: totalFill, CustomNumFmt: &yen}},
+		{&st.totalPercent, &excelize.Style{Border: border, Font: bold, Fill: totalFill, NumFmt: percentFormat}},
+	}
+
+	for _, def := range definitions {
+		id, err := f.NewStyle(def.style)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create style: %w", err)
+		}
+		*def.target = id
+	}
+
+	return st, nil
+}
+
+func writeSummarySheet(f *excelize.File, st *styles, report *entity.ProfitReport, dailyTotalRow int, companies []companySheet) error {
+	sheet := summarySheet
+	daily := sheetRef(dailySheet)
+
+	cells := []struct {
+		cell  string
+		value interface{}
+		style int
+	}{
+		{"A1", "売上・コスト・粗利レポート", st.title},
+		{"A3", "会社", st.label},
+		{"B3", fmt.Sprintf("%s (ID: %d)", report.CompanyName, report.CompanyID), st.label},
+		{"A4", "倉庫", st.label},
+		{"B4", fmt.Sprintf("%s (ID: %d)", report.WarehouseName, report.WarehouseID), st.label},
+		{"A5", "期間", st.label},
+		{"B5", fmt.Sprintf("%s ~ %s", report.StartDate.Format("2006-01-02"), report.EndDate.Format("2006-01-02")), st.label},
+		{"A7", "【期間合計】", st.header},
+		{"B7", "金額", st.header},
+		{"A8", "売上高", st.label},
+		{"A9", "コスト", st.label},
+		{"A10", "粗利益", st.label},
+		{"A11", "粗利率", st.label},
+	}
+	for _, c := range cells {
+		if err := setCell(f, sheet, c.cell, c.value, c.style); err != nil {
+			return err
+		}
+	}
+
+	formulas := []struct {
+		cell    string
+		formula string
+		style   int
+	}{
+		{"B8", fmt.Sprintf("%s!B%d", daily, dailyTotalRow), st.yen},
+		{"B9", fmt.Sprintf("%s!C%d", daily, dailyTotalRow), st.yen},
+		{"B10", "B8-B9", st.yen},
+		{"B11", "IF(B8=0,0,B10/B8)", st.percent},
+	}
+	for _, c := range formulas {
+		if err := setFormula(f, sheet, c.cell, c.formula, c.style); err != nil {
+			return err
+		}
+	}
+
+	// 無効化された勘定科目を含めた場合は、合計のうちの金額を横に示す
+	if report.IncludesDisabledTitles {
+		if err := setCell(f, sheet, "C7", "うち無効科目", st.header); err != nil {
+			return err
+		}
+		if err := setCell(f, sheet, "C8", report.InactiveSales, st.yen); err != nil {
+			return err
+		}
+		if err := setCell(f, sheet, "C9", report.InactiveCost, st.yen); err != nil {
+			return err
+		}
+	}
+
+	// 会社別サマリー（各会社シートの合計行を参照）
+	row := 13
+	if err := setRow(f, sheet, row, st.header, "【会社別】", "売上", "コスト", "粗利", "粗利率"); err != nil {
+		return err
+	}
+	first := row + 1
+	for _, company := range companies {
+		row++
+		ref := sheetRef(company.SheetName)
+		if err := setCell(f, sheet, cellName(1, row), company.CompanyName, st.label); err != nil {
+			return err
+		}
+		for col, source := range []string{"C", "D"} {
+			if err := setFormula(f, sheet, cellName(col+2, row), fmt.Sprintf("%s!%s%d", ref, source, company.TotalRow), st.yen); err != nil {
+				return err
+			}
+		}
+		if err := setProfitFormulas(f, sheet, row, "B", "C", "D", "E", st.yen, st.percent); err != nil {
+			return err
+		}
+	}
+	last := row
+	row++
+	if err := setTotalRow(f, st, sheet, row, first, last, []string{"B", "C"}, "D", "E"); err != nil {
+		return err
+	}
+
+	if err := f.SetColWidth(sheet, "A", "A", 18); err != nil {
+		return fmt.Errorf("failed to set column width: %w", err)
+	}
+	return f.SetColWidth(sheet, "B", "E", 18)
+}
+
+func writeDailySheet(f *excelize.File, st *styles, report *entity.ProfitReport) (int, error) {
+	sheet := dailySheet
+	if _, err := f.NewSheet(sheet); err != nil {
+		return 0, fmt.Errorf("failed to create sheet: %w", err)
+	}
+
+	if err := setRow(f, sheet, 1, st.header, "日付", "売上", "コスト", "粗利", "粗利率"); err != nil {
+		return 0, err
+	}
+
+	row := 1
+	for _, daily := range report.DailyReports {
+		row++
+		if err := setCell(f, sheet, cellName(1, row), daily.Date, st.date); err != nil {
+			return 0, err
+		}
+		if err := setCell(f, sheet, cellName(2, row), daily.Sales, st.yen); err != nil {
+			return 0, err
+		}
+		if err := setCell(f, sheet, cellName(3, row), daily.Cost, st.yen); err != nil {
+			return 0, err
+		}
+		if err := setProfitFormulas(f, sheet, row, "B", "C", "D", "E", st.yen, st.percent); err != nil {
+			return 0, err
+		}
+	}
+
+	totalRow := row + 1
+	if err := setTotalRow(f, st, sheet, totalRow, 2, row, []string{"B", "C"}, "D", "E"); err != nil {
+		return 0, err
+	}
+
+	if err := freezeHeader(f, sheet); err != nil {
+		return 0, err
+	}
+	if err := f.SetColWidth(sheet, "A", "E", 16); err != nil {
+		return 0, fmt.Errorf("failed to set column width: %w", err)
+	}
+	return totalRow, nil
+}
+
+func writeCompanySheet(f *excelize.File, st *styles, company *companySheet) error {
+	sheet := company.SheetName
+	if _, err := f.NewSheet(sheet); err != nil {
+		return fmt.Errorf("failed to create sheet: %w", err)
+	}
+
+	if err := setRow(f, sheet, 1, st.header, "日付", "倉庫", "売上", "コスト", "粗利", "粗利率"); err != nil {
+		return err
+	}
+
+	row := 1
+	for _, r := range company.Rows {
+		row++
+		if err := setCell(f, sheet, cellName(1, row), r.Date, st.date); err != nil {
+			return err
+		}
+		if err := setCell(f, sheet, cellName(2, row), r.WarehouseName, st.label); err != nil {
+			return err
+		}
+		if err := setCell(f, sheet, cellName(3, row), r.Sales, st.yen); err != nil {
+			return err
+		}
+		if err := setCell(f, sheet, cellName(4, row), r.Cost, st.yen); err != nil {
+			return err
+		}
+		if err := setProfitFormulas(f, sheet, row, "C", "D", "E", "F", st.yen, st.percent); err != nil {
+			return err
+		}
+	}
+
+	company.TotalRow = row + 1
+	if err := setTotalRow(f, st, sheet, company.TotalRow, 2, row, []string{"C", "D"}, "E", "F"); err != nil {
+		return err
+	}
+
+	if err := freezeHeader(f, sheet); err != nil {
+		return err
+	}
+	if err := f.SetColWidth(sheet, "A", "F", 16); err != nil {
+		return fmt.Errorf("failed to set column width: %w", err)
+	}
+	return nil
+}
+
+func writeAccountTitleSheet(f *excelize.File, st *styles, report *entity.ProfitReport) error {
+	sheet := accountTitleSheet
+	if _, err := f.NewSheet(sheet); err != nil {
+		return fmt.Errorf("failed to create sheet: %w", err)
+	}
+
+	if err := setRow(f, sheet, 1, st.header, "区分", "科目コード", "科目名", "金額", "構成比"); err != nil {
+		return err
+	}
+
+	rows := append(sumByAccountTitle("売上", report.SalesDetails), sumByAccountTitle("コスト", report.CostDetails)...)
+	last := len(rows) + 1
+	for i, r := range rows {
+		row := i + 2
+		for col, value := range []interface{}{r.Kind, r.Code, r.Name} {
+			if err := setCell(f, sheet, cellName(col+1, row), value, st.label); err != nil {
+				return err
+			}
+		}
+		if err := setCell(f, sheet, cellName(4, row), r.Amount, st.yen); err != nil {
+			return err
+		}
+		// 区分内での構成比
+		share := fmt.Sprintf("IF(SUMIF($A$2:$A$%d,A%d,$D$2:$D$%d)=0,0,D%d/SUMIF($A$2:$A$%d,A%d,$D$2:$D$%d))",
+			last, row, last, row, last, row, last)
+		if err := setFormula(f, sheet, cellName(5, row), share, st.percent); err != nil {
+			return err
+		}
+	}
+
+	row := last + 1
+	for _, kind := range []string{"売上", "コスト"} {
+		row++
+		if err := setCell(f, sheet, cellName(1, row), kind+"合計", st.totalLabel); err != nil {
+			return err
+		}
+		for col := 2; col <= 3; col++ {
+			if err := setCell(f, sheet, cellName(col, row), "", st.totalLabel); err != nil {
+				return err
+			}
+		}
+		if err := setFormula(f, sheet, cellName(4, row), fmt.Sprintf(`SUMIF($A$2:$A$%d,"%s",$D$2:$D$%d)`, last, kind, last), st.totalYen); err != nil {
+			return err
+		}
+		if err := setFormula(f, sheet, cellName(5, row), fmt.Sprintf("IF(D%d=0,0,1)", row), st.totalPercent); err != nil {
+			return err
+		}
+	}
+
+	if err := freezeHeader(f, sheet); err != nil {
+		return err
+	}
+	if err := f.SetColWidth(sheet, "A", "B", 14); err != nil {
+		return fmt.Errorf("failed to set column width: %w", err)
+	}
+	return f.SetColWidth(sheet, "C", "E", 18)
+}
+
+// writeTaxSheet 税抜・税込の合計と税率別の消費税額を出力する
+func writeTaxSheet(f *excelize.File, st *styles, tax *entity.TaxSummary) error {
+	sheet := taxSheet
+	if _, err := f.NewSheet(sheet); err != nil {
+		return fmt.Errorf("failed to create sheet: %w", err)
+	}
+
+	basis := "税抜"
+	if tax.Basis == entity.TaxBasisInclusive {
+		basis = "税込"
+	}
+	if err := setCell(f, sheet, "A1", "元データの金額: "+basis, st.title); err != nil {
+		return err
+	}
+
+	if err := setRow(f, sheet, 3, st.header, "", "税抜", "消費税", "税込"); err != nil {
+		return err
+	}
+	totals := []struct {
+		label    string
+		excluded float64
+		tax      float64
+	}{
+		{"売上高", tax.SalesExcludingTax, tax.SalesTax},
+		{"コスト", tax.CostExcludingTax, tax.CostTax},
+	}
+	for i, total := range totals {
+		row := i + 4
+		if err := setCell(f, sheet, cellName(1, row), total.label, st.label); err != nil {
+			return err
+		}
+		if err := setCell(f, sheet, cellName(2, row), total.excluded, st.yen); err != nil {
+			return err
+		}
+		if err := setCell(f, sheet, cellName(3, row), total.tax, st.yen); err != nil {
+			return err
+		}
+		if err := setFormula(f, sheet, cellName(4, row), fmt.Sprintf("B%d+C%d", row, row), st.yen); err != nil {
+			return err
+		}
+	}
+	if err := setCell(f, sheet, "A6", "粗利益", st.totalLabel); err != nil {
+		return err
+	}
+	for _, col := range []string{"B", "C", "D"} {
+		if err := setFormula(f, sheet, col+"6", fmt.Sprintf("%s4-%s5", col, col), st.totalYen); err != nil {
+			return err
+		}
+	}
+	if err := setCell(f, sheet, "A7", "非課税売上", st.label); err != nil {
+		return err
+	}
+	if err := setCell(f, sheet, "B7", tax.NonTaxableSales, st.yen); err != nil {
+		return err
+	}
+	if err := setCell(f, sheet, "A8", "非課税コスト", st.label); err != nil {
+		return err
+	}
+	if err := setCell(f, sheet, "B8", tax.NonTaxableCost, st.yen); err != nil {
+		return err
+	}
+
+	header := 10
+	if err := setRow(f, sheet, header, st.header, "税率", "売上(税抜)", "売上消費税", "コスト(税抜)", "コスト消費税"); err != nil {
+		return err
+	}
+	for i, breakdown := range tax.ByRate {
+		row := header + 1 + i
+		if err := setCell(f, sheet, cellName(1, row), breakdown.Rate, st.percent); err != nil {
+			return err
+		}
+		for col, value := range []float64{breakdown.SalesBase, breakdown.SalesTax, breakdown.CostBase, breakdown.CostTax} {
+			if err := setCell(f, sheet, cellName(col+2, row), value, st.yen); err != nil {
+				return err
+			}
+		}
+	}
+	first, last := header+1, header+len(tax.ByRate)
+	total := last + 1
+	if err := setCell(f, sheet, cellName(1, total), "合計", st.totalLabel); err != nil {
+		return err
+	}
+	for _, col := range []string{"B", "C", "D", "E"} {
+		formula := "0"
+		if last >= first {
+			formula = fmt.Sprintf("SUM(%s%d:%s%d)", col, first, col, last)
+		}
+		if err := setFormula(f, sheet, fmt.Sprintf("%s%d", col, total), formula, st.totalYen); err != nil {
+			return err
+		}
+	}
+
+	if err := f.SetColWidth(sheet, "A", "A", 14); err != nil {
+		return fmt.Errorf("failed to set column width: %w", err)
+	}
+	return f.SetColWidth(sheet, "B", "E", 18)
+}
+
+// groupByCompany 勘定科目別の内訳を会社ごとに会社・倉庫・日付単位で集計する
+func groupByCompany(report *entity.ProfitReport) []companySheet {
+	type key struct {
+		companyID   uint
+		warehouseID uint
+		date        time.Time
+	}
+	rows := make(map[key]*companyRow)
+	names := make(map[uint]string)
+
+	add := func(details []entity.AccountTitleAmount, isSales bool) {
+		for _, d := range details {
+			k := key{d.CompanyID, d.WarehouseID, d.Date}
+			r, ok := rows[k]
+			if !ok {
+				r = &companyRow{Date: d.Date, WarehouseID: d.WarehouseID, WarehouseName: d.WarehouseName}
+				rows[k] = r
+			}
+			if isSales {
+				r.Sales += d.Amount
+			} else {
+				r.Cost += d.Amount
+			}
+			names[d.CompanyID] = d.CompanyName
+		}
+	}
+	add(report.SalesDetails, true)
+	add(report.CostDetails, false)
+
+	byCompany := make(map[uint][]companyRow)
+	for k, r := range rows {
+		byCompany[k.companyID] = append(byCompany[k.companyID], *r)
+	}
+
+	var companies []companySheet
+	used := map[string]bool{summarySheet: true, dailySheet: true, accountTitleSheet: true, taxSheet: true}
+	for id, companyRows := range byCompany {
+		sort.Slice(companyRows, func(i, j int) bool {
+			if !companyRows[i].Date.Equal(companyRows[j].Date) {
+				return companyRows[i].Date.Before(companyRows[j].Date)
+			}
+			return companyRows[i].WarehouseID < companyRows[j].WarehouseID
+		})
+		companies = append(companies, companySheet{CompanyID: id, CompanyName: names[id], Rows: companyRows})
+	}
+	sort.Slice(companies, func(i, j int) bool {
+		return companies[i].CompanyID < companies[j].CompanyID
+	})
+	for i := range companies {
+		companies[i].SheetName = uniqueSheetName(companies[i].CompanyName, used)
+	}
+
+	return companies
+}
+
+// sumByAccountTitle 期間内の金額を勘定科目ごとに合計する
+func sumByAccountTitle(kind string, details []entity.AccountTitleAmount) []accountTitleRow {
+	totals := make(map[uint]*accountTitleRow)
+	var ids []uint
+	for _, d := range details {
+		r, ok := totals[d.AccountTitleID]
+		if !ok {
+			r = &accountTitleRow{Kind: kind, Code: d.AccountTitleCode, Name: d.AccountTitleName}
+			if d.AccountTitleDisabled {
+				r.Name += "（無効）"
+			}
+			totals[d.AccountTitleID] = r
+			ids = append(ids, d.AccountTitleID)
+		}
+		r.Amount += d.Amount
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+	rows := make([]accountTitleRow, 0, len(ids))
+	for _, id := range ids {
+		rows = append(rows, *totals[id])
+	}
+	return rows
+}
+
+// setProfitFormulas 粗利（売上-コスト）と粗利率の数式を設定する
+func setProfitFormulas(f *excelize.File, sheet string, row int, salesCol, costCol, profitCol, rateCol string, yenStyle, percentStyle int) error {
+	profit := fmt.Sprintf("%s%d-%s%d", salesCol, row, costCol, row)
+	if err := setFormula(f, sheet, fmt.Sprintf("%s%d", profitCol, row), profit, yenStyle); err != nil {
+		return err
+	}
+	rate := fmt.Sprintf("IF(%s%d=0,0,%s%d/%s%d)", salesCol, row, profitCol, row, salesCol, row)
+	return setFormula(f, sheet, fmt.Sprintf("%s%d", rateCol, row), rate, percentStyle)
+}
+
+// setTotalRow 合計行（SUM と粗利・粗利率の数式）を設定する
+func setTotalRow(f *excelize.File, st *styles, sheet string, row, first, last int, sumCols []string, profitCol, rateCol string) error {
+	if err := setCell(f, sheet, cellName(1, row), "合計", st.totalLabel); err != nil {
+		return err
+	}
+
+	// 合計列より左の空欄にも合計行の書式を適用する
+	firstSumCol, _, err := excelize.CellNameToCoordinates(sumCols[0] + "1")
+	if err != nil {
+		return fmt.Errorf("invalid column: %w", err)
+	}
+	for col := 2; col < firstSumCol; col++ {
+		if err := setCell(f, sheet, cellName(col, row), "", st.totalLabel); err != nil {
+			return err
+		}
+	}
+
+	for _, col := range sumCols {
+		formula := "0"
+		if last >= first {
+			formula = fmt.Sprintf("SUM(%s%d:%s%d)", col, first, col, last)
+		}
+		if err := setFormula(f, sheet, fmt.Sprintf("%s%d", col, row), formula, st.totalYen); err != nil {
+			return err
+		}
+	}
+	return setProfitFormulas(f, sheet, row, sumCols[0], sumCols[1], profitCol, rateCol, st.totalYen, st.totalPercent)
+}
+
+func setRow(f *excelize.File, sheet string, row, style int, values ...string) error {
+	for i, v := range values {
+		if err := setCell(f, sheet, cellName(i+1, row), v, style); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setCell(f *excelize.File, sheet, cell string, value interface{}, style int) error {
+	if err := f.SetCellValue(sheet, cell, value); err != nil {
+		return fmt.Errorf("failed to set cell %s!%s: %w", sheet, cell, err)
+	}
+	if err := f.SetCellStyle(sheet, cell, cell, style); err != nil {
+		return fmt.Errorf("failed to set style %s!%s: %w", sheet, cell, err)
+	}
+	return nil
+}
+
+func setFormula(f *excelize.File, sheet, cell, formula string, style int) error {
+	if err := f.SetCellFormula(sheet, cell, formula); err != nil {
+		return fmt.Errorf("failed to set formula %s!%s: %w", sheet, cell, err)
+	}
+	if err := f.SetCellStyle(sheet, cell, cell, style); err != nil {
+		return fmt.Errorf("failed to set style %s!%s: %w", sheet, cell, err)
+	}
+	return nil
+}
+
+// freezeHeader 1行目（見出し）を固定する
+func freezeHeader(f *excelize.File, sheet string) error {
+	if err := f.SetPanes(sheet, &excelize.Panes{
+		Freeze:      true,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	}); err != nil {
+		return fmt.Errorf("failed to freeze header: %w", err)
+	}
+	return nil
+}
+
+func cellName(col, row int) string {
+	name, _ := excelize.CoordinatesToCellName(col, row)
+	return name
+}
+
+// sheetRef 数式で参照するためのシート名（'シート名'）
+func sheetRef(sheet string) string {
+	return "'" + strings.ReplaceAll(sheet, "'", "''") + "'"
+}
+
+// uniqueSheetName Excel のシート名制約（31文字・禁止文字）に合わせ、重複しない名前を返す
+func uniqueSheetName(name string, used map[string]bool) string {
+	name = strings.NewReplacer(":", "_", "\\", "_", "/", "_", "?", "_", "*", "_", "[", "_", "]", "_").Replace(name)
+	name = strings.Trim(name, "'")
+	if name == "" {
+		name = "会社"
+	}
+
+	candidate := truncateRunes(name, maxSheetNameLength)
+	for i := 2; used[candidate]; i++ {
+		suffix := fmt.Sprintf(" (%d)", i)
+		candidate = truncateRunes(name, maxSheetNameLength-len(suffix)) + suffix
+	}
+	used[candidate] = true
+	return candidate
+}
+
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) > n {
+		return string(runes[:n])
+	}
+	return s
+}
@@ -0,0 +1,197 @@
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+const namespace = "profit_report"
+
+var (
+	titleLabels   = []string{"period", "company_id", "company", "warehouse_id", "warehouse", "account_title"}
+	companyLabels = []string{"period", "company_id", "company", "warehouse_id", "warehouse"}
+)
+
+// Exporter 売上・コスト・粗利のKPIを Prometheus のゲージとして公開する
+type Exporter struct {
+	registry *prometheus.Registry
+
+	kpi *kpiCollector
+
+	lastRefresh     prometheus.Gauge
+	refreshDuration prometheus.Gauge
+	refreshErrors   prometheus.Counter
+}
+
+func NewExporter() *Exporter {
+	e := &Exporter{
+		registry: prometheus.NewRegistry(),
+		kpi: &kpiCollector{
+			sales: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "sales_yen"),
+				"Sales amount by company, warehouse and sales account title.", titleLabels, nil),
+			cost: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "cost_yen"),
+				"Cost amount by company, warehouse and cost account title (after shared cost allocation unless disabled).", titleLabels, nil),
+			grossProfit: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "gross_profit_yen"),
+				"Gross profit (sales - cost) by company and warehouse.", companyLabels, nil),
+			grossMargin: prometheus.NewDesc(prometheus.BuildFQName(namespace, "", "gross_margin_ratio"),
+				"Gross profit / sales by company and warehouse (only when sales > 0).", companyLabels, nil),
+		},
+		lastRefresh: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "last_refresh_timestamp_seconds",
+			Help:      "Unix time of the last successful refresh.",
+		}),
+		refreshDuration: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: namespace,
+			Name:      "refresh_duration_seconds",
+			Help:      "Duration of the last refresh.",
+		}),
+		refreshErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "refresh_errors_total",
+			Help:      "Number of failed refreshes.",
+		}),
+	}
+
+	e.registry.MustRegister(
+		e.kpi,
+		e.lastRefresh, e.refreshDuration, e.refreshErrors,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return e
+}
+
+// Handler /metrics のハンドラ
+func (e *Exporter) Handler() http.Handler {
+	return promhttp.HandlerFor(e.registry, promhttp.HandlerOpts{})
+}
+
+// 会社・倉庫・勘定科目のキー
+type titleKey struct {
+	companyID   uint
+	warehouseID uint
+	title       string
+}
+
+// 会社・倉庫の合計
+type companyTotal struct {
+	companyName   string
+	warehouseName string
+	sales         float64
+	cost          float64
+}
+
+// kpiCollector 売上・コスト・粗利の系列を更新ごとのスナップショットとして公開する
+// 更新中のスクレイプで系列が欠けないよう、新しい値をすべて作ってから差し替える
+type kpiCollector struct {
+	sales       *prometheus.Desc
+	cost        *prometheus.Desc
+	grossProfit *prometheus.Desc
+	grossMargin *prometheus.Desc
+
+	mu      sync.RWMutex
+	metrics []prometheus.Metric
+}
+
+func (c *kpiCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- c.sales
+	ch <- c.cost
+	ch <- c.grossProfit
+	ch <- c.grossMargin
+}
+
+func (c *kpiCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, m := range c.metrics {
+		ch <- m
+	}
+}
+
+// Update 取得したKPIで系列をまとめて置き換える（前回あって今回ない系列は公開されなくなる）
+func (e *Exporter) Update(snapshots []entity.KPISnapshot) {
+	var metrics []prometheus.Metric
+	for _, snapshot := range snapshots {
+		period := snapshot.Period.Name
+		totals := make(map[[2]uint]*companyTotal)
+		totalFor := func(amount entity.AccountTitleAmount) *companyTotal {
+			k := [2]uint{amount.CompanyID, amount.WarehouseID}
+			total, ok := totals[k]
+			if !ok {
+				total = &companyTotal{companyName: amount.CompanyName, warehouseName: amount.WarehouseName}
+				totals[k] = total
+			}
+			return total
+		}
+
+		metrics = appendByTitle(metrics, e.kpi.sales, period, snapshot.Sales, func(amount entity.AccountTitleAmount) {
+			totalFor(amount).sales += amount.Amount
+		})
+		metrics = appendByTitle(metrics, e.kpi.cost, period, snapshot.Cost, func(amount entity.AccountTitleAmount) {
+			totalFor(amount).cost += amount.Amount
+		})
+
+		for k, total := range totals {
+			labels := []string{
+				period,
+				strconv.FormatUint(uint64(k[0]), 10),
+				total.companyName,
+				strconv.FormatUint(uint64(k[1]), 10),
+				total.warehouseName,
+			}
+			profit := total.sales - total.cost
+			metrics = append(metrics, prometheus.MustNewConstMetric(e.kpi.grossProfit, prometheus.GaugeValue, profit, labels...))
+			if total.sales > 0 {
+				metrics = append(metrics, prometheus.MustNewConstMetric(e.kpi.grossMargin, prometheus.GaugeValue, profit/total.sales, labels...))
+			}
+		}
+	}
+
+	e.kpi.mu.Lock()
+	e.kpi.metrics = metrics
+	e.kpi.mu.Unlock()
+}
+
+// appendByTitle 日付をまたいで会社・倉庫・勘定科目別に合計して系列に加える
+func appendByTitle(metrics []prometheus.Metric, desc *prometheus.Desc, period string, amounts []entity.AccountTitleAmount, each func(entity.AccountTitleAmount)) []prometheus.Metric {
+	sums := make(map[titleKey]float64)
+	names := make(map[titleKey]entity.AccountTitleAmount)
+	for _, amount := range amounts {
+		k := titleKey{amount.CompanyID, amount.WarehouseID, amount.AccountTitleCode}
+		sums[k] += amount.Amount
+		names[k] = amount
+		each(amount)
+	}
+
+	for k, sum := range sums {
+		amount := names[k]
+		metrics = append(metrics, prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, sum,
+			period,
+			strconv.FormatUint(uint64(k.companyID), 10),
+			amount.CompanyName,
+			strconv.FormatUint(uint64(k.warehouseID), 10),
+			amount.WarehouseName,
+			k.title,
+		))
+	}
+	return metrics
+}
+
+// RecordRefresh 更新結果（所要時間・成功時刻・失敗回数）を記録する
+func (e *Exporter) RecordRefresh(startedAt time.Time, err error) {
+	e.refreshDuration.Set(time.Since(startedAt).Seconds())
+	if err != nil {
+		e.refreshErrors.Inc()
+		return
+	}
+	e.lastRefresh.Set(float64(time.Now().Unix()))
+}
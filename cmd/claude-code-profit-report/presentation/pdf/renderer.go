@@ -0,0 +1,475 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/jung-kurt/gofpdf"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+const (
+	// 日本語フォントのパスを指定する環境変数
+	FontPathEnv = "PDF_FONT_PATH"
+
+	fontFamily = "jp"
+
+	pageMargin   = 20.0
+	footerHeight = 15.0
+	rowHeight    = 6.5
+)
+
+// フォント未指定時に探索する日本語TrueTypeフォント
+var defaultFontPaths = []string{
+	"/usr/share/fonts/opentype/ipaexfont-gothic/ipaexg.ttf",
+	"/usr/share/fonts/truetype/ipaexfont-gothic/ipaexg.ttf",
+	"/usr/share/fonts/truetype/fonts-japanese-gothic.ttf",
+	"/usr/share/fonts/truetype/takao-gothic/TakaoPGothic.ttf",
+	"/usr/share/fonts/ipa-gothic/ipag.ttf",
+	"/Library/Fonts/ipaexg.ttf",
+}
+
+type rgb struct{ r, g, b int }
+
+var (
+	colorHeader = rgb{31, 78, 121}
+	colorBorder = rgb{191, 191, 191}
+	colorShade  = rgb{242, 242, 242}
+	colorText   = rgb{33, 33, 33}
+	colorMuted  = rgb{110, 110, 110}
+	colorLoss   = rgb{192, 0, 0}
+	colorSales  = rgb{46, 117, 182}
+	colorCost   = rgb{237, 125, 49}
+	colorProfit = rgb{84, 130, 53}
+)
+
+type Renderer struct {
+	font []byte
+	now  func() time.Time
+}
+
+// fontPath が空の場合は環境変数 PDF_FONT_PATH、既知のフォントパスの順に探索する
+func NewRenderer(fontPath string) (*Renderer, error) {
+	path, err := ResolveFontPath(fontPath)
+	if err != nil {
+		return nil, err
+	}
+
+	font, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read font: %w", err)
+	}
+	// gofpdf は TrueType Collection (.ttc) / CFFベースの OpenType を扱えない
+	if bytes.HasPrefix(font, []byte("ttcf")) || bytes.HasPrefix(font, []byte("OTTO")) {
+		return nil, fmt.Errorf("unsupported font format: %s (TrueType .ttf is required)", path)
+	}
+
+	return &Renderer{font: font, now: time.Now}, nil
+}
+
+func ResolveFontPath(fontPath string) (string, error) {
+	if fontPath != "" {
+		return fontPath, nil
+	}
+	if path := os.Getenv(FontPathEnv); path != "" {
+		return path, nil
+	}
+	for _, path := range defaultFontPaths {
+		if _, err := os.Stat(path); err == nil {
+			return path, nil
+		}
+	}
+	return "", fmt.Errorf("japanese TrueType font not found: specify --font or %s (e.g. IPAexGothic ipaexg.ttf)", FontPathEnv)
+}
+
+func (r *Renderer) WriteFile(path string, report *entity.ProfitReport) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+
+	if err := r.Write(file, report); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func (r *Renderer) Write(w io.Writer, report *entity.ProfitReport) error {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pageMargin, pageMargin, pageMargin)
+	pdf.SetAutoPageBreak(false, footerHeight)
+	pdf.AddUTF8FontFromBytes(fontFamily, "", r.font)
+	pdf.AddUTF8FontFromBytes(fontFamily, "B", r.font)
+	pdf.SetTitle(fmt.Sprintf("粗利レポート %s", report.CompanyName), true)
+	pdf.AliasNbPages("")
+
+	generatedAt := r.now().Format("2006-01-02 15:04")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-footerHeight)
+		setTextColor(pdf, colorMuted)
+		pdf.SetFont(fontFamily, "", 8)
+		pdf.CellFormat(0, 8, "作成日時: "+generatedAt, "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 8, fmt.Sprintf("%d / {nb}", pdf.PageNo()), "", 0, "R", false, 0, "")
+	})
+
+	pdf.AddPage()
+	r.drawHeader(pdf, report)
+	r.drawTotals(pdf, report)
+	r.drawChart(pdf, report.DailyReports)
+	r.drawDailyTable(pdf, report)
+
+	if err := pdf.Output(w); err != nil {
+		return fmt.Errorf("failed to render pdf: %w", err)
+	}
+	return nil
+}
+
+func (r *Renderer) drawHeader(pdf *gofpdf.Fpdf, report *entity.ProfitReport) {
+	width := contentWidth(pdf)
+
+	setFillColor(pdf, colorHeader)
+	pdf.Rect(pageMargin, pageMargin, width, 14, "F")
+	pdf.SetTextColor(255, 255, 255)
+	pdf.SetFont(fontFamily, "B", 16)
+	pdf.SetXY(pageMargin+4, pageMargin)
+	pdf.CellFormat(width-8, 14, "売上・コスト・粗利レポート", "", 1, "L", false, 0, "")
+
+	pdf.Ln(3)
+	setTextColor(pdf, colorText)
+	pdf.SetFont(fontFamily, "", 10)
+	lines := [][2]string{
+		{"会社", labelWithID(report.CompanyName, report.CompanyID)},
+		{"倉庫", labelWithID(report.WarehouseName, report.WarehouseID)},
+		{"期間", fmt.Sprintf("%s ～ %s（%d日間）",
+			report.StartDate.Format("2006-01-02"),
+			report.EndDate.Format("2006-01-02"),
+			int(report.EndDate.Sub(report.StartDate).Hours()/24)+1)},
+	}
+	for _, line := range lines {
+		pdf.SetX(pageMargin)
+		setTextColor(pdf, colorMuted)
+		pdf.CellFormat(16, 6, line[0], "", 0, "L", false, 0, "")
+		setTextColor(pdf, colorText)
+		pdf.CellFormat(width-16, 6, line[1], "", 1, "L", false, 0, "")
+	}
+	pdf.Ln(4)
+}
+
+// 期間合計を4つのボックスで表示する
+func (r *Renderer) drawTotals(pdf *gofpdf.Fpdf, report *entity.ProfitReport) {
+	const boxHeight = 20.0
+	const gap = 4.0
+
+	boxes := []struct {
+		label string
+		value string
+		color rgb
+		loss  bool
+	}{
+		{"売上高", formatYen(report.TotalSales), colorSales, false},
+		{"コスト", formatYen(report.TotalCost), colorCost, false},
+		{"粗利益", formatYen(report.GrossProfit), colorProfit, report.GrossProfit < 0},
+		{"粗利率", fmt.Sprintf("%.2f%%", report.GrossProfitRate), colorProfit, report.GrossProfitRate < 0},
+	}
+
+	boxWidth := (contentWidth(pdf) - gap*float64(len(boxes)-1)) / float64(len(boxes))
+	y := pdf.GetY()
+	for i, box := range boxes {
+		x := pageMargin + float64(i)*(boxWidth+gap)
+
+		setFillColor(pdf, colorShade)
+		setDrawColor(pdf, colorBorder)
+		pdf.SetLineWidth(0.2)
+		pdf.Rect(x, y, boxWidth, boxHeight, "FD")
+		setFillColor(pdf, box.color)
+		pdf.Rect(x, y, 1.5, boxHeight, "F")
+
+		pdf.SetXY(x+3, y+2)
+		setTextColor(pdf, colorMuted)
+		pdf.SetFont(fontFamily, "", 9)
+		pdf.CellFormat(boxWidth-5, 6, box.label, "", 0, "L", false, 0, "")
+
+		pdf.SetXY(x+3, y+9)
+		if box.loss {
+			setTextColor(pdf, colorLoss)
+		} else {
+			setTextColor(pdf, colorText)
+		}
+		pdf.SetFont(fontFamily, "B", 13)
+		pdf.CellFormat(boxWidth-5, 9, box.value, "", 0, "R", false, 0, "")
+	}
+
+	pdf.SetXY(pageMargin, y+boxHeight+8)
+}
+
+// 売上・コスト・粗利の日別推移を折れ線グラフで描画する
+func (r *Renderer) drawChart(pdf *gofpdf.Fpdf, daily []entity.DailyProfitReport) {
+	const chartHeight = 70.0
+	const axisWidth = 22.0
+
+	width := contentWidth(pdf)
+	sectionTitle(pdf, "日別推移")
+
+	if len(daily) == 0 {
+		setTextColor(pdf, colorMuted)
+		pdf.SetFont(fontFamily, "", 10)
+		pdf.CellFormat(width, 10, "対象期間のデータがありません", "", 1, "C", false, 0, "")
+		pdf.Ln(4)
+		return
+	}
+
+	series := []struct {
+		label  string
+		color  rgb
+		values []float64
+	}{
+		{"売上", colorSales, make([]float64, len(daily))},
+		{"コスト", colorCost, make([]float64, len(daily))},
+		{"粗利", colorProfit, make([]float64, len(daily))},
+	}
+	minValue, maxValue := 0.0, 0.0
+	for i, d := range daily {
+		series[0].values[i] = d.Sales
+		series[1].values[i] = d.Cost
+		series[2].values[i] = d.GrossProfit
+		for _, v := range []float64{d.Sales, d.Cost, d.GrossProfit} {
+			minValue = math.Min(minValue, v)
+			maxValue = math.Max(maxValue, v)
+		}
+	}
+
+	// 凡例
+	y := pdf.GetY()
+	pdf.SetFont(fontFamily, "", 8)
+	x := pageMargin + axisWidth
+	for _, s := range series {
+		setFillColor(pdf, s.color)
+		pdf.Rect(x, y+1.5, 6, 2, "F")
+		setTextColor(pdf, colorText)
+		pdf.SetXY(x+7, y)
+		labelWidth := pdf.GetStringWidth(s.label) + 6
+		pdf.CellFormat(labelWidth, 5, s.label, "", 0, "L", false, 0, "")
+		x += 7 + labelWidth
+	}
+
+	top := y + 8
+	left := pageMargin + axisWidth
+	plotWidth := width - axisWidth
+	plotHeight := chartHeight - 14
+	bottom := top + plotHeight
+
+	step := niceStep((maxValue - minValue) / 5)
+	low := math.Floor(minValue/step) * step
+	high := math.Ceil(maxValue/step) * step
+	if high == low {
+		high = low + step
+	}
+	toY := func(v float64) float64 {
+		return bottom - (v-low)/(high-low)*plotHeight
+	}
+	toX := func(i int) float64 {
+		if len(daily) == 1 {
+			return left + plotWidth/2
+		}
+		return left + plotWidth*float64(i)/float64(len(daily)-1)
+	}
+
+	// 目盛りとグリッド
+	pdf.SetFont(fontFamily, "", 7)
+	pdf.SetLineWidth(0.1)
+	for v := low; v <= high+step/2; v += step {
+		gy := toY(v)
+		if v == 0 {
+			setDrawColor(pdf, colorMuted)
+		} else {
+			setDrawColor(pdf, colorBorder)
+		}
+		pdf.Line(left, gy, left+plotWidth, gy)
+		setTextColor(pdf, colorMuted)
+		pdf.SetXY(pageMargin, gy-2)
+		pdf.CellFormat(axisWidth-2, 4, formatAxis(v), "", 0, "R", false, 0, "")
+	}
+
+	// 日付ラベルは最終日から逆算して間引く
+	labelEvery := int(math.Ceil(float64(len(daily)) / 10))
+	for i := len(daily) - 1; i >= 0; i -= labelEvery {
+		lx := toX(i)
+		pdf.SetXY(lx-8, bottom+1)
+		pdf.CellFormat(16, 4, daily[i].Date.Format("01/02"), "", 0, "C", false, 0, "")
+	}
+
+	// 系列
+	pdf.SetLineWidth(0.5)
+	pdf.SetLineJoinStyle("round")
+	for _, s := range series {
+		setDrawColor(pdf, s.color)
+		setFillColor(pdf, s.color)
+		for i := 1; i < len(s.values); i++ {
+			pdf.Line(toX(i-1), toY(s.values[i-1]), toX(i), toY(s.values[i]))
+		}
+		if len(s.values) <= 31 {
+			for i, v := range s.values {
+				pdf.Circle(toX(i), toY(v), 0.7, "F")
+			}
+		}
+	}
+
+	pdf.SetLineWidth(0.2)
+	pdf.SetXY(pageMargin, bottom+10)
+}
+
+func (r *Renderer) drawDailyTable(pdf *gofpdf.Fpdf, report *entity.ProfitReport) {
+	columns := []struct {
+		title string
+		width float64
+		align string
+	}{
+		{"日付", 30, "C"},
+		{"売上", 38, "R"},
+		{"コスト", 38, "R"},
+		{"粗利", 38, "R"},
+		{"粗利率", 26, "R"},
+	}
+
+	drawHeader := func() {
+		setFillColor(pdf, colorHeader)
+		setDrawColor(pdf, colorBorder)
+		pdf.SetTextColor(255, 255, 255)
+		pdf.SetFont(fontFamily, "B", 9)
+		pdf.SetX(pageMargin)
+		for _, c := range columns {
+			pdf.CellFormat(c.width, rowHeight+1, c.title, "1", 0, "C", true, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+	drawRow := func(cells []string, loss bool, total bool) {
+		_, pageHeight := pdf.GetPageSize()
+		if pdf.GetY()+rowHeight > pageHeight-footerHeight-5 {
+			pdf.AddPage()
+			drawHeader()
+		}
+
+		style := ""
+		if total {
+			style = "B"
+		}
+		pdf.SetFont(fontFamily, style, 9)
+		setFillColor(pdf, colorShade)
+		pdf.SetX(pageMargin)
+		for i, c := range columns {
+			setTextColor(pdf, colorText)
+			// 粗利・粗利率がマイナスの場合は赤字で表示
+			if loss && i >= 3 {
+				setTextColor(pdf, colorLoss)
+			}
+			pdf.CellFormat(c.width, rowHeight, cells[i], "1", 0, c.align, total, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+
+	sectionTitle(pdf, "日別明細")
+	drawHeader()
+	for _, d := range report.DailyReports {
+		drawRow([]string{
+			d.Date.Format("2006-01-02"),
+			formatYen(d.Sales),
+			formatYen(d.Cost),
+			formatYen(d.GrossProfit),
+			fmt.Sprintf("%.2f%%", d.GrossProfitRate),
+		}, d.GrossProfit < 0, false)
+	}
+	drawRow([]string{
+		"合計",
+		formatYen(report.TotalSales),
+		formatYen(report.TotalCost),
+		formatYen(report.GrossProfit),
+		fmt.Sprintf("%.2f%%", report.GrossProfitRate),
+	}, report.GrossProfit < 0, true)
+}
+
+func sectionTitle(pdf *gofpdf.Fpdf, title string) {
+	_, pageHeight := pdf.GetPageSize()
+	if pdf.GetY()+30 > pageHeight-footerHeight {
+		pdf.AddPage()
+	}
+
+	setTextColor(pdf, colorHeader)
+	pdf.SetFont(fontFamily, "B", 12)
+	pdf.SetX(pageMargin)
+	pdf.CellFormat(contentWidth(pdf), 8, title, "", 1, "L", false, 0, "")
+	setDrawColor(pdf, colorHeader)
+	pdf.SetLineWidth(0.4)
+	pdf.Line(pageMargin, pdf.GetY(), pageMargin+contentWidth(pdf), pdf.GetY())
+	pdf.SetLineWidth(0.2)
+	pdf.Ln(3)
+}
+
+func contentWidth(pdf *gofpdf.Fpdf) float64 {
+	width, _ := pdf.GetPageSize()
+	return width - pageMargin*2
+}
+
+func labelWithID(name string, id uint) string {
+	if id == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s (ID: %d)", name, id)
+}
+
+func setTextColor(pdf *gofpdf.Fpdf, c rgb) { pdf.SetTextColor(c.r, c.g, c.b) }
+func setFillColor(pdf *gofpdf.Fpdf, c rgb) { pdf.SetFillColor(c.r, c.g, c.b) }
+func setDrawColor(pdf *gofpdf.Fpdf, c rgb) { pdf.SetDrawColor(c.r, c.g, c.b) }
+
+// 軸ラベル用に万・億単位へ丸める
+func formatAxis(v float64) string {
+	abs := math.Abs(v)
+	switch {
+	case abs >= 1e8:
+		return trimZero(v/1e8) + "億"
+	case abs >= 1e4:
+		return trimZero(v/1e4) + "万"
+	default:
+		return trimZero(v)
+	}
+}
+
+func trimZero(v float64) string {
+	s := fmt.Sprintf("%.1f", v)
+	return strings.TrimSuffix(s, ".0")
+}
+
+func formatYen(amount float64) string {
+	str := fmt.Sprintf("%.0f", math.Abs(amount))
+	var sb strings.Builder
+	for i, digit := range str {
+		if i > 0 && (len(str)-i)%3 == 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteRune(digit)
+	}
+	if amount <= -0.5 {
+		return "¥-" + sb.String()
+	}
+	return "¥" + sb.String()
+}
+
+// 目盛り間隔を 1, 2, 5 × 10^n に丸める
+func niceStep(raw float64) float64 {
+	if raw <= 0 {
+		return 1
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, m := range []float64{1, 2, 5, 10} {
+		if raw <= m*magnitude {
+			return m * magnitude
+		}
+	}
+	return 10 * magnitude
+}
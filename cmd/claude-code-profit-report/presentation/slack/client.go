@@ -2,14 +2,26 @@ package slack
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
 )
 
+const tracerName = "github.com/taka512/golang/cmd/claude-code-profit-report/presentation/slack"
+
 type Client struct {
 	webhookURL string
 	httpClient *http.Client
@@ -39,15 +51,37 @@ type TextObject struct {
 	Text string `json:"text"`
 }
 
-func (c *Client) SendProfitReport(report *entity.ProfitReport) error {
-	message := c.formatProfitReport(report)
+func (c *Client) SendProfitReport(ctx context.Context, report *entity.ProfitReport) error {
+	return c.send(ctx, "profit_report", c.formatProfitReport(report))
+}
+
+// send メッセージをWebhookに送信する（URLには認証情報が含まれるためスパンには記録しない）
+func (c *Client) send(ctx context.Context, kind string, message Message) (err error) {
+	ctx, span := otel.Tracer(tracerName).Start(ctx, "Slack.send",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("slack.message", kind), attribute.Int("slack.blocks", len(message.Blocks))),
+	)
+	defer func() {
+		if err != nil {
+			// url.Error はWebhookのURLを含むため、原因のエラーだけを記録する
+			spanErr := err
+			var urlErr *url.Error
+			if errors.As(err, &urlErr) {
+				spanErr = urlErr.Err
+			}
+			span.RecordError(spanErr)
+			span.SetStatus(codes.Error, spanErr.Error())
+		}
+		span.End()
+	}()
 
 	payload, err := json.Marshal(message)
 	if err != nil {
 		return fmt.Errorf("failed to marshal message: %w", err)
 	}
+	span.SetAttributes(attribute.Int("http.request.body.size", len(payload)))
 
-	req, err := http.NewRequest("POST", c.webhookURL, bytes.NewBuffer(payload))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.webhookURL, bytes.NewBuffer(payload))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -60,6 +94,7 @@ func (c *Client) SendProfitReport(report *entity.ProfitReport) error {
 	}
 	defer resp.Body.Close()
 
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
 	if resp.StatusCode != http.StatusOK {
 		return fmt.Errorf("slack webhook returned status code: %d", resp.StatusCode)
 	}
@@ -94,10 +129,10 @@ func (c *Client) formatProfitReport(report *entity.ProfitReport) Message {
 			Type: "section",
 			Text: &TextObject{
 				Type: "mrkdwn",
-				Text: fmt.Sprintf("*【期間合計】*\n売上高: ¥%,.0f\nコスト: ¥%,.0f\n粗利益: ¥%,.0f\n粗利率: %.2f%%",
-					report.TotalSales,
-					report.TotalCost,
-					report.GrossProfit,
+				Text: fmt.Sprintf("*【期間合計】*\n売上高: %s\nコスト: %s\n粗利益: %s\n粗利率: %.2f%%",
+					formatYen(report.TotalSales),
+					formatYen(report.TotalCost),
+					formatYen(report.GrossProfit),
 					report.GrossProfitRate),
 			},
 		},
@@ -137,4 +172,20 @@ func (c *Client) formatProfitReport(report *entity.ProfitReport) Message {
 		Text:   fmt.Sprintf("売上・コスト・粗利レポート (%s ~ %s)", report.StartDate.Format("2006-01-02"), report.EndDate.Format("2006-01-02")),
 		Blocks: blocks,
 	}
-}
\ No newline at end of file
+}
+
+// formatYen 3桁区切りの円表記に変換する（fmt は %, 書式に対応していないため）
+func formatYen(amount float64) string {
+	str := fmt.Sprintf("%.0f", math.Abs(amount))
+	var sb strings.Builder
+	for i, digit := range str {
+		if i > 0 && (len(str)-i)%3 == 0 {
+			sb.WriteString(",")
+		}
+		sb.WriteRune(digit)
+	}
+	if amount <= -0.5 {
+		return "¥-" + sb.String()
+	}
+	return "¥" + sb.String()
+}
@@ -0,0 +1,84 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+)
+
+// Slackのメッセージ長制限を考慮して明細の表示件数を制限する
+const maxCorrectionLines = 30
+
+func (c *Client) SendCorrectionNotice(ctx context.Context, report *entity.CorrectionReport) error {
+	return c.send(ctx, "correction_notice", c.formatCorrectionNotice(report))
+}
+
+func (c *Client) formatCorrectionNotice(report *entity.CorrectionReport) Message {
+	blocks := []Block{
+		{
+			Type: "section",
+			Text: &TextObject{
+				Type: "mrkdwn",
+				Text: fmt.Sprintf(":warning: *報告済みデータの修正（訂正）*\n%s 以降に更新された日次データのうち、報告済み期間の値が %d 件変更されています。",
+					report.Since.Format("2006-01-02 15:04:05"),
+					len(report.Corrections)),
+			},
+		},
+		{
+			Type: "section",
+			Text: &TextObject{
+				Type: "mrkdwn",
+				Text: fmt.Sprintf("*【差分合計】*\n売上: %s\nコスト: %s\n粗利: %s",
+					formatSignedYen(report.TotalSalesDelta()),
+					formatSignedYen(report.TotalCostDelta()),
+					formatSignedYen(report.TotalSalesDelta()-report.TotalCostDelta())),
+			},
+		},
+		{
+			Type: "divider",
+		},
+	}
+
+	var sb strings.Builder
+	sb.WriteString("*【修正内容（旧 → 新）】*\n```\n")
+	for i, correction := range report.Corrections {
+		if i == maxCorrectionLines {
+			sb.WriteString(fmt.Sprintf("... 他 %d 件\n", len(report.Corrections)-maxCorrectionLines))
+			break
+		}
+		current := correction.Current
+		sb.WriteString(fmt.Sprintf("%s %s / %s\n", current.Date.Format("2006-01-02"), current.CompanyName, current.WarehouseName))
+		if correction.OldKnown {
+			sb.WriteString(fmt.Sprintf("  売上 %s → %s  コスト %s → %s  粗利 %s → %s\n",
+				formatYen(correction.OldSales), formatYen(current.Sales),
+				formatYen(correction.OldCost), formatYen(current.Cost),
+				formatYen(correction.OldGrossProfit()), formatYen(current.GrossProfit())))
+		} else {
+			sb.WriteString(fmt.Sprintf("  売上 (報告時不明) → %s  コスト (報告時不明) → %s  粗利 → %s\n",
+				formatYen(current.Sales), formatYen(current.Cost), formatYen(current.GrossProfit())))
+		}
+	}
+	sb.WriteString("```")
+
+	blocks = append(blocks, Block{
+		Type: "section",
+		Text: &TextObject{
+			Type: "mrkdwn",
+			Text: sb.String(),
+		},
+	})
+
+	return Message{
+		Text:   fmt.Sprintf("報告済みデータの修正 %d件 (%s 以降)", len(report.Corrections), report.Since.Format("2006-01-02 15:04")),
+		Blocks: blocks,
+	}
+}
+
+func formatSignedYen(amount float64) string {
+	if amount >= 0.5 {
+		return "+" + formatYen(amount)
+	}
+	return formatYen(amount)
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// 中断時の終了コード（cron などから原因を区別できるよう timeout(1) やシェルの慣習に合わせる）
+const (
+	exitFailure     = 1
+	exitTimeout     = 124
+	exitInterrupted = 130
+)
+
+// completedSteps 中断時に表示するため、完了した処理を順に記録する
+var completedSteps []string
+
+func markDone(step string) {
+	completedSteps = append(completedSteps, step)
+}
+
+// reportFailure エラーを表示し、中断・タイムアウトの場合は完了済みの処理も表示して終了コードを返す
+func reportFailure(err error, interrupted bool) int {
+	code := exitFailure
+	switch {
+	case interrupted:
+		fmt.Fprintln(os.Stderr, "停止シグナルを受信したため処理を中断しました")
+		code = exitInterrupted
+	case errors.Is(err, context.DeadlineExceeded):
+		fmt.Fprintf(os.Stderr, "タイムアウトのため処理を中断しました (--timeout=%s, --query-timeout=%s)\n", timeout, queryTimeout)
+		code = exitTimeout
+	}
+
+	if code != exitFailure {
+		if len(completedSteps) > 0 {
+			fmt.Fprintf(os.Stderr, "完了した処理: %s\n", strings.Join(completedSteps, ", "))
+		} else {
+			fmt.Fprintln(os.Stderr, "完了した処理はありません")
+		}
+	}
+
+	fmt.Fprintln(os.Stderr, err)
+	return code
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/config"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/infrastructure/database"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/presentation/cli"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/presentation/pdf"
+)
+
+func newRunsCommand() *cobra.Command {
+	runsCmd := &cobra.Command{
+		Use:   "runs",
+		Short: "保存済みレポートの一覧・再出力・差分表示",
+	}
+
+	var (
+		listCompanyID   uint
+		listWarehouseID uint
+		listLimit       int
+	)
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "保存済みレポートを新しい順に表示する",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return withContainer(cmd, func(ctx context.Context, container *config.Container) error {
+				runs, err := container.ReportRunUseCase.ListReportRuns(ctx, repository.ReportRunFilter{
+					CompanyID:   listCompanyID,
+					WarehouseID: listWarehouseID,
+					Limit:       listLimit,
+				})
+				if err != nil {
+					return err
+				}
+				fmt.Print(cli.NewTextFormatter().FormatReportRuns(runs))
+				return nil
+			})
+		},
+	}
+	listCmd.Flags().UintVarP(&listCompanyID, "company", "c", 0, "会社IDで絞り込む")
+	listCmd.Flags().UintVarP(&listWarehouseID, "warehouse", "w", 0, "倉庫IDで絞り込む")
+	listCmd.Flags().IntVarP(&listLimit, "limit", "n", 20, "表示件数 (0: 全件)")
+
+	var (
+		showFormat string
+		showOutput string
+		showFont   string
+	)
+	showCmd := &cobra.Command{
+		Use:   "show <実行ID>",
+		Short: "保存済みレポートを保存時の数値で再出力する",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseRunID(args[0])
+			if err != nil {
+				return err
+			}
+			if err := validateFormat(showFormat); err != nil {
+				return err
+			}
+
+			var pdfRenderer *pdf.Renderer
+			if showFormat == "pdf" {
+				pdfRenderer, err = pdf.NewRenderer(showFont)
+				if err != nil {
+					return fmt.Errorf("failed to prepare pdf renderer: %w", err)
+				}
+			}
+
+			return withContainer(cmd, func(ctx context.Context, container *config.Container) error {
+				run, err := container.ReportRunUseCase.GetReportRun(ctx, id)
+				if err != nil {
+					return err
+				}
+
+				path := showOutput
+				if showFormat == "text" {
+					fmt.Print(cli.NewTextFormatter().FormatReportRunHeader(run))
+				} else if path == "" {
+					path = fmt.Sprintf("profit_report_run%d_%s_%s.%s", run.ID,
+						run.Report.StartDate.Format("20060102"), run.Report.EndDate.Format("20060102"), showFormat)
+				}
+				return writeReport(&run.Report, showFormat, path, pdfRenderer)
+			})
+		},
+	}
+	showCmd.Flags().StringVar(&showFormat, "format", "text", "出力形式 (text / xlsx / pdf)")
+	showCmd.Flags().StringVarP(&showOutput, "output", "o", "", "出力ファイル (xlsx/pdf時 未指定: profit_report_run<ID>_<開始日>_<終了日>.<形式>)")
+	showCmd.Flags().StringVar(&showFont, "font", "", "PDFに埋め込む日本語TrueTypeフォント (未指定時: 環境変数PDF_FONT_PATH)")
+
+	var failOnChange bool
+	diffCmd := &cobra.Command{
+		Use:   "diff <実行ID>",
+		Short: "保存済みレポートと現在のデータで再生成したレポートの差分を表示する",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := parseRunID(args[0])
+			if err != nil {
+				return err
+			}
+
+			return withContainer(cmd, func(ctx context.Context, container *config.Container) error {
+				diff, err := container.ReportRunUseCase.DiffReportRun(ctx, id)
+				if err != nil {
+					return err
+				}
+				fmt.Print(cli.NewTextFormatter().FormatReportDiff(diff))
+				if failOnChange && diff.HasChanges() {
+					os.Exit(1)
+				}
+				return nil
+			})
+		},
+	}
+	diffCmd.Flags().BoolVar(&failOnChange, "exit-code", false, "差分がある場合に終了コード1で終了する")
+
+	runsCmd.AddCommand(listCmd, showCmd, diffCmd)
+	return runsCmd
+}
+
+func withContainer(cmd *cobra.Command, fn func(ctx context.Context, container *config.Container) error) error {
+	db, err := database.NewDB(cmd.Context(), database.NewDBConfig())
+	if err != nil {
+		return fmt.Errorf("failed to connect to database: %w", err)
+	}
+	defer db.Close()
+
+	return fn(cmd.Context(), config.NewContainer(db))
+}
+
+func parseRunID(arg string) (uint64, error) {
+	id, err := strconv.ParseUint(arg, 10, 64)
+	if err != nil || id == 0 {
+		return 0, fmt.Errorf("invalid run id: %s", arg)
+	}
+	return id, nil
+}
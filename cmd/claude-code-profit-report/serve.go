@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/config"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/presentation/api"
+)
+
+func newServeCommand() *cobra.Command {
+	var (
+		serveListen          string
+		serveShutdownTimeout time.Duration
+		serveMaxBodyBytes    int64
+	)
+
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "粗利レポートを JSON で返し、日次レポートの登録を受け付ける HTTP API を起動する",
+		Long: `粗利レポートを HTTP API で提供します。リクエストには apikey create で発行したキーを
+Authorization: Bearer <キー> または X-API-Key ヘッダーで指定します。
+manager 権限のキーは割り当てられた会社のデータのみ参照でき、会社を指定しない集計も担当会社分に限定されます。
+日次レポートの登録は admin と ingest 権限のキーのみ可能で、ingest 権限のキーは割り当てられた会社のみ登録でき、参照はできません。
+全てのリクエストは監査ログ (apikey audit) に記録されます。
+
+  GET  /api/v1/profit-report?start=YYYY-MM-DD&end=YYYY-MM-DD[&company=ID&warehouse=ID&details=true&tax=true&tax_basis=inclusive&allocation=true]
+  GET  /api/v1/companies
+  POST /api/v1/daily-reports (Content-Type: application/json、本文は --max-body-bytes まで)
+  GET  /healthz`,
+		Args:        cobra.NoArgs,
+		Annotations: map[string]string{annotationLongRunning: "true"},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if serveMaxBodyBytes <= 0 {
+				return fmt.Errorf("max-body-bytes must be positive")
+			}
+
+			return withContainer(cmd, func(ctx context.Context, container *config.Container) error {
+				handler := api.NewServer(
+					container.AccessUseCase,
+					container.ProfitReportUseCase,
+					container.CostAllocationUseCase,
+					container.TaxUseCase,
+					container.ImportUseCase,
+					container.CompanyRepository,
+					serveMaxBodyBytes,
+				).Handler()
+
+				server := &http.Server{
+					Addr:              serveListen,
+					Handler:           handler,
+					ReadHeaderTimeout: 10 * time.Second,
+					// 日次レポートの本文をゆっくり送り続ける接続を切断する
+					ReadTimeout: 60 * time.Second,
+					// --query-timeout とトレースを各リクエストに引き継ぐ（停止シグナルでは処理中のリクエストを中断しない）
+					BaseContext: func(net.Listener) context.Context { return context.WithoutCancel(ctx) },
+				}
+
+				serverErr := make(chan error, 1)
+				go func() {
+					if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+						serverErr <- err
+					}
+					close(serverErr)
+				}()
+				log.Printf("粗利レポートAPIを起動しました: http://%s/api/v1/profit-report", serveListen)
+
+				select {
+				case err := <-serverErr:
+					return fmt.Errorf("failed to serve api: %w", err)
+				case <-ctx.Done():
+					log.Println("停止シグナルを受信しました。処理中のリクエストの完了を待って停止します...")
+					shutdownCtx, cancel := context.WithTimeout(context.Background(), serveShutdownTimeout)
+					defer cancel()
+					return server.Shutdown(shutdownCtx)
+				}
+			})
+		},
+	}
+
+	cmd.Flags().StringVar(&serveListen, "listen", ":8080", "待ち受けアドレス")
+	cmd.Flags().Int64Var(&serveMaxBodyBytes, "max-body-bytes", 1<<20, "日次レポート登録のリクエスト本文の上限（バイト）")
+	cmd.Flags().DurationVar(&serveShutdownTimeout, "shutdown-timeout", 30*time.Second, "停止時に処理中のリクエストの完了を待つ時間")
+
+	return cmd
+}
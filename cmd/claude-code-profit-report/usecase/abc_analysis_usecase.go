@@ -0,0 +1,137 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+)
+
+type ABCAnalysisUseCase interface {
+	// 期間内の売上・粗利で会社（または会社・倉庫）をABC分析する（allocate が true の場合は共通費配賦後のコストを使う）
+	Analyze(ctx context.Context, groupBy entity.ABCGroupBy, startDate, endDate time.Time, cutoffs entity.ABCCutoffs, minMargin float64, allocate bool) (*entity.ABCAnalysis, error)
+}
+
+type abcAnalysisUseCaseImpl struct {
+	salesRepo         repository.SalesRepository
+	costRepo          repository.CostRepository
+	companyRepo       repository.CompanyRepository
+	contractRepo      repository.ContractRepository
+	allocationUseCase CostAllocationUseCase
+}
+
+func NewABCAnalysisUseCase(
+	salesRepo repository.SalesRepository,
+	costRepo repository.CostRepository,
+	companyRepo repository.CompanyRepository,
+	contractRepo repository.ContractRepository,
+	allocationUseCase CostAllocationUseCase,
+) ABCAnalysisUseCase {
+	return &abcAnalysisUseCaseImpl{
+		salesRepo:         salesRepo,
+		costRepo:          costRepo,
+		companyRepo:       companyRepo,
+		contractRepo:      contractRepo,
+		allocationUseCase: allocationUseCase,
+	}
+}
+
+func (u *abcAnalysisUseCaseImpl) Analyze(ctx context.Context, groupBy entity.ABCGroupBy, startDate, endDate time.Time, cutoffs entity.ABCCutoffs, minMargin float64, allocate bool) (*entity.ABCAnalysis, error) {
+	if groupBy != entity.ABCByCompany && groupBy != entity.ABCByCompanyWarehouse {
+		return nil, fmt.Errorf("unsupported group: %s", groupBy)
+	}
+	if cutoffs.A <= 0 || cutoffs.A >= cutoffs.B || cutoffs.B > 100 {
+		return nil, fmt.Errorf("invalid cutoffs: A=%g B=%g (0 < A < B <= 100)", cutoffs.A, cutoffs.B)
+	}
+
+	sales, err := u.salesRepo.GetAccountTitleAmountsByPeriod(ctx, 0, 0, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sales: %w", err)
+	}
+	cost, err := u.costRepo.GetAccountTitleAmountsByPeriod(ctx, 0, 0, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cost: %w", err)
+	}
+	if allocate {
+		adjustments, err := u.allocationUseCase.CalculateAdjustments(ctx, 0, startDate, endDate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to allocate shared cost: %w", err)
+		}
+		cost = append(cost, adjustments...)
+	}
+
+	type key struct {
+		companyID   uint
+		warehouseID uint
+	}
+	var keys []key
+	items := make(map[key]*entity.ABCItem)
+	itemFor := func(amount entity.AccountTitleAmount) *entity.ABCItem {
+		k := key{companyID: amount.CompanyID}
+		if groupBy == entity.ABCByCompanyWarehouse {
+			k.warehouseID = amount.WarehouseID
+		}
+		item, ok := items[k]
+		if !ok {
+			item = &entity.ABCItem{CompanyID: amount.CompanyID, CompanyName: amount.CompanyName}
+			if groupBy == entity.ABCByCompanyWarehouse {
+				item.WarehouseID = amount.WarehouseID
+				item.WarehouseName = amount.WarehouseName
+			}
+			items[k] = item
+			keys = append(keys, k)
+		}
+		return item
+	}
+
+	// 会社単位の場合は取引のない会社もC区分として含める
+	if groupBy == entity.ABCByCompany {
+		companies, err := u.companyRepo.GetAllCompanies(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get companies: %w", err)
+		}
+		for _, company := range companies {
+			itemFor(entity.AccountTitleAmount{CompanyID: company.ID, CompanyName: company.Name})
+		}
+	}
+	// 会社・倉庫単位の場合は期間内に契約のある組み合わせのみ、取引がなくてもC区分として含める
+	if groupBy == entity.ABCByCompanyWarehouse {
+		contracts, err := u.contractRepo.List(ctx, repository.ContractFilter{StartDate: &startDate, EndDate: &endDate})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get contracts: %w", err)
+		}
+		for _, contract := range contracts {
+			itemFor(entity.AccountTitleAmount{
+				CompanyID:     contract.CompanyID,
+				CompanyName:   contract.CompanyName,
+				WarehouseID:   contract.WarehouseID,
+				WarehouseName: contract.WarehouseName,
+			})
+		}
+	}
+
+	for _, amount := range sales {
+		itemFor(amount).Sales += amount.Amount
+	}
+	for _, amount := range cost {
+		itemFor(amount).Cost += amount.Amount
+	}
+
+	analysis := &entity.ABCAnalysis{
+		GroupBy:       groupBy,
+		StartDate:     startDate,
+		EndDate:       endDate,
+		Cutoffs:       cutoffs,
+		MinMargin:     minMargin,
+		CostAllocated: allocate,
+		Items:         make([]entity.ABCItem, 0, len(keys)),
+	}
+	for _, k := range keys {
+		analysis.Items = append(analysis.Items, *items[k])
+	}
+	analysis.Classify()
+
+	return analysis, nil
+}
@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+)
+
+// 発行するAPIキーの接頭辞（ログやリポジトリに紛れ込んだ場合に検出しやすくする）
+const apiKeyPrefix = "prk_"
+
+type AccessUseCase interface {
+	// APIキーを発行する（キーはこの戻り値でのみ得られ、ハッシュのみ保存する）
+	IssueAPIKey(ctx context.Context, name string, role entity.Role, companyIDs []uint) (*entity.APIKey, string, error)
+	// キーに対応する利用者を返す（無効・失効済みの場合は entity.ErrUnauthenticated）
+	Authenticate(ctx context.Context, rawKey string) (*entity.Principal, error)
+	ListAPIKeys(ctx context.Context) ([]entity.APIKey, error)
+	RevokeAPIKey(ctx context.Context, id uint64) error
+	RecordAudit(ctx context.Context, log *entity.AuditLog) error
+	ListAuditLogs(ctx context.Context, filter repository.AuditLogFilter) ([]entity.AuditLog, error)
+}
+
+type accessUseCaseImpl struct {
+	apiKeyRepo   repository.APIKeyRepository
+	auditLogRepo repository.AuditLogRepository
+	companyRepo  repository.CompanyRepository
+}
+
+func NewAccessUseCase(
+	apiKeyRepo repository.APIKeyRepository,
+	auditLogRepo repository.AuditLogRepository,
+	companyRepo repository.CompanyRepository,
+) AccessUseCase {
+	return &accessUseCaseImpl{
+		apiKeyRepo:   apiKeyRepo,
+		auditLogRepo: auditLogRepo,
+		companyRepo:  companyRepo,
+	}
+}
+
+func (u *accessUseCaseImpl) IssueAPIKey(ctx context.Context, name string, role entity.Role, companyIDs []uint) (*entity.APIKey, string, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, "", fmt.Errorf("api key name is required")
+	}
+
+	switch role {
+	case entity.RoleAdmin:
+		if len(companyIDs) > 0 {
+			return nil, "", fmt.Errorf("admin api key can access all companies: do not specify companies")
+		}
+	case entity.RoleManager, entity.RoleIngest:
+		if len(companyIDs) == 0 {
+			return nil, "", fmt.Errorf("%s api key requires at least one company", role)
+		}
+	default:
+		return nil, "", fmt.Errorf("unknown role: %s", role)
+	}
+
+	for _, companyID := range companyIDs {
+		if _, err := u.companyRepo.GetCompanyByID(ctx, companyID); err != nil {
+			return nil, "", fmt.Errorf("failed to get company: %w", err)
+		}
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, "", fmt.Errorf("failed to generate api key: %w", err)
+	}
+	rawKey := apiKeyPrefix + hex.EncodeToString(secret)
+
+	key := &entity.APIKey{
+		Name:       name,
+		Role:       role,
+		CompanyIDs: companyIDs,
+	}
+	if err := u.apiKeyRepo.Create(ctx, key, hashAPIKey(rawKey)); err != nil {
+		return nil, "", fmt.Errorf("failed to save api key: %w", err)
+	}
+
+	return key, rawKey, nil
+}
+
+func (u *accessUseCaseImpl) Authenticate(ctx context.Context, rawKey string) (*entity.Principal, error) {
+	if !strings.HasPrefix(rawKey, apiKeyPrefix) {
+		return nil, entity.ErrUnauthenticated
+	}
+
+	key, err := u.apiKeyRepo.FindActiveByHash(ctx, hashAPIKey(rawKey))
+	if err != nil {
+		return nil, fmt.Errorf("failed to authenticate: %w", err)
+	}
+	if key == nil {
+		return nil, entity.ErrUnauthenticated
+	}
+
+	// 最終利用日時は参考情報のため、更新に失敗しても認証は通す
+	if err := u.apiKeyRepo.TouchLastUsed(ctx, key.ID); err != nil {
+		log.Printf("APIキーの最終利用日時の更新に失敗しました (id=%d): %v", key.ID, err)
+	}
+
+	return key.Principal(), nil
+}
+
+func (u *accessUseCaseImpl) ListAPIKeys(ctx context.Context) ([]entity.APIKey, error) {
+	keys, err := u.apiKeyRepo.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list api keys: %w", err)
+	}
+	return keys, nil
+}
+
+func (u *accessUseCaseImpl) RevokeAPIKey(ctx context.Context, id uint64) error {
+	return u.apiKeyRepo.Revoke(ctx, id)
+}
+
+func (u *accessUseCaseImpl) RecordAudit(ctx context.Context, log *entity.AuditLog) error {
+	if err := u.auditLogRepo.Create(ctx, log); err != nil {
+		return fmt.Errorf("failed to record audit log: %w", err)
+	}
+	return nil
+}
+
+func (u *accessUseCaseImpl) ListAuditLogs(ctx context.Context, filter repository.AuditLogFilter) ([]entity.AuditLog, error) {
+	logs, err := u.auditLogRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list audit logs: %w", err)
+	}
+	return logs, nil
+}
+
+func hashAPIKey(rawKey string) string {
+	sum := sha256.Sum256([]byte(rawKey))
+	return hex.EncodeToString(sum[:])
+}
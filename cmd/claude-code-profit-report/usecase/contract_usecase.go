@@ -0,0 +1,123 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+)
+
+type ContractUseCase interface {
+	ListContracts(ctx context.Context, filter repository.ContractFilter) ([]entity.Contract, error)
+	// 会社・倉庫の契約を登録する（同じ組み合わせの契約と期間が重なる場合はエラー）
+	AddContract(ctx context.Context, companyID, warehouseID uint, startDate time.Time, endDate *time.Time) (*entity.Contract, error)
+	// 契約終了日を設定する（同じ組み合わせの契約と期間が重なる場合はエラー）
+	EndContract(ctx context.Context, id uint64, endDate time.Time) (*entity.Contract, error)
+}
+
+type contractUseCaseImpl struct {
+	contractRepo repository.ContractRepository
+	companyRepo  repository.CompanyRepository
+}
+
+func NewContractUseCase(contractRepo repository.ContractRepository, companyRepo repository.CompanyRepository) ContractUseCase {
+	return &contractUseCaseImpl{
+		contractRepo: contractRepo,
+		companyRepo:  companyRepo,
+	}
+}
+
+func (u *contractUseCaseImpl) ListContracts(ctx context.Context, filter repository.ContractFilter) ([]entity.Contract, error) {
+	contracts, err := u.contractRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contracts: %w", err)
+	}
+	return contracts, nil
+}
+
+func (u *contractUseCaseImpl) AddContract(ctx context.Context, companyID, warehouseID uint, startDate time.Time, endDate *time.Time) (*entity.Contract, error) {
+	contract := &entity.Contract{
+		CompanyID:   companyID,
+		WarehouseID: warehouseID,
+		StartDate:   startDate,
+		EndDate:     endDate,
+	}
+	if err := contract.Validate(); err != nil {
+		return nil, err
+	}
+
+	company, err := u.companyRepo.GetCompanyByID(ctx, companyID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get company: %w", err)
+	}
+	warehouse, err := u.companyRepo.GetWarehouseByID(ctx, warehouseID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get warehouse: %w", err)
+	}
+	contract.CompanyName = company.Name
+	contract.WarehouseName = warehouse.Name
+
+	if err := u.checkOverlap(ctx, contract); err != nil {
+		return nil, err
+	}
+	if err := u.contractRepo.Create(ctx, contract); err != nil {
+		return nil, fmt.Errorf("failed to create contract: %w", err)
+	}
+	return contract, nil
+}
+
+func (u *contractUseCaseImpl) EndContract(ctx context.Context, id uint64, endDate time.Time) (*entity.Contract, error) {
+	contract, err := u.contractRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get contract: %w", err)
+	}
+	if contract == nil {
+		return nil, fmt.Errorf("contract not found: id=%d", id)
+	}
+	if contract.EndDate != nil && contract.EndDate.Equal(endDate) {
+		return contract, nil
+	}
+
+	contract.EndDate = &endDate
+	if err := contract.Validate(); err != nil {
+		return nil, err
+	}
+	if err := u.checkOverlap(ctx, contract); err != nil {
+		return nil, err
+	}
+	if err := u.contractRepo.End(ctx, id, endDate); err != nil {
+		return nil, fmt.Errorf("failed to end contract: %w", err)
+	}
+	return contract, nil
+}
+
+// checkOverlap 同じ会社・倉庫の他の契約と期間が重なる場合はエラーを返す
+func (u *contractUseCaseImpl) checkOverlap(ctx context.Context, contract *entity.Contract) error {
+	existing, err := u.contractRepo.List(ctx, repository.ContractFilter{
+		CompanyID:   contract.CompanyID,
+		WarehouseID: contract.WarehouseID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list contracts: %w", err)
+	}
+
+	// 終了日なしの契約は期間の終わりを最大の日付として扱う
+	end := time.Date(9999, 12, 31, 0, 0, 0, 0, time.Local)
+	if contract.EndDate != nil {
+		end = *contract.EndDate
+	}
+	for _, other := range existing {
+		if other.ID == contract.ID || !other.Overlaps(contract.StartDate, end) {
+			continue
+		}
+		otherEnd := "open"
+		if other.EndDate != nil {
+			otherEnd = other.EndDate.Format("2006-01-02")
+		}
+		return fmt.Errorf("contract overlaps existing contract id=%d (%s ~ %s)",
+			other.ID, other.StartDate.Format("2006-01-02"), otherEnd)
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+)
+
+type CorrectionUseCase interface {
+	// since が nil の場合は前回チェック以降の修正を検出する
+	DetectCorrections(ctx context.Context, since *time.Time) (*entity.CorrectionReport, error)
+	// 通知済みの修正後の値を報告済みの値として記録し、チェック日時を保存する
+	ConfirmCorrections(ctx context.Context, report *entity.CorrectionReport) error
+}
+
+type correctionUseCaseImpl struct {
+	correctionRepo repository.CorrectionRepository
+}
+
+func NewCorrectionUseCase(correctionRepo repository.CorrectionRepository) CorrectionUseCase {
+	return &correctionUseCaseImpl{correctionRepo: correctionRepo}
+}
+
+func (u *correctionUseCaseImpl) DetectCorrections(ctx context.Context, since *time.Time) (*entity.CorrectionReport, error) {
+	// updated_at は秒単位のため、チェック日時も秒単位に揃えて次回との取りこぼしを防ぐ
+	report := &entity.CorrectionReport{CheckedAt: time.Now().Truncate(time.Second)}
+
+	if since != nil {
+		report.Since = *since
+	} else {
+		lastCheckedAt, ok, err := u.correctionRepo.GetLastCheckedAt(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			// 報告済みのレポートがないため検出対象がない
+			report.Since = report.CheckedAt
+			return report, nil
+		}
+		report.Since = lastCheckedAt
+	}
+
+	corrections, err := u.correctionRepo.FindCorrections(ctx, report.Since, report.CheckedAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find corrections: %w", err)
+	}
+
+	for _, correction := range corrections {
+		if correction.Changed() {
+			report.Corrections = append(report.Corrections, correction)
+		}
+	}
+
+	return report, nil
+}
+
+func (u *correctionUseCaseImpl) ConfirmCorrections(ctx context.Context, report *entity.CorrectionReport) error {
+	totals := make([]entity.DailyTotal, 0, len(report.Corrections))
+	for _, correction := range report.Corrections {
+		totals = append(totals, correction.Current)
+	}
+
+	if err := u.correctionRepo.SaveReportedDailyTotals(ctx, totals); err != nil {
+		return err
+	}
+	return u.correctionRepo.SaveCheck(ctx, report.Since, report.CheckedAt, len(report.Corrections))
+}
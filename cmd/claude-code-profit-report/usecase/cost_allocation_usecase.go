@@ -0,0 +1,278 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+)
+
+type CostAllocationUseCase interface {
+	GetAllocationRules(ctx context.Context) ([]entity.AllocationRule, error)
+	// 配賦ルールに基づく会社・倉庫・日付・科目別のコスト調整額（配賦後 - 計上額）を計算する（warehouseID が 0 の場合は全倉庫）
+	CalculateAdjustments(ctx context.Context, warehouseID uint, startDate, endDate time.Time) ([]entity.AccountTitleAmount, error)
+	// 配賦によるコスト調整額をレポートの日別・期間合計・勘定科目別のコストに反映する
+	ApplyAllocation(ctx context.Context, report *entity.ProfitReport) error
+}
+
+type costAllocationUseCaseImpl struct {
+	allocationRepo repository.CostAllocationRepository
+	salesRepo      repository.SalesRepository
+	costRepo       repository.CostRepository
+}
+
+func NewCostAllocationUseCase(
+	allocationRepo repository.CostAllocationRepository,
+	salesRepo repository.SalesRepository,
+	costRepo repository.CostRepository,
+) CostAllocationUseCase {
+	return &costAllocationUseCaseImpl{
+		allocationRepo: allocationRepo,
+		salesRepo:      salesRepo,
+		costRepo:       costRepo,
+	}
+}
+
+// 日別・期間全体の配賦基準（期間全体は日別の基準が0の日に使う）
+type allocationWeights struct {
+	daily  map[time.Time]map[uint]float64
+	period map[uint]float64
+}
+
+func (w *allocationWeights) add(date time.Time, companyID uint, value float64) {
+	if w.daily[date] == nil {
+		w.daily[date] = make(map[uint]float64)
+	}
+	w.daily[date][companyID] += value
+	w.period[companyID] += value
+}
+
+func (u *costAllocationUseCaseImpl) GetAllocationRules(ctx context.Context) ([]entity.AllocationRule, error) {
+	rules, err := u.allocationRepo.GetAllocationRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, rule := range rules {
+		switch rule.Method {
+		case entity.AllocationByShippedQuantity, entity.AllocationBySales:
+		case entity.AllocationFixed:
+			var total float64
+			for _, share := range rule.Shares {
+				total += share.Percentage
+			}
+			if math.Abs(total-100) > 0.0001 {
+				return nil, fmt.Errorf("cost allocation rule %d: fixed shares must add up to 100%% (got %g%%)", rule.ID, total)
+			}
+		default:
+			return nil, fmt.Errorf("cost allocation rule %d: unknown method: %s", rule.ID, rule.Method)
+		}
+	}
+
+	return rules, nil
+}
+
+func (u *costAllocationUseCaseImpl) CalculateAdjustments(ctx context.Context, warehouseID uint, startDate, endDate time.Time) ([]entity.AccountTitleAmount, error) {
+	rules, err := u.GetAllocationRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	costsByWarehouse := make(map[uint][]entity.AccountTitleAmount)
+	var adjustments []entity.AccountTitleAmount
+	for _, rule := range rules {
+		if warehouseID > 0 && rule.WarehouseID != warehouseID {
+			continue
+		}
+
+		costs, ok := costsByWarehouse[rule.WarehouseID]
+		if !ok {
+			costs, err = u.costRepo.GetAccountTitleAmountsByPeriod(ctx, 0, rule.WarehouseID, startDate, endDate)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get cost to allocate: %w", err)
+			}
+			costsByWarehouse[rule.WarehouseID] = costs
+		}
+
+		names := make(map[uint]string)
+		recorded := make(map[time.Time]map[uint]float64)
+		for _, cost := range costs {
+			if cost.AccountTitleID != rule.CostAccountTitleID {
+				continue
+			}
+			names[cost.CompanyID] = cost.CompanyName
+			if recorded[cost.Date] == nil {
+				recorded[cost.Date] = make(map[uint]float64)
+			}
+			recorded[cost.Date][cost.CompanyID] += cost.Amount
+		}
+		if len(recorded) == 0 {
+			continue
+		}
+
+		weights, err := u.buildWeights(ctx, rule, startDate, endDate, names)
+		if err != nil {
+			return nil, err
+		}
+
+		dates := make([]time.Time, 0, len(recorded))
+		for date := range recorded {
+			dates = append(dates, date)
+		}
+		sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+
+		for _, date := range dates {
+			var pool float64
+			for _, amount := range recorded[date] {
+				pool += amount
+			}
+
+			allocated := entity.Allocate(pool, weights.daily[date])
+			if allocated == nil {
+				allocated = entity.Allocate(pool, weights.period)
+			}
+			if allocated == nil {
+				// 配賦基準がない場合は計上した会社のコストのまま
+				continue
+			}
+
+			companies := make(map[uint]bool)
+			for id := range recorded[date] {
+				companies[id] = true
+			}
+			for id := range allocated {
+				companies[id] = true
+			}
+			ids := make([]uint, 0, len(companies))
+			for id := range companies {
+				ids = append(ids, id)
+			}
+			sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+			for _, id := range ids {
+				delta := allocated[id] - recorded[date][id]
+				if math.Abs(delta) < 0.0005 {
+					continue
+				}
+				adjustments = append(adjustments, entity.AccountTitleAmount{
+					CompanyID:        id,
+					CompanyName:      names[id],
+					WarehouseID:      rule.WarehouseID,
+					WarehouseName:    rule.WarehouseName,
+					Date:             date,
+					AccountTitleID:   rule.CostAccountTitleID,
+					AccountTitleCode: rule.CostAccountTitleCode,
+					AccountTitleName: rule.CostAccountTitleName,
+					Amount:           delta,
+				})
+			}
+		}
+	}
+
+	return adjustments, nil
+}
+
+// buildWeights 配賦方法に応じた配賦基準を作成し、配賦先の会社名を names に追加する
+func (u *costAllocationUseCaseImpl) buildWeights(ctx context.Context, rule entity.AllocationRule, startDate, endDate time.Time, names map[uint]string) (*allocationWeights, error) {
+	weights := &allocationWeights{
+		daily:  make(map[time.Time]map[uint]float64),
+		period: make(map[uint]float64),
+	}
+
+	switch rule.Method {
+	case entity.AllocationBySales:
+		sales, err := u.salesRepo.GetAccountTitleAmountsByPeriod(ctx, 0, rule.WarehouseID, startDate, endDate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get sales for allocation: %w", err)
+		}
+		for _, amount := range sales {
+			names[amount.CompanyID] = amount.CompanyName
+			weights.add(amount.Date, amount.CompanyID, amount.Amount)
+		}
+
+	case entity.AllocationByShippedQuantity:
+		quantities, err := u.allocationRepo.GetShippedQuantities(ctx, rule.WarehouseID, startDate, endDate)
+		if err != nil {
+			return nil, err
+		}
+		for _, quantity := range quantities {
+			names[quantity.CompanyID] = quantity.CompanyName
+			weights.add(quantity.Date, quantity.CompanyID, quantity.Quantity)
+		}
+
+	case entity.AllocationFixed:
+		// 日別の基準を持たないため、全日で期間全体の比率を使う
+		for _, share := range rule.Shares {
+			names[share.CompanyID] = share.CompanyName
+			weights.period[share.CompanyID] += share.Percentage
+		}
+	}
+
+	return weights, nil
+}
+
+func (u *costAllocationUseCaseImpl) ApplyAllocation(ctx context.Context, report *entity.ProfitReport) error {
+	adjustments, err := u.CalculateAdjustments(ctx, report.WarehouseID, report.StartDate, report.EndDate)
+	if err != nil {
+		return err
+	}
+	report.CostAllocated = true
+
+	byDate := make(map[time.Time]float64)
+	var applied []entity.AccountTitleAmount
+	for _, adjustment := range adjustments {
+		if report.CompanyID > 0 && adjustment.CompanyID != report.CompanyID {
+			continue
+		}
+		byDate[adjustment.Date] += adjustment.Amount
+		report.CostAllocationAdjustment += adjustment.Amount
+		applied = append(applied, adjustment)
+	}
+
+	for i := range report.DailyReports {
+		daily := &report.DailyReports[i]
+		date := time.Date(daily.Date.Year(), daily.Date.Month(), daily.Date.Day(), 0, 0, 0, 0, time.Local)
+		if delta, ok := byDate[date]; ok {
+			daily.Cost += delta
+			daily.CalculateGrossProfit()
+		}
+	}
+	report.TotalCost += report.CostAllocationAdjustment
+	report.CalculateGrossProfit()
+
+	if report.CostDetails != nil {
+		report.CostDetails = mergeAccountTitleAmounts(report.CostDetails, applied)
+	}
+
+	return nil
+}
+
+// mergeAccountTitleAmounts 会社・倉庫・日付・科目が同じ行に調整額を加算し、ない場合は行を追加する
+func mergeAccountTitleAmounts(amounts, adjustments []entity.AccountTitleAmount) []entity.AccountTitleAmount {
+	type key struct {
+		companyID      uint
+		warehouseID    uint
+		date           time.Time
+		accountTitleID uint
+	}
+
+	index := make(map[key]int, len(amounts))
+	for i, amount := range amounts {
+		index[key{amount.CompanyID, amount.WarehouseID, amount.Date, amount.AccountTitleID}] = i
+	}
+
+	for _, adjustment := range adjustments {
+		k := key{adjustment.CompanyID, adjustment.WarehouseID, adjustment.Date, adjustment.AccountTitleID}
+		if i, ok := index[k]; ok {
+			amounts[i].Amount += adjustment.Amount
+			continue
+		}
+		index[k] = len(amounts)
+		amounts = append(amounts, adjustment)
+	}
+	return amounts
+}
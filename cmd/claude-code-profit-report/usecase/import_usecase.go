@@ -0,0 +1,290 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+	"unicode/utf8"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+)
+
+// 明細のカラムの上限（size varchar(16)、単価 decimal(9,3)、金額 decimal(13,3)）
+const (
+	importSizeMaxLength = 16
+	importPriceMax      = 999999.999
+	importAmountMax     = 9999999999.999
+)
+
+type ImportUseCase interface {
+	// 明細を会社・倉庫・日・科目のレポート単位にまとめて検証し、問題のないレポートを1つのトランザクションで取り込む
+	// （エラーの行を含むレポートは同じレポートの行をすべて取り込まない。取り込んだレポートは source の取り込みバッチに記録する）
+	ImportDailyReports(ctx context.Context, kind entity.ReportKind, source string, rows []entity.DailyReportRow, tolerance float64, dryRun bool) (*entity.ImportResult, error)
+	ListBatches(ctx context.Context, limit int) ([]entity.ImportBatch, error)
+	// 取り込みバッチの反映を取り消し、置き換えたレポートを置き換え前の明細に戻す
+	RollbackBatch(ctx context.Context, id uint64, dryRun bool) (*entity.ImportBatchRollback, error)
+}
+
+type importUseCaseImpl struct {
+	importRepo      repository.ImportRepository
+	importBatchRepo repository.ImportBatchRepository
+	masterRepo      repository.MasterRepository
+	contractRepo    repository.ContractRepository
+}
+
+func NewImportUseCase(
+	importRepo repository.ImportRepository,
+	importBatchRepo repository.ImportBatchRepository,
+	masterRepo repository.MasterRepository,
+	contractRepo repository.ContractRepository,
+) ImportUseCase {
+	return &importUseCaseImpl{
+		importRepo:      importRepo,
+		importBatchRepo: importBatchRepo,
+		masterRepo:      masterRepo,
+		contractRepo:    contractRepo,
+	}
+}
+
+type reportKey struct {
+	companyID      uint
+	warehouseID    uint
+	date           time.Time
+	accountTitleID uint
+}
+
+func (u *importUseCaseImpl) ImportDailyReports(ctx context.Context, kind entity.ReportKind, source string, rows []entity.DailyReportRow, tolerance float64, dryRun bool) (*entity.ImportResult, error) {
+	if _, err := entity.ParseReportKind(string(kind)); err != nil {
+		return nil, err
+	}
+
+	companies, err := u.masterCodes(ctx, entity.MasterCompany)
+	if err != nil {
+		return nil, err
+	}
+	warehouses, err := u.masterCodes(ctx, entity.MasterWarehouse)
+	if err != nil {
+		return nil, err
+	}
+	titles, err := u.masterCodes(ctx, kind.TitleMasterKind())
+	if err != nil {
+		return nil, err
+	}
+	contracts, err := u.contractsFor(ctx, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entity.ImportResult{Kind: kind, DryRun: dryRun}
+	var keys []reportKey
+	reports := make(map[reportKey]*entity.DailyReportImport)
+	// レポートごとの最初のエラー行（同じレポートの他の行も取り込まない）
+	invalid := make(map[reportKey]int)
+
+	for _, row := range rows {
+		key, reason := resolveImportRow(row, companies, warehouses, titles, contracts, tolerance)
+		if reason != "" {
+			result.Rejected = append(result.Rejected, entity.ImportRejection{Line: row.Line, Reason: reason})
+			if key != nil {
+				if _, ok := invalid[*key]; !ok {
+					invalid[*key] = row.Line
+				}
+			}
+			continue
+		}
+
+		report, ok := reports[*key]
+		if !ok {
+			report = &entity.DailyReportImport{
+				CompanyID:      key.companyID,
+				WarehouseID:    key.warehouseID,
+				Date:           key.date,
+				AccountTitleID: key.accountTitleID,
+			}
+			reports[*key] = report
+			keys = append(keys, *key)
+		}
+		report.Rows = append(report.Rows, row)
+	}
+
+	for _, key := range keys {
+		report := reports[key]
+		if line, ok := invalid[key]; ok {
+			for _, row := range report.Rows {
+				result.Rejected = append(result.Rejected, entity.ImportRejection{
+					Line:     row.Line,
+					Reason:   fmt.Sprintf("another row of the same report is invalid (line %d)", line),
+					CausedBy: line,
+				})
+			}
+			continue
+		}
+		result.Reports = append(result.Reports, *report)
+	}
+	sortRejections(result.Rejected)
+
+	if len(result.Reports) == 0 {
+		return result, nil
+	}
+	batch := &entity.ImportBatch{Source: source}
+	if principal, ok := entity.PrincipalFromContext(ctx); ok {
+		batch.CreatedBy = principal.Name
+	}
+	if err := u.importRepo.ImportDailyReports(ctx, kind, batch, result.Reports, dryRun); err != nil {
+		return nil, fmt.Errorf("failed to import %s daily reports: %w", kind, err)
+	}
+	// dry-run のバッチはロールバックされて残らないため、一覧や取り消しで使えない ID は返さない
+	if !dryRun {
+		result.BatchID = batch.ID
+	}
+	return result, nil
+}
+
+func (u *importUseCaseImpl) ListBatches(ctx context.Context, limit int) ([]entity.ImportBatch, error) {
+	if limit < 0 {
+		return nil, fmt.Errorf("limit must not be negative")
+	}
+	batches, err := u.importBatchRepo.List(ctx, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list import batches: %w", err)
+	}
+	return batches, nil
+}
+
+func (u *importUseCaseImpl) RollbackBatch(ctx context.Context, id uint64, dryRun bool) (*entity.ImportBatchRollback, error) {
+	batch, err := u.importBatchRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get import batch: %w", err)
+	}
+	if batch == nil {
+		return nil, fmt.Errorf("import batch not found: %d", id)
+	}
+	if batch.RolledBack() {
+		return nil, fmt.Errorf("import batch %d was already rolled back at %s", id, batch.RolledBackAt.Format("2006-01-02 15:04:05"))
+	}
+
+	result, err := u.importBatchRepo.Rollback(ctx, id, dryRun)
+	if err != nil {
+		return nil, fmt.Errorf("failed to roll back import batch %d: %w", id, err)
+	}
+	return result, nil
+}
+
+// resolveImportRow コードをIDに変換して行を検証する（会社・倉庫・日・科目が特定できた場合はエラーでもレポートのキーを返す）
+func resolveImportRow(
+	row entity.DailyReportRow,
+	companies, warehouses, titles map[string]entity.MasterRecord,
+	contracts []entity.Contract,
+	tolerance float64,
+) (*reportKey, string) {
+	company, companyOK := companies[row.CompanyCode]
+	warehouse, warehouseOK := warehouses[row.WarehouseCode]
+	title, titleOK := titles[row.TitleCode]
+	var key *reportKey
+	if companyOK && warehouseOK && titleOK && !row.Date.IsZero() {
+		key = &reportKey{
+			companyID:      company.ID,
+			warehouseID:    warehouse.ID,
+			date:           row.Date,
+			accountTitleID: title.ID,
+		}
+	}
+
+	// 明細の値を解釈できない行も、同じレポートの他の行で既存の明細を置き換えないようにキーを返す
+	if row.ParseError != "" {
+		return key, row.ParseError
+	}
+	switch {
+	case !companyOK:
+		return nil, fmt.Sprintf("unknown company code: %q", row.CompanyCode)
+	case !warehouseOK:
+		return nil, fmt.Sprintf("unknown warehouse code: %q", row.WarehouseCode)
+	case !titleOK:
+		return nil, fmt.Sprintf("unknown account title code: %q", row.TitleCode)
+	}
+
+	switch {
+	case company.Disabled:
+		return key, fmt.Sprintf("company %q is disabled", row.CompanyCode)
+	case warehouse.Disabled:
+		return key, fmt.Sprintf("warehouse %q is disabled", row.WarehouseCode)
+	case title.Disabled:
+		return key, fmt.Sprintf("account title %q is disabled", row.TitleCode)
+	}
+	if !hasContract(contracts, company.ID, warehouse.ID, row.Date) {
+		return key, fmt.Sprintf("company %q has no contract with warehouse %q on %s",
+			row.CompanyCode, row.WarehouseCode, row.Date.Format("2006-01-02"))
+	}
+
+	switch {
+	case utf8.RuneCountInString(row.Size) > importSizeMaxLength:
+		return key, fmt.Sprintf("size must be at most %d characters", importSizeMaxLength)
+	case row.Quantity <= 0:
+		return key, fmt.Sprintf("quantity must be positive: %d", row.Quantity)
+	case row.Price < 0 || row.Price > importPriceMax:
+		return key, fmt.Sprintf("price out of range: %.3f", row.Price)
+	case math.Abs(row.Amount) > importAmountMax:
+		return key, fmt.Sprintf("amount out of range: %.3f", row.Amount)
+	}
+	if expected := float64(row.Quantity) * row.Price; math.Abs(row.Amount-expected) > tolerance {
+		return key, fmt.Sprintf("amount %.3f does not match quantity x price %.3f", row.Amount, expected)
+	}
+
+	return key, ""
+}
+
+// masterCodes 無効なものを含めたマスタをコードで引けるようにする（無効なマスタへの取り込みは理由を付けて除外する）
+func (u *importUseCaseImpl) masterCodes(ctx context.Context, kind entity.MasterKind) (map[string]entity.MasterRecord, error) {
+	records, err := u.masterRepo.List(ctx, kind, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s master: %w", kind, err)
+	}
+	codes := make(map[string]entity.MasterRecord, len(records))
+	for _, record := range records {
+		codes[record.Code] = record
+	}
+	return codes, nil
+}
+
+// contractsFor 取り込む行の日付の範囲と重なる契約を取得する
+func (u *importUseCaseImpl) contractsFor(ctx context.Context, rows []entity.DailyReportRow) ([]entity.Contract, error) {
+	var start, end time.Time
+	for _, row := range rows {
+		if row.ParseError != "" {
+			continue
+		}
+		if start.IsZero() || row.Date.Before(start) {
+			start = row.Date
+		}
+		if end.IsZero() || row.Date.After(end) {
+			end = row.Date
+		}
+	}
+	if start.IsZero() {
+		return nil, nil
+	}
+
+	contracts, err := u.contractRepo.List(ctx, repository.ContractFilter{StartDate: &start, EndDate: &end})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list contracts: %w", err)
+	}
+	return contracts, nil
+}
+
+func hasContract(contracts []entity.Contract, companyID, warehouseID uint, date time.Time) bool {
+	for _, contract := range contracts {
+		if contract.CompanyID == companyID && contract.WarehouseID == warehouseID && contract.Overlaps(date, date) {
+			return true
+		}
+	}
+	return false
+}
+
+func sortRejections(rejections []entity.ImportRejection) {
+	sort.SliceStable(rejections, func(i, j int) bool {
+		return rejections[i].Line < rejections[j].Line
+	})
+}
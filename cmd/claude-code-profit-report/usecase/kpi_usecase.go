@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+)
+
+type KPIUseCase interface {
+	// 当日・前日・当月累計の勘定科目別の売上・コストを取得する（allocate が true の場合は共通費配賦後のコスト）
+	CollectKPIs(ctx context.Context, now time.Time, allocate bool) ([]entity.KPISnapshot, error)
+}
+
+type kpiUseCaseImpl struct {
+	salesRepo         repository.SalesRepository
+	costRepo          repository.CostRepository
+	allocationUseCase CostAllocationUseCase
+}
+
+func NewKPIUseCase(
+	salesRepo repository.SalesRepository,
+	costRepo repository.CostRepository,
+	allocationUseCase CostAllocationUseCase,
+) KPIUseCase {
+	return &kpiUseCaseImpl{
+		salesRepo:         salesRepo,
+		costRepo:          costRepo,
+		allocationUseCase: allocationUseCase,
+	}
+}
+
+func (u *kpiUseCaseImpl) CollectKPIs(ctx context.Context, now time.Time, allocate bool) ([]entity.KPISnapshot, error) {
+	periods := entity.KPIPeriods(now)
+	snapshots := make([]entity.KPISnapshot, 0, len(periods))
+
+	for _, period := range periods {
+		sales, err := u.salesRepo.GetAccountTitleAmountsByPeriod(ctx, 0, 0, period.StartDate, period.EndDate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s sales: %w", period.Name, err)
+		}
+		cost, err := u.costRepo.GetAccountTitleAmountsByPeriod(ctx, 0, 0, period.StartDate, period.EndDate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get %s cost: %w", period.Name, err)
+		}
+		if allocate {
+			adjustments, err := u.allocationUseCase.CalculateAdjustments(ctx, 0, period.StartDate, period.EndDate)
+			if err != nil {
+				return nil, fmt.Errorf("failed to allocate %s shared cost: %w", period.Name, err)
+			}
+			cost = mergeAccountTitleAmounts(cost, adjustments)
+		}
+
+		snapshots = append(snapshots, entity.KPISnapshot{Period: period, Sales: sales, Cost: cost})
+	}
+
+	return snapshots, nil
+}
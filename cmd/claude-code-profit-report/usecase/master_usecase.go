@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+)
+
+type MasterUseCase interface {
+	ListMasters(ctx context.Context, kind entity.MasterKind, includeDisabled bool) ([]entity.MasterRecord, error)
+	// 入力ごとに作成・更新・変更なし・エラーを判定する（データベースは変更しない）
+	Plan(ctx context.Context, kind entity.MasterKind, inputs []entity.MasterInput) (*entity.MasterPlan, error)
+	// 計画を1つのトランザクションで反映する（エラーの行が1件でもあれば何も反映しない）
+	Apply(ctx context.Context, plan *entity.MasterPlan) error
+}
+
+type masterUseCaseImpl struct {
+	masterRepo repository.MasterRepository
+}
+
+func NewMasterUseCase(masterRepo repository.MasterRepository) MasterUseCase {
+	return &masterUseCaseImpl{masterRepo: masterRepo}
+}
+
+func (u *masterUseCaseImpl) ListMasters(ctx context.Context, kind entity.MasterKind, includeDisabled bool) ([]entity.MasterRecord, error) {
+	records, err := u.masterRepo.List(ctx, kind, includeDisabled)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s master: %w", kind, err)
+	}
+	return records, nil
+}
+
+func (u *masterUseCaseImpl) Plan(ctx context.Context, kind entity.MasterKind, inputs []entity.MasterInput) (*entity.MasterPlan, error) {
+	plan := &entity.MasterPlan{Kind: kind}
+	// 入力内でのコードの重複（反映後のコード → 行番号）
+	codes := make(map[string]int)
+
+	for _, input := range inputs {
+		change, err := u.planChange(ctx, kind, input)
+		if err != nil {
+			return nil, err
+		}
+
+		if change.Action != entity.MasterActionInvalid {
+			if line, ok := codes[change.After.Code]; ok {
+				change.Action = entity.MasterActionInvalid
+				change.Error = fmt.Errorf("duplicate code %q in input (line %d)", change.After.Code, line)
+			} else {
+				codes[change.After.Code] = input.Line
+			}
+		}
+		plan.Changes = append(plan.Changes, change)
+	}
+
+	return plan, nil
+}
+
+func (u *masterUseCaseImpl) planChange(ctx context.Context, kind entity.MasterKind, input entity.MasterInput) (entity.MasterChange, error) {
+	change := entity.MasterChange{Line: input.Line}
+	invalid := func(err error) (entity.MasterChange, error) {
+		change.Action = entity.MasterActionInvalid
+		change.Error = err
+		return change, nil
+	}
+
+	existing, err := u.masterRepo.GetByCode(ctx, kind, input.LookupCode)
+	if err != nil {
+		return change, fmt.Errorf("failed to get %s master: %w", kind, err)
+	}
+
+	if existing == nil {
+		change.After = entity.MasterRecord{Code: input.LookupCode, Taxable: true}
+		change.Action = entity.MasterActionCreate
+		if input.MustExist {
+			change.After = applyMasterInput(change.After, input)
+			return invalid(fmt.Errorf("%s not found: code=%s", kind.Label(), input.LookupCode))
+		}
+	} else {
+		change.Before = existing
+		change.After = *existing
+		change.Action = entity.MasterActionUpdate
+		if input.MustNotExist {
+			change.After = applyMasterInput(change.After, input)
+			return invalid(fmt.Errorf("code already exists: %s", input.LookupCode))
+		}
+	}
+
+	change.After = applyMasterInput(change.After, input)
+	if !kind.IsAccountTitle() {
+		change.After.Taxable = true
+	}
+	if err := change.After.Validate(kind); err != nil {
+		return invalid(err)
+	}
+
+	// コードを変更する場合は変更先が使われていないこと
+	if change.Before != nil && change.After.Code != change.Before.Code {
+		other, err := u.masterRepo.GetByCode(ctx, kind, change.After.Code)
+		if err != nil {
+			return change, fmt.Errorf("failed to get %s master: %w", kind, err)
+		}
+		if other != nil {
+			return invalid(fmt.Errorf("code already exists: %s", change.After.Code))
+		}
+	}
+
+	if change.Before != nil && change.After == *change.Before {
+		change.Action = entity.MasterActionUnchanged
+	}
+	return change, nil
+}
+
+func applyMasterInput(record entity.MasterRecord, input entity.MasterInput) entity.MasterRecord {
+	if input.Code != nil {
+		record.Code = *input.Code
+	}
+	if input.Name != nil {
+		record.Name = *input.Name
+	}
+	if input.Disabled != nil {
+		record.Disabled = *input.Disabled
+	}
+	if input.Taxable != nil {
+		record.Taxable = *input.Taxable
+	}
+	return record
+}
+
+func (u *masterUseCaseImpl) Apply(ctx context.Context, plan *entity.MasterPlan) error {
+	if plan.HasErrors() {
+		return fmt.Errorf("%s master has %d invalid rows: nothing was applied", plan.Kind, plan.Count(entity.MasterActionInvalid))
+	}
+	if plan.Count(entity.MasterActionCreate)+plan.Count(entity.MasterActionUpdate) == 0 {
+		return nil
+	}
+	if err := u.masterRepo.Apply(ctx, plan.Kind, plan.Changes); err != nil {
+		return fmt.Errorf("failed to apply %s master: %w", plan.Kind, err)
+	}
+	return nil
+}
@@ -6,14 +6,22 @@ import (
 	"log"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
 	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
 	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
 )
 
 type ProfitReportUseCase interface {
 	GenerateProfitReport(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) (*entity.ProfitReport, error)
+	GenerateProfitReportWithDetails(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) (*entity.ProfitReport, error)
 }
 
+const tracerName = "github.com/taka512/golang/cmd/claude-code-profit-report/usecase"
+
 type profitReportUseCaseImpl struct {
 	salesRepo   repository.SalesRepository
 	costRepo    repository.CostRepository
@@ -32,7 +40,10 @@ func NewProfitReportUseCase(
 	}
 }
 
-func (u *profitReportUseCaseImpl) GenerateProfitReport(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) (*entity.ProfitReport, error) {
+func (u *profitReportUseCaseImpl) GenerateProfitReport(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) (report *entity.ProfitReport, err error) {
+	ctx, span := startReportSpan(ctx, "ProfitReportUseCase.GenerateProfitReport", companyID, warehouseID, startDate, endDate)
+	defer func() { endReportSpan(span, report, err) }()
+
 	var companyName, warehouseName string
 
 	if companyID > 0 {
@@ -41,6 +52,9 @@ func (u *profitReportUseCaseImpl) GenerateProfitReport(ctx context.Context, comp
 			return nil, fmt.Errorf("failed to get company: %w", err)
 		}
 		companyName = company.Name
+	} else if principal, ok := entity.PrincipalFromContext(ctx); ok && !principal.IsAdmin() {
+		// 担当会社に絞り込んだ集計になるため「全社」とは表示しない
+		companyName = "担当会社計"
 	} else {
 		companyName = "全社"
 	}
@@ -65,7 +79,7 @@ func (u *profitReportUseCaseImpl) GenerateProfitReport(ctx context.Context, comp
 		return nil, fmt.Errorf("failed to get cost summary: %w", err)
 	}
 
-	report := &entity.ProfitReport{
+	report = &entity.ProfitReport{
 		CompanyID:     companyID,
 		CompanyName:   companyName,
 		WarehouseID:   warehouseID,
@@ -121,5 +135,66 @@ func (u *profitReportUseCaseImpl) GenerateProfitReport(ctx context.Context, comp
 	report.TotalCost = totalCost
 	report.CalculateGrossProfit()
 
+	// 無効化された勘定科目を含めた場合は、合計のうち無効な科目の金額を別に示す
+	if entity.IncludesDisabledAccountTitles(ctx) {
+		report.IncludesDisabledTitles = true
+		report.InactiveSales, err = u.salesRepo.GetDisabledTitleTotalByPeriod(ctx, companyID, warehouseID, startDate, endDate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get sales of disabled account titles: %w", err)
+		}
+		report.InactiveCost, err = u.costRepo.GetDisabledTitleTotalByPeriod(ctx, companyID, warehouseID, startDate, endDate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get cost of disabled account titles: %w", err)
+		}
+	}
+
+	return report, nil
+}
+// GenerateProfitReportWithDetails 期間合計・日別に加えて会社・倉庫・勘定科目別の内訳を設定したレポートを作成する
+func (u *profitReportUseCaseImpl) GenerateProfitReportWithDetails(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time) (report *entity.ProfitReport, err error) {
+	ctx, span := startReportSpan(ctx, "ProfitReportUseCase.GenerateProfitReportWithDetails", companyID, warehouseID, startDate, endDate)
+	defer func() { endReportSpan(span, report, err) }()
+
+	report, err = u.GenerateProfitReport(ctx, companyID, warehouseID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	report.SalesDetails, err = u.salesRepo.GetAccountTitleAmountsByPeriod(ctx, companyID, warehouseID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sales details: %w", err)
+	}
+
+	report.CostDetails, err = u.costRepo.GetAccountTitleAmountsByPeriod(ctx, companyID, warehouseID, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cost details: %w", err)
+	}
+
 	return report, nil
-}
\ No newline at end of file
+}
+
+func startReportSpan(ctx context.Context, name string, companyID, warehouseID uint, startDate, endDate time.Time) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(
+		attribute.Int64("report.company_id", int64(companyID)),
+		attribute.Int64("report.warehouse_id", int64(warehouseID)),
+		attribute.String("report.start_date", startDate.Format("2006-01-02")),
+		attribute.String("report.end_date", endDate.Format("2006-01-02")),
+	))
+}
+
+// endReportSpan 作成したレポートの日数と合計をスパンに記録して終了する
+func endReportSpan(span trace.Span, report *entity.ProfitReport, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else if report != nil {
+		span.SetAttributes(
+			attribute.Int("report.days", len(report.DailyReports)),
+			attribute.Int("report.sales_details", len(report.SalesDetails)),
+			attribute.Int("report.cost_details", len(report.CostDetails)),
+			attribute.Float64("report.total_sales", report.TotalSales),
+			attribute.Float64("report.total_cost", report.TotalCost),
+		)
+	}
+	span.End()
+}
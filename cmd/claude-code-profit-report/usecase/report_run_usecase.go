@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+)
+
+type ReportRunUseCase interface {
+	SaveReportRun(ctx context.Context, report *entity.ProfitReport, outputFormat, destination, outputPath string) (*entity.ReportRun, error)
+	ListReportRuns(ctx context.Context, filter repository.ReportRunFilter) ([]entity.ReportRun, error)
+	GetReportRun(ctx context.Context, id uint64) (*entity.ReportRun, error)
+	DiffReportRun(ctx context.Context, id uint64) (*entity.ReportDiff, error)
+}
+
+type reportRunUseCaseImpl struct {
+	reportRunRepo       repository.ReportRunRepository
+	correctionRepo      repository.CorrectionRepository
+	profitReportUseCase ProfitReportUseCase
+	allocationUseCase   CostAllocationUseCase
+}
+
+func NewReportRunUseCase(
+	reportRunRepo repository.ReportRunRepository,
+	correctionRepo repository.CorrectionRepository,
+	profitReportUseCase ProfitReportUseCase,
+	allocationUseCase CostAllocationUseCase,
+) ReportRunUseCase {
+	return &reportRunUseCaseImpl{
+		reportRunRepo:       reportRunRepo,
+		correctionRepo:      correctionRepo,
+		profitReportUseCase: profitReportUseCase,
+		allocationUseCase:   allocationUseCase,
+	}
+}
+
+func (u *reportRunUseCaseImpl) SaveReportRun(ctx context.Context, report *entity.ProfitReport, outputFormat, destination, outputPath string) (*entity.ReportRun, error) {
+	run := entity.NewReportRun(report, outputFormat, destination, outputPath)
+	if err := u.reportRunRepo.Create(ctx, run); err != nil {
+		return nil, fmt.Errorf("failed to save report run: %w", err)
+	}
+
+	// 後日の修正を検出できるよう、報告した時点の会社・倉庫・日別の合計を記録する
+	totals, err := u.correctionRepo.GetDailyTotals(ctx, report.CompanyID, report.WarehouseID, report.StartDate, report.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reported daily totals: %w", err)
+	}
+	if err := u.correctionRepo.SaveReportedDailyTotals(ctx, totals); err != nil {
+		return nil, err
+	}
+
+	return run, nil
+}
+
+func (u *reportRunUseCaseImpl) ListReportRuns(ctx context.Context, filter repository.ReportRunFilter) ([]entity.ReportRun, error) {
+	runs, err := u.reportRunRepo.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list report runs: %w", err)
+	}
+	return runs, nil
+}
+
+func (u *reportRunUseCaseImpl) GetReportRun(ctx context.Context, id uint64) (*entity.ReportRun, error) {
+	run, err := u.reportRunRepo.GetByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get report run: %w", err)
+	}
+	return run, nil
+}
+
+// DiffReportRun 保存済みレポートと同じ条件で再生成し、現在のデータとの差分を返す
+func (u *reportRunUseCaseImpl) DiffReportRun(ctx context.Context, id uint64) (*entity.ReportDiff, error) {
+	run, err := u.GetReportRun(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	report := run.Report
+	current, err := u.profitReportUseCase.GenerateProfitReport(ctx, report.CompanyID, report.WarehouseID, report.StartDate, report.EndDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to regenerate profit report: %w", err)
+	}
+	if report.CostAllocated {
+		if err := u.allocationUseCase.ApplyAllocation(ctx, current); err != nil {
+			return nil, fmt.Errorf("failed to allocate shared cost: %w", err)
+		}
+	}
+
+	return entity.NewReportDiff(run, current), nil
+}
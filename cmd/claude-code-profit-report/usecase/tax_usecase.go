@@ -0,0 +1,117 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+)
+
+type TaxUseCase interface {
+	// レポートの期間・会社・倉庫について税抜・税込の金額と税率別の消費税額を計算し、report.Tax に設定する
+	ApplyTax(ctx context.Context, report *entity.ProfitReport, basis entity.TaxBasis) error
+}
+
+type taxUseCaseImpl struct {
+	taxRepo repository.TaxRepository
+}
+
+func NewTaxUseCase(taxRepo repository.TaxRepository) TaxUseCase {
+	return &taxUseCaseImpl{taxRepo: taxRepo}
+}
+
+func (u *taxUseCaseImpl) ApplyTax(ctx context.Context, report *entity.ProfitReport, basis entity.TaxBasis) error {
+	if basis != entity.TaxBasisExclusive && basis != entity.TaxBasisInclusive {
+		return fmt.Errorf("unsupported tax basis: %s", basis)
+	}
+
+	rates, err := u.taxRepo.GetTaxRates(ctx)
+	if err != nil {
+		return err
+	}
+
+	sales, err := u.taxRepo.GetTaxableAmounts(ctx, entity.ReportKindSales, report.CompanyID, report.WarehouseID, report.StartDate, report.EndDate)
+	if err != nil {
+		return err
+	}
+	cost, err := u.taxRepo.GetTaxableAmounts(ctx, entity.ReportKindCost, report.CompanyID, report.WarehouseID, report.StartDate, report.EndDate)
+	if err != nil {
+		return err
+	}
+
+	summary := &entity.TaxSummary{Basis: basis}
+
+	// 課税対象額を適用税率ごとに合算してから税額を計算する（端数処理は税率ごとに1回）
+	var order []uint
+	byRate := make(map[uint]*entity.TaxBreakdown)
+	add := func(amount entity.TaxableAmount, isSales bool) error {
+		if !amount.Taxable {
+			if isSales {
+				summary.NonTaxableSales += amount.Amount
+			} else {
+				summary.NonTaxableCost += amount.Amount
+			}
+			return nil
+		}
+
+		rate, ok := findTaxRate(rates, amount.Date)
+		if !ok {
+			return fmt.Errorf("no tax rate is effective on %s", amount.Date.Format("2006-01-02"))
+		}
+		breakdown, ok := byRate[rate.ID]
+		if !ok {
+			breakdown = &entity.TaxBreakdown{Name: rate.Name, Rate: rate.Rate}
+			byRate[rate.ID] = breakdown
+			order = append(order, rate.ID)
+		}
+		if isSales {
+			breakdown.SalesBase += amount.Amount
+		} else {
+			breakdown.CostBase += amount.Amount
+		}
+		return nil
+	}
+
+	for _, amount := range sales {
+		if err := add(amount, true); err != nil {
+			return err
+		}
+	}
+	for _, amount := range cost {
+		if err := add(amount, false); err != nil {
+			return err
+		}
+	}
+
+	summary.SalesExcludingTax = summary.NonTaxableSales
+	summary.CostExcludingTax = summary.NonTaxableCost
+	for _, id := range order {
+		breakdown := byRate[id]
+		breakdown.SalesBase, breakdown.SalesTax = entity.SplitTax(breakdown.SalesBase, breakdown.Rate, basis)
+		breakdown.CostBase, breakdown.CostTax = entity.SplitTax(breakdown.CostBase, breakdown.Rate, basis)
+
+		summary.SalesExcludingTax += breakdown.SalesBase
+		summary.SalesTax += breakdown.SalesTax
+		summary.CostExcludingTax += breakdown.CostBase
+		summary.CostTax += breakdown.CostTax
+		summary.ByRate = append(summary.ByRate, *breakdown)
+	}
+
+	report.Tax = summary
+	return nil
+}
+
+// findTaxRate 日付に適用される税率（適用開始日が日付以前で最も新しいもの）を返す
+func findTaxRate(rates []entity.TaxRate, date time.Time) (entity.TaxRate, bool) {
+	var found entity.TaxRate
+	ok := false
+	for _, rate := range rates {
+		if rate.EffectiveFrom.After(date) {
+			break
+		}
+		found, ok = rate, true
+	}
+	return found, ok
+}
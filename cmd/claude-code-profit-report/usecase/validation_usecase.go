@@ -0,0 +1,183 @@
+package usecase
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/entity"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/domain/repository"
+)
+
+type ValidationUseCase interface {
+	// 期間内の売上・コストデータを検証する（tolerance は金額と数量×単価の許容誤差）
+	Validate(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time, tolerance float64) (*entity.ValidationResult, error)
+}
+
+type validationUseCaseImpl struct {
+	validationRepo repository.ValidationRepository
+}
+
+func NewValidationUseCase(validationRepo repository.ValidationRepository) ValidationUseCase {
+	return &validationUseCaseImpl{validationRepo: validationRepo}
+}
+
+// 会社・倉庫・日のキー
+type dailyKey struct {
+	companyID   uint
+	warehouseID uint
+	date        time.Time
+}
+
+// 会社・倉庫・科目のキー
+type titleKey struct {
+	companyID      uint
+	warehouseID    uint
+	accountTitleID uint
+}
+
+func (u *validationUseCaseImpl) Validate(ctx context.Context, companyID, warehouseID uint, startDate, endDate time.Time, tolerance float64) (*entity.ValidationResult, error) {
+	result := &entity.ValidationResult{
+		CompanyID:   companyID,
+		WarehouseID: warehouseID,
+		StartDate:   startDate,
+		EndDate:     endDate,
+		Tolerance:   tolerance,
+	}
+
+	headers := make(map[entity.ReportKind][]entity.DailyReportHeader)
+	var items []entity.DailyReportItem
+	for _, kind := range []entity.ReportKind{entity.ReportKindSales, entity.ReportKindCost} {
+		kindHeaders, err := u.validationRepo.GetDailyReportHeaders(ctx, kind, companyID, warehouseID, startDate, endDate)
+		if err != nil {
+			return nil, err
+		}
+		headers[kind] = kindHeaders
+
+		kindItems, err := u.validationRepo.FindInvalidItems(ctx, kind, companyID, warehouseID, startDate, endDate, tolerance)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, kindItems...)
+	}
+
+	var issues []entity.ValidationIssue
+	for _, kind := range []entity.ReportKind{entity.ReportKindSales, entity.ReportKindCost} {
+		issues = append(issues, findMissingDays(headers[kind], startDate, endDate)...)
+		issues = append(issues, findEmptyReports(headers[kind])...)
+	}
+	issues = append(issues, findUnmatchedDays(entity.CheckCostWithoutSales, headers[entity.ReportKindCost], headers[entity.ReportKindSales])...)
+	issues = append(issues, findUnmatchedDays(entity.CheckSalesWithoutCost, headers[entity.ReportKindSales], headers[entity.ReportKindCost])...)
+
+	for i := range items {
+		item := items[i]
+		if item.Quantity <= 0 {
+			issues = append(issues, entity.ValidationIssue{Check: entity.CheckNonPositiveQuantity, Header: item.Header, Item: &item})
+		}
+		// 数量が0以下でも金額の不一致は別の問題として報告する
+		if diff := item.Amount - item.ExpectedAmount(); diff > tolerance || diff < -tolerance {
+			issues = append(issues, entity.ValidationIssue{Check: entity.CheckAmountMismatch, Header: item.Header, Item: &item})
+		}
+	}
+
+	result.Issues = sortIssues(issues)
+	return result, nil
+}
+
+// findMissingDays 期間内に1日でもレポートがある会社・倉庫・科目について、レポートがない日を返す
+func findMissingDays(headers []entity.DailyReportHeader, startDate, endDate time.Time) []entity.ValidationIssue {
+	var keys []titleKey
+	first := make(map[titleKey]entity.DailyReportHeader)
+	days := make(map[titleKey]map[time.Time]bool)
+	for _, header := range headers {
+		key := titleKey{header.CompanyID, header.WarehouseID, header.AccountTitleID}
+		if _, ok := days[key]; !ok {
+			keys = append(keys, key)
+			first[key] = header
+			days[key] = make(map[time.Time]bool)
+		}
+		days[key][header.Date] = true
+	}
+
+	var issues []entity.ValidationIssue
+	for _, key := range keys {
+		for date := startDate; !date.After(endDate); date = date.AddDate(0, 0, 1) {
+			normalizedDate := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.Local)
+			if days[key][normalizedDate] {
+				continue
+			}
+			header := first[key]
+			header.ReportID = 0
+			header.ItemCount = 0
+			header.Date = normalizedDate
+			issues = append(issues, entity.ValidationIssue{Check: entity.CheckMissingDay, Header: header})
+		}
+	}
+	return issues
+}
+
+func findEmptyReports(headers []entity.DailyReportHeader) []entity.ValidationIssue {
+	var issues []entity.ValidationIssue
+	for _, header := range headers {
+		if header.ItemCount == 0 {
+			issues = append(issues, entity.ValidationIssue{Check: entity.CheckEmptyReport, Header: header})
+		}
+	}
+	return issues
+}
+
+// findUnmatchedDays headers のうち、同じ会社・倉庫・日のレポートが others にないものを会社・倉庫・日単位で返す
+func findUnmatchedDays(check entity.ValidationCheck, headers, others []entity.DailyReportHeader) []entity.ValidationIssue {
+	existing := make(map[dailyKey]bool, len(others))
+	for _, header := range others {
+		existing[dailyKey{header.CompanyID, header.WarehouseID, header.Date}] = true
+	}
+
+	reported := make(map[dailyKey]bool)
+	var issues []entity.ValidationIssue
+	for _, header := range headers {
+		key := dailyKey{header.CompanyID, header.WarehouseID, header.Date}
+		if existing[key] || reported[key] {
+			continue
+		}
+		reported[key] = true
+
+		// 科目をまたいだ問題のため科目・レポートIDは持たない
+		header.ReportID = 0
+		header.AccountTitleID = 0
+		header.AccountTitleCode = ""
+		header.AccountTitleName = ""
+		header.ItemCount = 0
+		issues = append(issues, entity.ValidationIssue{Check: check, Header: header})
+	}
+	return issues
+}
+
+// sortIssues 検証項目順、日付・会社・倉庫・種別・科目順に並べる
+func sortIssues(issues []entity.ValidationIssue) []entity.ValidationIssue {
+	order := make(map[entity.ValidationCheck]int, len(entity.ValidationChecks))
+	for i, check := range entity.ValidationChecks {
+		order[check] = i
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if order[a.Check] != order[b.Check] {
+			return order[a.Check] < order[b.Check]
+		}
+		if !a.Header.Date.Equal(b.Header.Date) {
+			return a.Header.Date.Before(b.Header.Date)
+		}
+		if a.Header.CompanyID != b.Header.CompanyID {
+			return a.Header.CompanyID < b.Header.CompanyID
+		}
+		if a.Header.WarehouseID != b.Header.WarehouseID {
+			return a.Header.WarehouseID < b.Header.WarehouseID
+		}
+		if a.Header.Kind != b.Header.Kind {
+			return a.Header.Kind == entity.ReportKindSales
+		}
+		return a.Header.AccountTitleID < b.Header.AccountTitleID
+	})
+	return issues
+}
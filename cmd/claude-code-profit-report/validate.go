@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/config"
+	"github.com/taka512/golang/cmd/claude-code-profit-report/presentation/cli"
+)
+
+func newValidateCommand() *cobra.Command {
+	var (
+		validateCompanyID   uint
+		validateWarehouseID uint
+		validateStart       string
+		validateEnd         string
+		validateFormat      string
+		tolerance           float64
+		maxDetails          int
+	)
+
+	cmd := &cobra.Command{
+		Use:   "validate",
+		Short: "売上・コストデータの品質をチェックする",
+		Long: `指定期間の売上・コストの日次レポートを検証し、レポートの欠損日・明細のないレポート・
+金額と数量×単価の不一致・売上とコストの片側のみの日・数量が0以下の明細を報告します。
+問題が見つかった場合は終了コード1で終了します。`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			start, end, err := parsePeriod(validateStart, validateEnd)
+			if err != nil {
+				return err
+			}
+			if validateFormat != "text" && validateFormat != "json" {
+				return fmt.Errorf("unsupported format: %s", validateFormat)
+			}
+			if tolerance < 0 {
+				return fmt.Errorf("tolerance must not be negative")
+			}
+
+			return withContainer(cmd, func(ctx context.Context, container *config.Container) error {
+				result, err := container.ValidationUseCase.Validate(ctx, validateCompanyID, validateWarehouseID, start, end, tolerance)
+				if err != nil {
+					return fmt.Errorf("failed to validate: %w", err)
+				}
+
+				if validateFormat == "json" {
+					output, err := cli.FormatValidationJSON(result)
+					if err != nil {
+						return err
+					}
+					fmt.Print(output)
+				} else {
+					fmt.Print(cli.NewTextFormatter().FormatValidation(result, maxDetails))
+				}
+
+				if !result.Passed() {
+					os.Exit(1)
+				}
+				return nil
+			})
+		},
+	}
+
+	cmd.Flags().UintVarP(&validateCompanyID, "company", "c", 0, "会社ID (オプション: 未指定時は全社)")
+	cmd.Flags().UintVarP(&validateWarehouseID, "warehouse", "w", 0, "倉庫ID (オプション: 未指定時は全倉庫)")
+	cmd.Flags().StringVarP(&validateStart, "start", "s", "", "開始日 (YYYY-MM-DD) (必須)")
+	cmd.Flags().StringVarP(&validateEnd, "end", "e", "", "終了日 (YYYY-MM-DD) (必須)")
+	cmd.Flags().StringVar(&validateFormat, "format", "text", "出力形式 (text / json)")
+	cmd.Flags().Float64Var(&tolerance, "tolerance", 0.01, "金額と数量×単価の許容誤差")
+	cmd.Flags().IntVar(&maxDetails, "max-details", 20, "text形式で検証項目ごとに表示する件数 (0: 全件)")
+
+	cmd.MarkFlagRequired("start")
+	cmd.MarkFlagRequired("end")
+
+	return cmd
+}
+
+func parsePeriod(startValue, endValue string) (time.Time, time.Time, error) {
+	start, err := time.Parse("2006-01-02", startValue)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid start date format: %w", err)
+	}
+
+	end, err := time.Parse("2006-01-02", endValue)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("invalid end date format: %w", err)
+	}
+
+	if start.After(end) {
+		return time.Time{}, time.Time{}, fmt.Errorf("start date must be before or equal to end date")
+	}
+
+	return start, end, nil
+}
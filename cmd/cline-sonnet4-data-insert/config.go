@@ -0,0 +1,262 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"math/rand"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	maxPrice     = 999999.99
+	maxBatchSize = 10000
+)
+
+// 既存のレポートの扱い
+type Mode string
+
+const (
+	// 既存のレポートはそのまま残す（同じ期間で再実行しても重複しない）
+	ModeSkip Mode = "skip"
+	// 既存のレポートの明細を生成した明細で置き換える
+	ModeUpsert Mode = "upsert"
+)
+
+// 分布の種類
+type DistributionType string
+
+const (
+	// min〜max の一様分布
+	DistributionUniform DistributionType = "uniform"
+	// 平均 mean・標準偏差 stddev の正規分布（min〜max に切り詰める）
+	DistributionNormal DistributionType = "normal"
+)
+
+// Config 生成の設定（YAMLファイルで指定し、コマンドラインオプションで上書きする）
+type Config struct {
+	// 対象期間 (YYYY-MM-DD)。省略時は実行日の6日前〜実行日
+	Start string `yaml:"start"`
+	End   string `yaml:"end"`
+	// 乱数のシード（同じシード・期間・マスタであれば同じデータを生成する）
+	Seed      int64 `yaml:"seed"`
+	Mode      Mode  `yaml:"mode"`
+	BatchSize int   `yaml:"batch_size"`
+	// 全社共通の明細数・数量・単価の分布
+	Defaults Profile `yaml:"defaults"`
+	// 会社コードごとの分布（指定のない項目は defaults を使う）
+	Companies map[string]Profile `yaml:"companies"`
+	// 名前付きのシナリオと、適用するシナリオの名前（定義順ではなく指定順に適用する）
+	Scenarios      map[string]*Scenario `yaml:"scenarios"`
+	ApplyScenarios []string             `yaml:"apply_scenarios"`
+
+	startDate time.Time
+	endDate   time.Time
+}
+
+// Profile 1レポートあたりの明細数と、明細の数量・単価の分布
+type Profile struct {
+	Items      *IntRange     `yaml:"items"`
+	Quantity   *Distribution `yaml:"quantity"`
+	SalesPrice *Distribution `yaml:"sales_price"`
+	CostPrice  *Distribution `yaml:"cost_price"`
+}
+
+type IntRange struct {
+	Min int `yaml:"min"`
+	Max int `yaml:"max"`
+}
+
+type Distribution struct {
+	Type   DistributionType `yaml:"type"`
+	Min    float64          `yaml:"min"`
+	Max    float64          `yaml:"max"`
+	Mean   float64          `yaml:"mean"`
+	StdDev float64          `yaml:"stddev"`
+}
+
+// 従来の固定値（明細2-4個、数量10-109個、売上単価10.00-59.99円、原価単価5.00-34.99円）
+func defaultProfile() Profile {
+	return Profile{
+		Items:      &IntRange{Min: 2, Max: 4},
+		Quantity:   &Distribution{Type: DistributionUniform, Min: 10, Max: 109},
+		SalesPrice: &Distribution{Type: DistributionUniform, Min: 10, Max: 59.99},
+		CostPrice:  &Distribution{Type: DistributionUniform, Min: 5, Max: 34.99},
+	}
+}
+
+// LoadConfig 設定ファイルを読み込む（path が空の場合は既定値のみ）
+func LoadConfig(path string) (*Config, error) {
+	config := &Config{}
+	if path == "" {
+		return config, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+	return config, nil
+}
+
+// normalize 既定値を補い、値を検証する
+func (c *Config) normalize(now time.Time) error {
+	if c.Mode == "" {
+		c.Mode = ModeSkip
+	}
+	if c.Mode != ModeSkip && c.Mode != ModeUpsert {
+		return fmt.Errorf("unknown mode: %s (%s / %s)", c.Mode, ModeSkip, ModeUpsert)
+	}
+	if c.BatchSize == 0 {
+		c.BatchSize = 500
+	}
+	// MySQL のプレースホルダは1文あたり 65535 個まで（明細は1行6個）
+	if c.BatchSize < 0 || c.BatchSize > maxBatchSize {
+		return fmt.Errorf("batch_size must be between 1 and %d", maxBatchSize)
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+	var err error
+	if c.startDate, err = parseDate(c.Start, today.AddDate(0, 0, -6)); err != nil {
+		return fmt.Errorf("invalid start date: %w", err)
+	}
+	if c.endDate, err = parseDate(c.End, today); err != nil {
+		return fmt.Errorf("invalid end date: %w", err)
+	}
+	if c.startDate.After(c.endDate) {
+		return fmt.Errorf("start date must be before or equal to end date")
+	}
+
+	c.Defaults = c.Defaults.withDefaults(defaultProfile())
+	if err := c.Defaults.validate(); err != nil {
+		return fmt.Errorf("defaults: %w", err)
+	}
+	for code, profile := range c.Companies {
+		profile = profile.withDefaults(c.Defaults)
+		if err := profile.validate(); err != nil {
+			return fmt.Errorf("companies.%s: %w", code, err)
+		}
+		c.Companies[code] = profile
+	}
+
+	for name, scenario := range c.Scenarios {
+		if scenario == nil {
+			return fmt.Errorf("scenarios.%s: rules must not be empty", name)
+		}
+		if err := scenario.validate(); err != nil {
+			return fmt.Errorf("scenarios.%s: %w", name, err)
+		}
+	}
+	for _, name := range c.ApplyScenarios {
+		if _, ok := c.Scenarios[name]; !ok {
+			return fmt.Errorf("scenario not found: %s", name)
+		}
+	}
+	return nil
+}
+
+// ProfileFor 会社コードの分布（個別の指定がなければ defaults）
+func (c *Config) ProfileFor(companyCode string) Profile {
+	if profile, ok := c.Companies[companyCode]; ok {
+		return profile
+	}
+	return c.Defaults
+}
+
+// Dates 対象期間の日付
+func (c *Config) Dates() []time.Time {
+	var dates []time.Time
+	for date := c.startDate; !date.After(c.endDate); date = date.AddDate(0, 0, 1) {
+		dates = append(dates, date)
+	}
+	return dates
+}
+
+func (p Profile) withDefaults(base Profile) Profile {
+	if p.Items == nil {
+		p.Items = base.Items
+	}
+	if p.Quantity == nil {
+		p.Quantity = base.Quantity
+	}
+	if p.SalesPrice == nil {
+		p.SalesPrice = base.SalesPrice
+	}
+	if p.CostPrice == nil {
+		p.CostPrice = base.CostPrice
+	}
+	return p
+}
+
+func (p Profile) validate() error {
+	if p.Items.Min < 1 || p.Items.Max < p.Items.Min {
+		return fmt.Errorf("items must satisfy 1 <= min <= max")
+	}
+	for name, distribution := range map[string]*Distribution{
+		"quantity":    p.Quantity,
+		"sales_price": p.SalesPrice,
+		"cost_price":  p.CostPrice,
+	} {
+		if err := distribution.validate(); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	if p.Quantity.Max < 1 {
+		return fmt.Errorf("quantity: max must be at least 1")
+	}
+	// 明細の単価は decimal(9,3)
+	if p.SalesPrice.Max > maxPrice || p.CostPrice.Max > maxPrice {
+		return fmt.Errorf("price must be at most %.2f", maxPrice)
+	}
+	return nil
+}
+
+func (d *Distribution) validate() error {
+	switch d.Type {
+	case "", DistributionUniform:
+		d.Type = DistributionUniform
+	case DistributionNormal:
+		if d.StdDev < 0 {
+			return fmt.Errorf("stddev must not be negative")
+		}
+		if d.Mean < d.Min || d.Mean > d.Max {
+			return fmt.Errorf("mean must be between min and max")
+		}
+	default:
+		return fmt.Errorf("unknown distribution type: %s (%s / %s)", d.Type, DistributionUniform, DistributionNormal)
+	}
+	if d.Min < 0 || d.Max < d.Min {
+		return fmt.Errorf("must satisfy 0 <= min <= max")
+	}
+	return nil
+}
+
+// Sample 分布から値を1つ取り出す
+func (d *Distribution) Sample(r *rand.Rand) float64 {
+	if d.Type == DistributionNormal {
+		return math.Min(d.Max, math.Max(d.Min, r.NormFloat64()*d.StdDev+d.Mean))
+	}
+	return d.Min + r.Float64()*(d.Max-d.Min)
+}
+
+// Intn min〜max の整数を1つ取り出す
+func (r IntRange) Intn(rnd *rand.Rand) int {
+	return r.Min + rnd.Intn(r.Max-r.Min+1)
+}
+
+func parseDate(value string, fallback time.Time) (time.Time, error) {
+	if value == "" {
+		return fallback, nil
+	}
+	return time.ParseInLocation("2006-01-02", value, time.Local)
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"time"
+)
+
+// レポート種別
+type ReportKind string
+
+const (
+	ReportKindSales ReportKind = "sales"
+	ReportKindCost  ReportKind = "cost"
+)
+
+var itemSizes = []string{"S", "M", "L", "XL"}
+
+// 生成した日次レポート（会社・倉庫・日・科目ごと）
+type GeneratedReport struct {
+	Kind        ReportKind
+	CompanyID   int
+	WarehouseID int
+	Date        time.Time
+	TitleID     int
+	Items       []GeneratedItem
+}
+
+type GeneratedItem struct {
+	Size     string
+	Quantity int
+	Price    float64
+	Amount   float64
+}
+
+// reportKey 日次レポートのユニークキー（uniq_sales_daily_reports / uniq_cost_daily_reports）
+type reportKey struct {
+	companyID   int
+	warehouseID int
+	date        string
+	titleID     int
+}
+
+func (r *GeneratedReport) key() reportKey {
+	return reportKey{r.CompanyID, r.WarehouseID, r.Date.Format("2006-01-02"), r.TitleID}
+}
+
+// GenerateReports 契約期間内の日付 × 科目ごとにレポートを生成する
+// レポートごとにシード・会社・倉庫・日・科目のコードから乱数を初期化するため、
+// 期間や他の会社の指定が変わっても同じレポートには同じ明細を生成する
+// シナリオで欠損させたレポートは生成せず、その件数を返す
+func GenerateReports(config *Config, masterData *MasterData, kind ReportKind) ([]GeneratedReport, int) {
+	titles := masterData.SalesAccountTitles
+	if kind == ReportKindCost {
+		titles = masterData.CostAccountTitles
+	}
+
+	var reports []GeneratedReport
+	missing := 0
+	seen := make(map[reportKey]bool)
+	for _, date := range config.Dates() {
+		for _, contract := range masterData.Contracts {
+			if !contract.Covers(date) {
+				continue
+			}
+			profile := config.ProfileFor(contract.CompanyCode)
+			for _, title := range titles {
+				report := GeneratedReport{
+					Kind:        kind,
+					CompanyID:   contract.CompanyID,
+					WarehouseID: contract.WarehouseID,
+					Date:        date,
+					TitleID:     title.ID,
+				}
+				// 同じ会社・倉庫の契約期間が重なっている場合も1レポートにする
+				if seen[report.key()] {
+					continue
+				}
+				seen[report.key()] = true
+
+				effect := config.ScenarioEffectFor(kind, contract, date)
+				if effect.Missing {
+					missing++
+					continue
+				}
+
+				r := reportRand(config.Seed, kind, contract, date, title)
+				price := profile.SalesPrice
+				if kind == ReportKindCost {
+					price = profile.CostPrice
+				}
+				itemCount := profile.Items.Intn(r)
+				for i := 0; i < itemCount; i++ {
+					quantity := int(math.Max(1, math.Round(profile.Quantity.Sample(r)*effect.Quantity)))
+					unitPrice := math.Round(math.Min(maxPrice, price.Sample(r)*effect.Price(kind))*100) / 100
+					report.Items = append(report.Items, GeneratedItem{
+						Size:     itemSizes[i%len(itemSizes)],
+						Quantity: quantity,
+						Price:    unitPrice,
+						Amount:   math.Round(float64(quantity)*unitPrice*1000) / 1000,
+					})
+				}
+				reports = append(reports, report)
+			}
+		}
+	}
+	return reports, missing
+}
+
+// reportRand レポートごとの乱数（IDではなくコードを使い、別のデータベースでも同じデータを再現できるようにする）
+func reportRand(seed int64, kind ReportKind, contract Contract, date time.Time, title IDName) *rand.Rand {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%d/%s/%s/%s/%s/%s", seed, kind, contract.CompanyCode, contract.WarehouseCode, date.Format("2006-01-02"), title.Code)
+	return rand.New(rand.NewSource(int64(h.Sum64())))
+}
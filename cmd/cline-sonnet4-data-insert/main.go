@@ -1,290 +1,218 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"flag"
 	"fmt"
 	"log"
-	"math/rand"
+	"os"
+	"os/signal"
+	"strings"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
 )
 
+// 接続先は DATA_INSERT_DSN で変更できる
+const defaultDSN = "root:mypass@(mysql.local:3306)/sample_mysql?parseTime=true"
+
 type MasterData struct {
-	Companies          []IDName
-	Warehouses        []IDName
+	Contracts          []Contract
 	SalesAccountTitles []IDName
 	CostAccountTitles  []IDName
 }
 
 type IDName struct {
 	ID   int
+	Code string
 	Name string
 }
 
+// 会社と倉庫の契約（契約期間内の日付にのみデータを挿入する）
+type Contract struct {
+	CompanyID     int
+	CompanyCode   string
+	WarehouseID   int
+	WarehouseCode string
+	StartDate     time.Time
+	EndDate       sql.NullTime // NULL: 終了日なし
+}
+
+// Covers 日付が契約期間内か
+func (c Contract) Covers(date time.Time) bool {
+	day := date.Format("2006-01-02")
+	if day < c.StartDate.Format("2006-01-02") {
+		return false
+	}
+	return !c.EndDate.Valid || day <= c.EndDate.Time.Format("2006-01-02")
+}
+
 func main() {
+	configPath := flag.String("config", "", "設定ファイル (YAML)")
+	start := flag.String("start", "", "開始日 (YYYY-MM-DD、省略時は6日前)")
+	end := flag.String("end", "", "終了日 (YYYY-MM-DD、省略時は今日)")
+	seed := flag.Int64("seed", 0, "乱数のシード")
+	mode := flag.String("mode", "", "既存のレポートの扱い (skip: そのまま残す / upsert: 明細を置き換える)")
+	batchSize := flag.Int("batch-size", 0, "1回の INSERT で挿入する行数 (既定: 500)")
+	scenario := flag.String("scenario", "", "適用するシナリオ名 (カンマ区切り、設定ファイルの scenarios に定義したもの)")
+	flag.Parse()
+
+	config, err := LoadConfig(*configPath)
+	if err != nil {
+		log.Fatal("設定ファイル読み込みエラー:", err)
+	}
+	// 指定されたオプションで設定ファイルの値を上書きする
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "start":
+			config.Start = *start
+		case "end":
+			config.End = *end
+		case "seed":
+			config.Seed = *seed
+		case "mode":
+			config.Mode = Mode(*mode)
+		case "batch-size":
+			config.BatchSize = *batchSize
+		case "scenario":
+			config.ApplyScenarios = nil
+			for _, name := range strings.Split(*scenario, ",") {
+				if name = strings.TrimSpace(name); name != "" {
+					config.ApplyScenarios = append(config.ApplyScenarios, name)
+				}
+			}
+		}
+	})
+	if err := config.normalize(time.Now()); err != nil {
+		log.Fatal("設定エラー:", err)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
 	// データベース接続
-	db, err := sql.Open("mysql", "root:mypass@(mysql.local:3306)/sample_mysql?parseTime=true")
+	dsn := os.Getenv("DATA_INSERT_DSN")
+	if dsn == "" {
+		dsn = defaultDSN
+	}
+	db, err := sql.Open("mysql", dsn)
 	if err != nil {
 		log.Fatal("データベース接続エラー:", err)
 	}
 	defer db.Close()
 
 	// マスターデータ取得
-	masterData, err := getMasterData(db)
+	masterData, err := getMasterData(ctx, db, config.startDate, config.endDate)
 	if err != nil {
 		log.Fatal("マスターデータ取得エラー:", err)
 	}
 
-	// 1週間分の日付を生成（今日から7日前まで）
-	dates := generateWeekDates()
-
 	fmt.Printf("=== データ挿入開始 ===\n")
-	fmt.Printf("対象期間: %s ～ %s\n", dates[0].Format("2006-01-02"), dates[len(dates)-1].Format("2006-01-02"))
-	fmt.Printf("会社数: %d, 倉庫数: %d, 売上科目数: %d, 原価科目数: %d\n", 
-		len(masterData.Companies), len(masterData.Warehouses), 
+	fmt.Printf("対象期間: %s ～ %s\n", config.startDate.Format("2006-01-02"), config.endDate.Format("2006-01-02"))
+	fmt.Printf("シード: %d, モード: %s, 一括挿入行数: %d\n", config.Seed, config.Mode, config.BatchSize)
+	for _, name := range config.ApplyScenarios {
+		fmt.Printf("シナリオ: %s %s\n", name, config.Scenarios[name].Description)
+	}
+	fmt.Printf("会社・倉庫の契約数: %d, 売上科目数: %d, 原価科目数: %d\n",
+		len(masterData.Contracts),
 		len(masterData.SalesAccountTitles), len(masterData.CostAccountTitles))
+	if len(masterData.Contracts) == 0 {
+		log.Fatal("対象期間に契約のある会社・倉庫がありません（company_warehouse_contracts に契約を登録してください）")
+	}
+	for code := range config.Companies {
+		if !masterData.hasCompany(code) {
+			fmt.Printf("警告: 会社コード %s は対象期間に契約がないため、設定を使用しません\n", code)
+		}
+	}
 
-	// 売上データ挿入
-	salesCount, err := insertSalesData(db, masterData, dates)
-	if err != nil {
-		log.Fatal("売上データ挿入エラー:", err)
+	reports := make(map[ReportKind][]GeneratedReport)
+	missing := make(map[ReportKind]int)
+	for _, kind := range []ReportKind{ReportKindSales, ReportKindCost} {
+		reports[kind], missing[kind] = GenerateReports(config, masterData, kind)
 	}
-	fmt.Printf("売上データ挿入完了: %d件のレポート\n", salesCount)
 
-	// 原価データ挿入
-	costCount, err := insertCostData(db, masterData, dates)
+	// 売上・原価を1つのトランザクションで書き込み、取り込みバッチに記録する
+	// （claude-code-profit-report の import-batch rollback で取り消せる）
+	batchID, results, err := WriteReports(ctx, db, config, reports)
 	if err != nil {
-		log.Fatal("原価データ挿入エラー:", err)
+		log.Fatal(err)
+	}
+	for _, result := range results {
+		fmt.Printf("%sデータ: 新規 %d件, 置き換え %d件, スキップ %d件, 欠損 %d件のレポート（明細 %d件）\n",
+			kindLabel(result.Kind), result.Inserted, result.Replaced, result.Skipped, missing[result.Kind], result.Items)
+	}
+	if batchID == 0 {
+		fmt.Printf("挿入・置き換えたレポートはありません\n")
+	} else {
+		fmt.Printf("取り込みバッチ: %d\n", batchID)
 	}
-	fmt.Printf("原価データ挿入完了: %d件のレポート\n", costCount)
 
 	fmt.Printf("=== データ挿入完了 ===\n")
 }
 
-func getMasterData(db *sql.DB) (*MasterData, error) {
-	masterData := &MasterData{}
-
-	// 会社データ取得
-	rows, err := db.Query("SELECT id, name FROM companies ORDER BY id")
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	for rows.Next() {
-		var item IDName
-		err := rows.Scan(&item.ID, &item.Name)
-		if err != nil {
-			return nil, err
+func (m *MasterData) hasCompany(code string) bool {
+	for _, contract := range m.Contracts {
+		if contract.CompanyCode == code {
+			return true
 		}
-		masterData.Companies = append(masterData.Companies, item)
 	}
+	return false
+}
 
-	// 倉庫データ取得
-	rows, err = db.Query("SELECT id, name FROM warehouse_bases ORDER BY id")
+func getMasterData(ctx context.Context, db *sql.DB, startDate, endDate time.Time) (*MasterData, error) {
+	masterData := &MasterData{}
+
+	// 対象期間と契約期間が重なる会社・倉庫の契約を取得（契約のない組み合わせには挿入しない）
+	rows, err := db.QueryContext(ctx, `
+		SELECT c.company_id, co.code, c.warehouse_base_id, w.code, c.start_date, c.end_date
+		FROM company_warehouse_contracts c
+		JOIN companies co ON co.id = c.company_id
+		JOIN warehouse_bases w ON w.id = c.warehouse_base_id
+		WHERE c.start_date <= ? AND (c.end_date IS NULL OR c.end_date >= ?)
+		ORDER BY c.company_id, c.warehouse_base_id, c.start_date
+	`, endDate.Format("2006-01-02"), startDate.Format("2006-01-02"))
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
 	for rows.Next() {
-		var item IDName
-		err := rows.Scan(&item.ID, &item.Name)
+		var contract Contract
+		err := rows.Scan(&contract.CompanyID, &contract.CompanyCode, &contract.WarehouseID, &contract.WarehouseCode, &contract.StartDate, &contract.EndDate)
 		if err != nil {
 			return nil, err
 		}
-		masterData.Warehouses = append(masterData.Warehouses, item)
+		masterData.Contracts = append(masterData.Contracts, contract)
 	}
-
-	// 売上科目データ取得
-	rows, err = db.Query("SELECT id, name FROM sales_account_titles ORDER BY id")
-	if err != nil {
+	if err := rows.Err(); err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-	for rows.Next() {
-		var item IDName
-		err := rows.Scan(&item.ID, &item.Name)
-		if err != nil {
-			return nil, err
-		}
-		masterData.SalesAccountTitles = append(masterData.SalesAccountTitles, item)
-	}
 
-	// 原価科目データ取得
-	rows, err = db.Query("SELECT id, name FROM cost_account_titles ORDER BY id")
-	if err != nil {
+	// 売上科目・原価科目データ取得（無効化された科目には挿入しない）
+	if masterData.SalesAccountTitles, err = getAccountTitles(ctx, db, "sales_account_titles"); err != nil {
 		return nil, err
 	}
-	defer rows.Close()
-	for rows.Next() {
-		var item IDName
-		err := rows.Scan(&item.ID, &item.Name)
-		if err != nil {
-			return nil, err
-		}
-		masterData.CostAccountTitles = append(masterData.CostAccountTitles, item)
+	if masterData.CostAccountTitles, err = getAccountTitles(ctx, db, "cost_account_titles"); err != nil {
+		return nil, err
 	}
 
 	return masterData, nil
 }
 
-func generateWeekDates() []time.Time {
-	var dates []time.Time
-	now := time.Now()
-	
-	// 今日から7日前まで
-	for i := 6; i >= 0; i-- {
-		date := now.AddDate(0, 0, -i)
-		dates = append(dates, date)
-	}
-	
-	return dates
-}
-
-func insertSalesData(db *sql.DB, masterData *MasterData, dates []time.Time) (int, error) {
-	tx, err := db.Begin()
-	if err != nil {
-		return 0, err
-	}
-	defer tx.Rollback()
-
-	// 売上レポート挿入準備
-	reportStmt, err := tx.Prepare(`
-		INSERT INTO sales_daily_reports (company_id, warehouse_base_id, target_date, sales_account_title_id) 
-		VALUES (?, ?, ?, ?)
-	`)
-	if err != nil {
-		return 0, err
-	}
-	defer reportStmt.Close()
-
-	// 売上レポート明細挿入準備
-	itemStmt, err := tx.Prepare(`
-		INSERT INTO sales_daily_report_items (sales_daily_report_id, size, quantity, price, amount) 
-		VALUES (?, ?, ?, ?, ?)
-	`)
-	if err != nil {
-		return 0, err
-	}
-	defer itemStmt.Close()
-
-	reportCount := 0
-	
-	for _, date := range dates {
-		for _, company := range masterData.Companies {
-			for _, warehouse := range masterData.Warehouses {
-				for _, accountTitle := range masterData.SalesAccountTitles {
-					// 売上レポート挿入
-					result, err := reportStmt.Exec(company.ID, warehouse.ID, date.Format("2006-01-02"), accountTitle.ID)
-					if err != nil {
-						return 0, err
-					}
-					
-					reportID, err := result.LastInsertId()
-					if err != nil {
-						return 0, err
-					}
-					
-					// 各レポートに対して2-4個の明細を挿入
-					itemCount := rand.Intn(3) + 2 // 2-4個
-					sizes := []string{"S", "M", "L", "XL"}
-					
-					for i := 0; i < itemCount; i++ {
-						size := sizes[i%len(sizes)]
-						quantity := rand.Intn(100) + 10 // 10-109個
-						price := float64(rand.Intn(5000)+1000) / 100.0 // 10.00-59.99円
-						amount := float64(quantity) * price
-						
-						_, err := itemStmt.Exec(reportID, size, quantity, price, amount)
-						if err != nil {
-							return 0, err
-						}
-					}
-					
-					reportCount++
-				}
-			}
-		}
-	}
-
-	err = tx.Commit()
+func getAccountTitles(ctx context.Context, db *sql.DB, table string) ([]IDName, error) {
+	rows, err := db.QueryContext(ctx, "SELECT id, code, name FROM "+table+" WHERE disabled = 0 ORDER BY id")
 	if err != nil {
-		return 0, err
-	}
-
-	return reportCount, nil
-}
-
-func insertCostData(db *sql.DB, masterData *MasterData, dates []time.Time) (int, error) {
-	tx, err := db.Begin()
-	if err != nil {
-		return 0, err
-	}
-	defer tx.Rollback()
-
-	// 原価レポート挿入準備
-	reportStmt, err := tx.Prepare(`
-		INSERT INTO cost_daily_reports (company_id, warehouse_base_id, target_date, cost_account_title_id) 
-		VALUES (?, ?, ?, ?)
-	`)
-	if err != nil {
-		return 0, err
-	}
-	defer reportStmt.Close()
-
-	// 原価レポート明細挿入準備
-	itemStmt, err := tx.Prepare(`
-		INSERT INTO cost_daily_report_items (cost_daily_report_id, size, quantity, cost_price, cost_amount) 
-		VALUES (?, ?, ?, ?, ?)
-	`)
-	if err != nil {
-		return 0, err
+		return nil, err
 	}
-	defer itemStmt.Close()
+	defer rows.Close()
 
-	reportCount := 0
-	
-	for _, date := range dates {
-		for _, company := range masterData.Companies {
-			for _, warehouse := range masterData.Warehouses {
-				for _, accountTitle := range masterData.CostAccountTitles {
-					// 原価レポート挿入
-					result, err := reportStmt.Exec(company.ID, warehouse.ID, date.Format("2006-01-02"), accountTitle.ID)
-					if err != nil {
-						return 0, err
-					}
-					
-					reportID, err := result.LastInsertId()
-					if err != nil {
-						return 0, err
-					}
-					
-					// 各レポートに対して2-4個の明細を挿入
-					itemCount := rand.Intn(3) + 2 // 2-4個
-					sizes := []string{"S", "M", "L", "XL"}
-					
-					for i := 0; i < itemCount; i++ {
-						size := sizes[i%len(sizes)]
-						quantity := rand.Intn(100) + 10 // 10-109個
-						costPrice := float64(rand.Intn(3000)+500) / 100.0 // 5.00-34.99円（売上より安く）
-						costAmount := float64(quantity) * costPrice
-						
-						_, err := itemStmt.Exec(reportID, size, quantity, costPrice, costAmount)
-						if err != nil {
-							return 0, err
-						}
-					}
-					
-					reportCount++
-				}
-			}
+	var titles []IDName
+	for rows.Next() {
+		var item IDName
+		if err := rows.Scan(&item.ID, &item.Code, &item.Name); err != nil {
+			return nil, err
 		}
+		titles = append(titles, item)
 	}
-
-	err = tx.Commit()
-	if err != nil {
-		return 0, err
-	}
-
-	return reportCount, nil
+	return titles, rows.Err()
 }
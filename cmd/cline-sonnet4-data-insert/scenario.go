@@ -0,0 +1,203 @@
+package main
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math/rand"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Scenario 名前付きのシナリオ（季節性・繁忙期・赤字の取引先・欠損日・原価の急騰など）
+// 設定ファイルの scenarios に定義し、apply_scenarios または -scenario で適用する
+type Scenario struct {
+	Description string         `yaml:"description"`
+	Rules       []ScenarioRule `yaml:"rules"`
+}
+
+// ScenarioRule 条件に一致するレポートの数量・単価に倍率をかける、またはレポートを欠損させる
+// 条件を省略した項目は全てに一致する。複数のルールに一致した場合は倍率を掛け合わせる
+type ScenarioRule struct {
+	Name string `yaml:"name"`
+
+	// 条件
+	Start      string       `yaml:"start"`      // 期間 (YYYY-MM-DD)
+	End        string       `yaml:"end"`        // 期間 (YYYY-MM-DD)
+	Months     []int        `yaml:"months"`     // 月 (1〜12、毎年の繁忙期など)
+	Weekdays   []string     `yaml:"weekdays"`   // 曜日 (mon〜sun)
+	Days       []int        `yaml:"days"`       // 日 (1〜31、-1 は月末日、-2 は月末の前日)
+	Companies  []string     `yaml:"companies"`  // 会社コード
+	Warehouses []string     `yaml:"warehouses"` // 倉庫コード
+	Kinds      []ReportKind `yaml:"kinds"`      // sales / cost
+	// 条件に一致した会社・倉庫・日ごとに適用する確率 (0〜1、省略時は必ず適用)
+	Probability float64 `yaml:"probability"`
+
+	// 効果（倍率は省略時 1）
+	Quantity   float64 `yaml:"quantity"`
+	SalesPrice float64 `yaml:"sales_price"`
+	CostPrice  float64 `yaml:"cost_price"`
+	// レポートを作成しない（データの欠損日）
+	Missing bool `yaml:"missing"`
+
+	startDate time.Time
+	endDate   time.Time
+	weekdays  []time.Weekday
+}
+
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday,
+	"mon": time.Monday,
+	"tue": time.Tuesday,
+	"wed": time.Wednesday,
+	"thu": time.Thursday,
+	"fri": time.Friday,
+	"sat": time.Saturday,
+}
+
+// ScenarioEffect レポートに適用する効果
+type ScenarioEffect struct {
+	Quantity   float64
+	SalesPrice float64
+	CostPrice  float64
+	Missing    bool
+}
+
+// Price 種別の単価の倍率
+func (e ScenarioEffect) Price(kind ReportKind) float64 {
+	if kind == ReportKindCost {
+		return e.CostPrice
+	}
+	return e.SalesPrice
+}
+
+func (s *Scenario) validate() error {
+	if len(s.Rules) == 0 {
+		return fmt.Errorf("rules must not be empty")
+	}
+	for i := range s.Rules {
+		rule := &s.Rules[i]
+		if err := rule.validate(); err != nil {
+			return fmt.Errorf("rules[%d] %s: %w", i, rule.Name, err)
+		}
+	}
+	return nil
+}
+
+func (r *ScenarioRule) validate() error {
+	var err error
+	if r.Start != "" {
+		if r.startDate, err = time.ParseInLocation("2006-01-02", r.Start, time.Local); err != nil {
+			return fmt.Errorf("invalid start date: %w", err)
+		}
+	}
+	if r.End != "" {
+		if r.endDate, err = time.ParseInLocation("2006-01-02", r.End, time.Local); err != nil {
+			return fmt.Errorf("invalid end date: %w", err)
+		}
+	}
+	if r.Start != "" && r.End != "" && r.startDate.After(r.endDate) {
+		return fmt.Errorf("start date must be before or equal to end date")
+	}
+	for _, month := range r.Months {
+		if month < 1 || month > 12 {
+			return fmt.Errorf("months must be between 1 and 12: %d", month)
+		}
+	}
+	r.weekdays = nil
+	for _, name := range r.Weekdays {
+		weekday, ok := weekdayNames[strings.ToLower(name)]
+		if !ok {
+			return fmt.Errorf("unknown weekday: %s (sun / mon / tue / wed / thu / fri / sat)", name)
+		}
+		r.weekdays = append(r.weekdays, weekday)
+	}
+	for _, day := range r.Days {
+		if day == 0 || day < -31 || day > 31 {
+			return fmt.Errorf("days must be between 1 and 31 or -31 and -1: %d", day)
+		}
+	}
+	for _, kind := range r.Kinds {
+		if kind != ReportKindSales && kind != ReportKindCost {
+			return fmt.Errorf("unknown kind: %s (%s / %s)", kind, ReportKindSales, ReportKindCost)
+		}
+	}
+	if r.Probability < 0 || r.Probability > 1 {
+		return fmt.Errorf("probability must be between 0 and 1")
+	}
+	if r.Quantity < 0 || r.SalesPrice < 0 || r.CostPrice < 0 {
+		return fmt.Errorf("multipliers must not be negative")
+	}
+	if !r.Missing && r.Quantity == 0 && r.SalesPrice == 0 && r.CostPrice == 0 {
+		return fmt.Errorf("rule has no effect (quantity / sales_price / cost_price / missing)")
+	}
+	return nil
+}
+
+// matches レポートがルールの条件に一致するか
+func (r *ScenarioRule) matches(kind ReportKind, contract Contract, date time.Time) bool {
+	if r.Start != "" && date.Before(r.startDate) {
+		return false
+	}
+	if r.End != "" && date.After(r.endDate) {
+		return false
+	}
+	if len(r.Months) > 0 && !slices.Contains(r.Months, int(date.Month())) {
+		return false
+	}
+	if len(r.weekdays) > 0 && !slices.Contains(r.weekdays, date.Weekday()) {
+		return false
+	}
+	if len(r.Days) > 0 {
+		// 月末からの日 (-1: 月末日)
+		fromEnd := date.Day() - time.Date(date.Year(), date.Month()+1, 0, 0, 0, 0, 0, time.Local).Day() - 1
+		if !slices.Contains(r.Days, date.Day()) && !slices.Contains(r.Days, fromEnd) {
+			return false
+		}
+	}
+	if len(r.Companies) > 0 && !slices.Contains(r.Companies, contract.CompanyCode) {
+		return false
+	}
+	if len(r.Warehouses) > 0 && !slices.Contains(r.Warehouses, contract.WarehouseCode) {
+		return false
+	}
+	if len(r.Kinds) > 0 && !slices.Contains(r.Kinds, kind) {
+		return false
+	}
+	return true
+}
+
+// ScenarioEffectFor 適用するシナリオのルールから、レポートの効果を求める
+// 確率は科目・種別によらず会社・倉庫・日ごとに決まるため、欠損日は売上・原価とも同じ日になる
+func (c *Config) ScenarioEffectFor(kind ReportKind, contract Contract, date time.Time) ScenarioEffect {
+	effect := ScenarioEffect{Quantity: 1, SalesPrice: 1, CostPrice: 1}
+	for _, scenario := range c.ApplyScenarios {
+		for i := range c.Scenarios[scenario].Rules {
+			rule := &c.Scenarios[scenario].Rules[i]
+			if !rule.matches(kind, contract, date) {
+				continue
+			}
+			if rule.Probability > 0 && scenarioRand(c.Seed, scenario, i, contract, date).Float64() >= rule.Probability {
+				continue
+			}
+			effect.Quantity *= multiplier(rule.Quantity)
+			effect.SalesPrice *= multiplier(rule.SalesPrice)
+			effect.CostPrice *= multiplier(rule.CostPrice)
+			effect.Missing = effect.Missing || rule.Missing
+		}
+	}
+	return effect
+}
+
+func scenarioRand(seed int64, scenario string, rule int, contract Contract, date time.Time) *rand.Rand {
+	h := fnv.New64a()
+	fmt.Fprintf(h, "%d/%s/%d/%s/%s/%s", seed, scenario, rule, contract.CompanyCode, contract.WarehouseCode, date.Format("2006-01-02"))
+	return rand.New(rand.NewSource(int64(h.Sum64())))
+}
+
+func multiplier(value float64) float64 {
+	if value == 0 {
+		return 1
+	}
+	return value
+}
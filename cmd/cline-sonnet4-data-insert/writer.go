@@ -0,0 +1,269 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// 取り込みバッチの取り込み元
+const importBatchSource = "data-insert"
+
+// 日次レポートのテーブルとカラム
+type reportTable struct {
+	reports      string
+	items        string
+	reportFK     string
+	titleFK      string
+	priceColumn  string
+	amountColumn string
+}
+
+var reportTables = map[ReportKind]reportTable{
+	ReportKindSales: {"sales_daily_reports", "sales_daily_report_items", "sales_daily_report_id", "sales_account_title_id", "price", "amount"},
+	ReportKindCost:  {"cost_daily_reports", "cost_daily_report_items", "cost_daily_report_id", "cost_account_title_id", "cost_price", "cost_amount"},
+}
+
+// 種別ごとの書き込み結果
+type WriteResult struct {
+	Kind     ReportKind
+	Inserted int
+	Replaced int
+	Skipped  int
+	Items    int
+}
+
+// WriteReports 取り込みバッチを作成し、売上・原価のレポートを1つのトランザクションで書き込む
+// 書き込むレポートがない場合はバッチを作成せず、batchID は 0 を返す
+func WriteReports(ctx context.Context, db *sql.DB, config *Config, reports map[ReportKind][]GeneratedReport) (int64, []WriteResult, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.ExecContext(ctx, "INSERT INTO import_batches (source) VALUES (?)", importBatchSource)
+	if err != nil {
+		return 0, nil, fmt.Errorf("取り込みバッチ作成エラー: %w", err)
+	}
+	batchID, err := result.LastInsertId()
+	if err != nil {
+		return 0, nil, err
+	}
+
+	var results []WriteResult
+	written, items := 0, 0
+	for _, kind := range []ReportKind{ReportKindSales, ReportKindCost} {
+		w := &reportWriter{tx: tx, table: reportTables[kind], kind: kind, batchID: batchID, batchSize: config.BatchSize}
+		res, err := w.write(ctx, reports[kind], config.Mode, config.startDate, config.endDate)
+		if err != nil {
+			return 0, nil, fmt.Errorf("%sデータ書き込みエラー: %w", kindLabel(kind), err)
+		}
+		results = append(results, *res)
+		written += res.Inserted + res.Replaced
+		items += res.Items
+	}
+
+	if written == 0 {
+		return 0, results, nil
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE import_batches SET report_count = ?, item_count = ? WHERE id = ?", written, items, batchID); err != nil {
+		return 0, nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, nil, err
+	}
+	return batchID, results, nil
+}
+
+type reportWriter struct {
+	tx        *sql.Tx
+	table     reportTable
+	kind      ReportKind
+	batchID   int64
+	batchSize int
+}
+
+func (w *reportWriter) write(ctx context.Context, reports []GeneratedReport, mode Mode, startDate, endDate time.Time) (*WriteResult, error) {
+	result := &WriteResult{Kind: w.kind}
+
+	existing, err := w.reportIDs(ctx, "target_date BETWEEN ? AND ?", startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+
+	var inserts, replaces []*GeneratedReport
+	ids := make(map[reportKey]int64, len(reports))
+	for i := range reports {
+		report := &reports[i]
+		id, ok := existing[report.key()]
+		switch {
+		case !ok:
+			inserts = append(inserts, report)
+		case mode == ModeSkip:
+			result.Skipped++
+		default:
+			replaces = append(replaces, report)
+			ids[report.key()] = id
+		}
+	}
+
+	if err := w.insertReports(ctx, inserts); err != nil {
+		return nil, err
+	}
+	// 複数行の INSERT では各行の ID を得られないため、バッチIDで作成したレポートを引き直す
+	inserted, err := w.reportIDs(ctx, "import_batch_id = ?", w.batchID)
+	if err != nil {
+		return nil, err
+	}
+	for key, id := range inserted {
+		ids[key] = id
+	}
+	if len(inserts) > 0 {
+		if _, err := w.tx.ExecContext(ctx, `
+			INSERT INTO import_batch_reports (import_batch_id, kind, report_id, created)
+			SELECT ?, ?, id, 1 FROM `+w.table.reports+` WHERE import_batch_id = ?
+		`, w.batchID, w.kind, w.batchID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := w.replaceReports(ctx, replaces, ids); err != nil {
+		return nil, err
+	}
+
+	written := append(inserts, replaces...)
+	if err := w.insertItems(ctx, written, ids); err != nil {
+		return nil, err
+	}
+
+	result.Inserted = len(inserts)
+	result.Replaced = len(replaces)
+	for _, report := range written {
+		result.Items += len(report.Items)
+	}
+	return result, nil
+}
+
+// reportIDs 条件に一致するレポートのユニークキーと ID
+func (w *reportWriter) reportIDs(ctx context.Context, condition string, args ...interface{}) (map[reportKey]int64, error) {
+	rows, err := w.tx.QueryContext(ctx, `
+		SELECT id, company_id, warehouse_base_id, target_date, `+w.table.titleFK+`
+		FROM `+w.table.reports+`
+		WHERE `+condition, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make(map[reportKey]int64)
+	for rows.Next() {
+		var id int64
+		var key reportKey
+		var date time.Time
+		if err := rows.Scan(&id, &key.companyID, &key.warehouseID, &date, &key.titleID); err != nil {
+			return nil, err
+		}
+		key.date = date.Format("2006-01-02")
+		ids[key] = id
+	}
+	return ids, rows.Err()
+}
+
+func (w *reportWriter) insertReports(ctx context.Context, reports []*GeneratedReport) error {
+	return inChunks(len(reports), w.batchSize, func(start, end int) error {
+		args := make([]interface{}, 0, (end-start)*5)
+		for _, report := range reports[start:end] {
+			args = append(args, report.CompanyID, report.WarehouseID, report.Date.Format("2006-01-02"), report.TitleID, w.batchID)
+		}
+		_, err := w.tx.ExecContext(ctx, `
+			INSERT INTO `+w.table.reports+` (company_id, warehouse_base_id, target_date, `+w.table.titleFK+`, import_batch_id)
+			VALUES `+placeholders(end-start, 5), args...)
+		return err
+	})
+}
+
+// replaceReports 既存のレポートの明細を置き換える準備をする
+// （置き換え前の状態を取り込みバッチに保存し、claude-code-profit-report の import-batch rollback で戻せるようにする）
+func (w *reportWriter) replaceReports(ctx context.Context, reports []*GeneratedReport, ids map[reportKey]int64) error {
+	return inChunks(len(reports), w.batchSize, func(start, end int) error {
+		reportIDs := make([]interface{}, 0, end-start)
+		for _, report := range reports[start:end] {
+			reportIDs = append(reportIDs, ids[report.key()])
+		}
+		in := "(" + strings.TrimSuffix(strings.Repeat("?, ", len(reportIDs)), ", ") + ")"
+
+		statements := []struct {
+			query string
+			args  []interface{}
+		}{
+			{`INSERT INTO import_batch_reports (import_batch_id, kind, report_id, created, previous_import_batch_id)
+				SELECT ?, ?, id, 0, import_batch_id FROM ` + w.table.reports + ` WHERE id IN ` + in,
+				append([]interface{}{w.batchID, w.kind}, reportIDs...)},
+			{`INSERT INTO import_batch_replaced_items
+					(import_batch_id, kind, report_id, item_id, item_import_batch_id, size, quantity, price, amount, item_created_at, item_updated_at)
+				SELECT ?, ?, ` + w.table.reportFK + `, id, import_batch_id, size, quantity, ` + w.table.priceColumn + `, ` + w.table.amountColumn + `, created_at, updated_at
+				FROM ` + w.table.items + ` WHERE ` + w.table.reportFK + ` IN ` + in,
+				append([]interface{}{w.batchID, w.kind}, reportIDs...)},
+			{`DELETE FROM ` + w.table.items + ` WHERE ` + w.table.reportFK + ` IN ` + in, reportIDs},
+			{`UPDATE ` + w.table.reports + ` SET import_batch_id = ?, updated_at = CURRENT_TIMESTAMP WHERE id IN ` + in,
+				append([]interface{}{w.batchID}, reportIDs...)},
+		}
+		for _, statement := range statements {
+			if _, err := w.tx.ExecContext(ctx, statement.query, statement.args...); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (w *reportWriter) insertItems(ctx context.Context, reports []*GeneratedReport, ids map[reportKey]int64) error {
+	var args []interface{}
+	for _, report := range reports {
+		id, ok := ids[report.key()]
+		if !ok {
+			return fmt.Errorf("report id not found: %+v", report.key())
+		}
+		for _, item := range report.Items {
+			args = append(args, id, item.Size, item.Quantity, item.Price, item.Amount, w.batchID)
+		}
+	}
+
+	const columns = 6
+	return inChunks(len(args)/columns, w.batchSize, func(start, end int) error {
+		_, err := w.tx.ExecContext(ctx, `
+			INSERT INTO `+w.table.items+` (`+w.table.reportFK+`, size, quantity, `+w.table.priceColumn+`, `+w.table.amountColumn+`, import_batch_id)
+			VALUES `+placeholders(end-start, columns), args[start*columns:end*columns]...)
+		return err
+	})
+}
+
+// inChunks 0〜n を size 件ずつに分けて fn を呼ぶ
+func inChunks(n, size int, fn func(start, end int) error) error {
+	for start := 0; start < n; start += size {
+		end := start + size
+		if end > n {
+			end = n
+		}
+		if err := fn(start, end); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// placeholders 複数行の VALUES 句 "(?, ?), (?, ?)"
+func placeholders(rows, columns int) string {
+	row := "(" + strings.TrimSuffix(strings.Repeat("?, ", columns), ", ") + ")"
+	return strings.TrimSuffix(strings.Repeat(row+", ", rows), ", ")
+}
+
+func kindLabel(kind ReportKind) string {
+	if kind == ReportKindCost {
+		return "原価"
+	}
+	return "売上"
+}
@@ -2,4 +2,10 @@ module profit-report
 
 go 1.21
 
-require github.com/go-sql-driver/mysql v1.7.1
+require (
+	github.com/go-sql-driver/mysql v1.7.1
+	github.com/taka512/golang/pkg/calendar v0.0.0-00010101000000-000000000000
+)
+
+// 祝日カレンダーはリポジトリ内で共有する
+replace github.com/taka512/golang/pkg/calendar => ../../pkg/calendar
@@ -1,12 +1,20 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql"
+	"github.com/taka512/golang/pkg/calendar"
 )
 
 type ShipmentReport struct {
@@ -29,9 +37,61 @@ type Summary struct {
 	TotalSalesQty   int
 	TotalCostQty    int
 	Days            int
+
+	// 営業日（土日・祝日以外）と休日の内訳
+	BusinessDays         int
+	NonBusinessDays      int
+	BusinessDaySales     float64
+	BusinessDayCost      float64
+	BusinessDayProfit    float64
+	NonBusinessDaySales  float64
+	NonBusinessDayCost   float64
+	NonBusinessDayProfit float64
+
+	// -include-disabled で含めた無効な科目の金額（合計の内数）
+	InactiveSales float64
+	InactiveCost  float64
+}
+
+// 集計対象の売上科目・原価科目のコード
+const shipmentTitleCode = "shipment"
+
+// 出荷の売上科目・原価科目が無効化されているか
+type DisabledTitles struct {
+	Sales bool
+	Cost  bool
 }
 
+// target_dateの文字列表現として受け付ける形式
+var reportDateLayouts = []string{"2006-01-02", time.RFC3339}
+
 func main() {
+	holidayFile := flag.String("holidays", "", "祝日CSVファイル (YYYY-MM-DD,名称) を組み込みカレンダーに追加")
+	timeout := flag.Duration("timeout", 10*time.Minute, "全体のタイムアウト (0: 無制限)")
+	queryTimeout := flag.Duration("query-timeout", 2*time.Minute, "レポート取得クエリのタイムアウト (0: 無制限)")
+	includeDisabled := flag.Bool("include-disabled", false, "無効化された科目の日報も集計に含める")
+	flag.Parse()
+
+	// SIGINT/SIGTERM またはタイムアウトで実行中のクエリをキャンセルする
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
+	// 祝日カレンダー読み込み
+	cal, err := calendar.NewCalendar()
+	if err != nil {
+		log.Fatal("祝日カレンダー読み込みエラー:", err)
+	}
+	if *holidayFile != "" {
+		if err := cal.LoadFile(*holidayFile); err != nil {
+			log.Fatal("祝日ファイル読み込みエラー:", err)
+		}
+	}
+
 	// データベース接続
 	db, err := sql.Open("mysql", "root:mypass@(mysql.local:3306)/sample_mysql?parseTime=true")
 	if err != nil {
@@ -39,9 +99,25 @@ func main() {
 	}
 	defer db.Close()
 
+	// 科目の無効フラグを確認（無効な科目は -include-disabled を指定しない限り除外する）
+	disabled, err := getDisabledTitles(ctx, db, *queryTimeout)
+	if err != nil {
+		exitIfCanceled(ctx, err)
+		log.Fatal("科目の確認エラー:", err)
+	}
+	if !*includeDisabled {
+		if disabled.Sales {
+			fmt.Printf("売上科目 %s は無効化されているため集計から除外します (-include-disabled で含める)\n", shipmentTitleCode)
+		}
+		if disabled.Cost {
+			fmt.Printf("原価科目 %s は無効化されているため集計から除外します (-include-disabled で含める)\n", shipmentTitleCode)
+		}
+	}
+
 	// 出荷実績レポートを取得
-	reports, err := getShipmentReports(db)
+	reports, err := getShipmentReports(ctx, db, *queryTimeout, *includeDisabled)
 	if err != nil {
+		exitIfCanceled(ctx, err)
 		log.Fatal("レポート取得エラー:", err)
 	}
 
@@ -50,6 +126,15 @@ func main() {
 		return
 	}
 
+	// 祝日が登録されていない年が含まれる場合は、レポートの表示前に一度だけ警告する
+	start, end, err := reportPeriod(reports)
+	if err != nil {
+		log.Fatal("レポート期間の確認エラー:", err)
+	}
+	if err := cal.CheckRange(start, end); err != nil {
+		log.Printf("警告: 祝日カレンダー: %v", err)
+	}
+
 	// レポート表示
 	printHeader()
 	for _, report := range reports {
@@ -58,11 +143,66 @@ func main() {
 	printSeparator()
 
 	// サマリー計算と表示
-	summary := calculateSummary(reports)
+	summary, err := calculateSummary(reports, cal)
+	if err != nil {
+		log.Fatal("サマリー計算エラー:", err)
+	}
+	if *includeDisabled {
+		if disabled.Sales {
+			summary.InactiveSales = summary.TotalSales
+		}
+		if disabled.Cost {
+			summary.InactiveCost = summary.TotalCost
+		}
+	}
 	printSummary(summary)
 }
 
-func getShipmentReports(db *sql.DB) ([]ShipmentReport, error) {
+// 停止シグナル・タイムアウトによる中断であれば理由を表示して終了する
+// （終了コードは timeout(1) に合わせてタイムアウト時 124、シグナル受信時 130）
+func exitIfCanceled(ctx context.Context, err error) {
+	switch {
+	case ctx.Err() != nil && !errors.Is(ctx.Err(), context.DeadlineExceeded):
+		fmt.Fprintf(os.Stderr, "停止シグナルを受信したためレポート取得を中断しました: %v\n", err)
+		os.Exit(130)
+	case errors.Is(err, context.DeadlineExceeded):
+		fmt.Fprintf(os.Stderr, "タイムアウトのためレポート取得を中断しました: %v\n", err)
+		os.Exit(124)
+	}
+}
+
+// 出荷の売上科目・原価科目の無効フラグを取得する
+func getDisabledTitles(ctx context.Context, db *sql.DB, queryTimeout time.Duration) (DisabledTitles, error) {
+	if queryTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, queryTimeout)
+		defer cancel()
+	}
+
+	var disabled DisabledTitles
+	err := db.QueryRowContext(ctx, `
+SELECT
+COALESCE((SELECT disabled FROM sales_account_titles WHERE code = ?), 0),
+COALESCE((SELECT disabled FROM cost_account_titles WHERE code = ?), 0)
+`, shipmentTitleCode, shipmentTitleCode).Scan(&disabled.Sales, &disabled.Cost)
+	return disabled, err
+}
+
+// includeDisabled が false の場合は無効化された科目の日報を除外する
+func getShipmentReports(ctx context.Context, db *sql.DB, queryTimeout time.Duration, includeDisabled bool) ([]ShipmentReport, error) {
+	if queryTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, queryTimeout)
+		defer cancel()
+	}
+
+	activeOnly := " AND disabled = 0"
+	if includeDisabled {
+		activeOnly = ""
+	}
+	salesTitle := "(SELECT id FROM sales_account_titles WHERE code = '" + shipmentTitleCode + "'" + activeOnly + ")"
+	costTitle := "(SELECT id FROM cost_account_titles WHERE code = '" + shipmentTitleCode + "'" + activeOnly + ")"
+
 	query := `
 SELECT 
 s.target_date,
@@ -75,11 +215,11 @@ COALESCE(cost_summary.total_quantity, 0) as cost_quantity
 FROM (
 SELECT DISTINCT target_date, company_id, warehouse_base_id
 FROM sales_daily_reports 
-WHERE sales_account_title_id = (SELECT id FROM sales_account_titles WHERE code = 'shipment')
+WHERE sales_account_title_id = ` + salesTitle + `
 UNION
 SELECT DISTINCT target_date, company_id, warehouse_base_id  
 FROM cost_daily_reports
-WHERE cost_account_title_id = (SELECT id FROM cost_account_titles WHERE code = 'shipment')
+WHERE cost_account_title_id = ` + costTitle + `
 ) s
 JOIN companies c ON s.company_id = c.id
 JOIN warehouse_bases w ON s.warehouse_base_id = w.id
@@ -92,7 +232,7 @@ SUM(si.amount) as total_amount,
 SUM(si.quantity) as total_quantity
 FROM sales_daily_reports sdr
 JOIN sales_daily_report_items si ON sdr.id = si.sales_daily_report_id
-WHERE sdr.sales_account_title_id = (SELECT id FROM sales_account_titles WHERE code = 'shipment')
+WHERE sdr.sales_account_title_id = ` + salesTitle + `
 GROUP BY sdr.target_date, sdr.company_id, sdr.warehouse_base_id
 ) sales_summary ON s.target_date = sales_summary.target_date 
 AND s.company_id = sales_summary.company_id 
@@ -106,7 +246,7 @@ SUM(ci.cost_amount) as total_amount,
 SUM(ci.quantity) as total_quantity
 FROM cost_daily_reports cdr
 JOIN cost_daily_report_items ci ON cdr.id = ci.cost_daily_report_id
-WHERE cdr.cost_account_title_id = (SELECT id FROM cost_account_titles WHERE code = 'shipment')
+WHERE cdr.cost_account_title_id = ` + costTitle + `
 GROUP BY cdr.target_date, cdr.company_id, cdr.warehouse_base_id
 ) cost_summary ON s.target_date = cost_summary.target_date 
 AND s.company_id = cost_summary.company_id 
@@ -114,7 +254,7 @@ AND s.warehouse_base_id = cost_summary.warehouse_base_id
 ORDER BY s.target_date DESC, c.name, w.name
 `
 
-	rows, err := db.Query(query)
+	rows, err := db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -157,6 +297,11 @@ ORDER BY s.target_date DESC, c.name, w.name
 		reports = append(reports, report)
 	}
 
+	// 読み込み途中のキャンセルは rows.Err で返る
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
 	return reports, nil
 }
 
@@ -192,7 +337,7 @@ func printSeparator() {
 	fmt.Println(strings.Repeat("-", 120))
 }
 
-func calculateSummary(reports []ShipmentReport) Summary {
+func calculateSummary(reports []ShipmentReport, cal *calendar.Calendar) (Summary, error) {
 	var summary Summary
 	dateMap := make(map[string]bool)
 
@@ -202,7 +347,31 @@ func calculateSummary(reports []ShipmentReport) Summary {
 		summary.TotalProfit += report.Profit
 		summary.TotalSalesQty += report.SalesQuantity
 		summary.TotalCostQty += report.CostQuantity
-		dateMap[report.Date] = true
+
+		// 日付を解釈できない行を営業日として数えると営業日平均がずれるため、エラーにする
+		date, err := parseDate(report.Date, reportDateLayouts)
+		if err != nil {
+			return Summary{}, fmt.Errorf("invalid report date %q: %w", report.Date, err)
+		}
+		businessDay := cal.IsBusinessDay(date)
+		if businessDay {
+			summary.BusinessDaySales += report.SalesAmount
+			summary.BusinessDayCost += report.CostAmount
+			summary.BusinessDayProfit += report.Profit
+		} else {
+			summary.NonBusinessDaySales += report.SalesAmount
+			summary.NonBusinessDayCost += report.CostAmount
+			summary.NonBusinessDayProfit += report.Profit
+		}
+
+		if !dateMap[report.Date] {
+			dateMap[report.Date] = true
+			if businessDay {
+				summary.BusinessDays++
+			} else {
+				summary.NonBusinessDays++
+			}
+		}
 	}
 
 	summary.Days = len(dateMap)
@@ -212,7 +381,7 @@ func calculateSummary(reports []ShipmentReport) Summary {
 		summary.AvgProfitMargin = (summary.TotalProfit / summary.TotalSales) * 100
 	}
 
-	return summary
+	return summary, nil
 }
 
 func printSummary(summary Summary) {
@@ -224,6 +393,9 @@ func printSummary(summary Summary) {
 	fmt.Printf("平均粗利率: %.1f%%\n", summary.AvgProfitMargin)
 	fmt.Printf("総売上数量: %d\n", summary.TotalSalesQty)
 	fmt.Printf("総原価数量: %d\n", summary.TotalCostQty)
+	if summary.InactiveSales != 0 || summary.InactiveCost != 0 {
+		fmt.Printf("無効科目: 売上 %s / 原価 %s (上記の合計に含む)\n", formatCurrency(summary.InactiveSales), formatCurrency(summary.InactiveCost))
+	}
 	fmt.Println()
 
 	// 日別平均
@@ -234,6 +406,22 @@ func printSummary(summary Summary) {
 		fmt.Printf("平均日粗利: %s\n", formatCurrency(summary.TotalProfit/float64(summary.Days)))
 		fmt.Printf("平均日売上数量: %.0f\n", float64(summary.TotalSalesQty)/float64(summary.Days))
 		fmt.Printf("平均日原価数量: %.0f\n", float64(summary.TotalCostQty)/float64(summary.Days))
+		fmt.Println()
+	}
+
+	// 営業日・休日別平均
+	if summary.BusinessDays > 0 {
+		fmt.Printf("=== 営業日平均 (%d日) ===\n", summary.BusinessDays)
+		fmt.Printf("平均日売上: %s\n", formatCurrency(summary.BusinessDaySales/float64(summary.BusinessDays)))
+		fmt.Printf("平均日原価: %s\n", formatCurrency(summary.BusinessDayCost/float64(summary.BusinessDays)))
+		fmt.Printf("平均日粗利: %s\n", formatCurrency(summary.BusinessDayProfit/float64(summary.BusinessDays)))
+		fmt.Println()
+	}
+	if summary.NonBusinessDays > 0 {
+		fmt.Printf("=== 休日平均 (土日祝 %d日) ===\n", summary.NonBusinessDays)
+		fmt.Printf("平均日売上: %s\n", formatCurrency(summary.NonBusinessDaySales/float64(summary.NonBusinessDays)))
+		fmt.Printf("平均日原価: %s\n", formatCurrency(summary.NonBusinessDayCost/float64(summary.NonBusinessDays)))
+		fmt.Printf("平均日粗利: %s\n", formatCurrency(summary.NonBusinessDayProfit/float64(summary.NonBusinessDays)))
 	}
 }
 
@@ -271,3 +459,32 @@ func truncateString(s string, maxLen int) string {
 	}
 	return s[:maxLen-3] + "..."
 }
+
+// reportPeriod レポートの最初と最後の日付を返す
+func reportPeriod(reports []ShipmentReport) (time.Time, time.Time, error) {
+	var start, end time.Time
+	for i, report := range reports {
+		date, err := parseDate(report.Date, reportDateLayouts)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid report date %q: %w", report.Date, err)
+		}
+		if i == 0 || date.Before(start) {
+			start = date
+		}
+		if i == 0 || date.After(end) {
+			end = date
+		}
+	}
+	return start, end, nil
+}
+
+// parseDate 複数の日付形式を順に試して解析する
+func parseDate(s string, layouts []string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported date format: %s", s)
+}
@@ -1,12 +1,20 @@
 package main
 
 import (
+	"context"
 	"database/sql"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
 	"strings"
+	"syscall"
+	"time"
 
 	_ "github.com/go-sql-driver/mysql"
+	"github.com/taka512/golang/pkg/calendar"
 )
 
 // ShipmentData 出荷データの構造体
@@ -36,12 +44,64 @@ type Summary struct {
 	TotalSalesQty   int
 	TotalCostQty    int
 	Days            int
+
+	// 営業日（土日・祝日以外）と休日の内訳
+	BusinessDays         int
+	NonBusinessDays      int
+	BusinessDaySales     float64
+	BusinessDayCost      float64
+	BusinessDayProfit    float64
+	NonBusinessDaySales  float64
+	NonBusinessDayCost   float64
+	NonBusinessDayProfit float64
+
+	// -include-disabled で含めた無効な科目の金額（合計の内数）
+	InactiveSales float64
+	InactiveCost  float64
+}
+
+// shipmentTitleCode 集計対象の売上科目・原価科目のコード
+const shipmentTitleCode = "shipment"
+
+// DisabledTitles 出荷の売上科目・原価科目が無効化されているか
+type DisabledTitles struct {
+	Sales bool
+	Cost  bool
 }
 
+// reportDateLayouts target_dateの文字列表現として受け付ける形式
+var reportDateLayouts = []string{"2006-01-02", time.RFC3339}
+
 func main() {
+	holidayFile := flag.String("holidays", "", "祝日CSVファイル (YYYY-MM-DD,名称) を組み込みカレンダーに追加")
+	timeout := flag.Duration("timeout", 10*time.Minute, "全体のタイムアウト (0: 無制限)")
+	queryTimeout := flag.Duration("query-timeout", 2*time.Minute, "接続テスト・データ取得それぞれのタイムアウト (0: 無制限)")
+	includeDisabled := flag.Bool("include-disabled", false, "無効化された科目の日報も集計に含める")
+	flag.Parse()
+
+	// SIGINT/SIGTERM またはタイムアウトで実行中のクエリをキャンセルする
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
 	fmt.Println("=== 出荷粗利計算プログラム ===")
 	fmt.Println()
 
+	// 祝日カレンダー読み込み
+	cal, err := calendar.NewCalendar()
+	if err != nil {
+		log.Fatal("祝日カレンダー読み込みエラー:", err)
+	}
+	if *holidayFile != "" {
+		if err := cal.LoadFile(*holidayFile); err != nil {
+			log.Fatal("祝日ファイル読み込みエラー:", err)
+		}
+	}
+
 	// データベース接続
 	db, err := sql.Open("mysql", "root:mypass@(mysql.local:3306)/sample_mysql?parseTime=true")
 	if err != nil {
@@ -50,13 +110,38 @@ func main() {
 	defer db.Close()
 
 	// データベース接続テスト
-	if err := db.Ping(); err != nil {
+	if err := withQueryTimeout(ctx, *queryTimeout, db.PingContext); err != nil {
+		exitIfCanceled(ctx, "データベース接続テスト", err)
 		log.Fatal("データベース接続テスト失敗:", err)
 	}
 
+	// 科目の無効フラグを確認（無効な科目は -include-disabled を指定しない限り除外する）
+	var disabled DisabledTitles
+	err = withQueryTimeout(ctx, *queryTimeout, func(ctx context.Context) (err error) {
+		disabled, err = getDisabledTitles(ctx, db)
+		return err
+	})
+	if err != nil {
+		exitIfCanceled(ctx, "科目の確認", err)
+		log.Fatal("科目の確認エラー:", err)
+	}
+	if !*includeDisabled {
+		if disabled.Sales {
+			fmt.Printf("売上科目 %s は無効化されているため集計から除外します (-include-disabled で含める)\n", shipmentTitleCode)
+		}
+		if disabled.Cost {
+			fmt.Printf("原価科目 %s は無効化されているため集計から除外します (-include-disabled で含める)\n", shipmentTitleCode)
+		}
+	}
+
 	// 出荷データを取得
-	shipmentData, err := getShipmentData(db)
+	var shipmentData []ShipmentData
+	err = withQueryTimeout(ctx, *queryTimeout, func(ctx context.Context) (err error) {
+		shipmentData, err = getShipmentData(ctx, db, *includeDisabled)
+		return err
+	})
 	if err != nil {
+		exitIfCanceled(ctx, "出荷データ取得", err)
 		log.Fatal("データ取得エラー:", err)
 	}
 
@@ -68,6 +153,15 @@ func main() {
 	// 粗利計算
 	reports := calculateProfits(shipmentData)
 
+	// 祝日が登録されていない年が含まれる場合は、レポートの表示前に一度だけ警告する
+	start, end, err := reportPeriod(reports)
+	if err != nil {
+		log.Fatal("レポート期間の確認エラー:", err)
+	}
+	if err := cal.CheckRange(start, end); err != nil {
+		log.Printf("警告: 祝日カレンダー: %v", err)
+	}
+
 	// レポート表示
 	printHeader()
 	for _, report := range reports {
@@ -76,12 +170,64 @@ func main() {
 	printSeparator()
 
 	// サマリー計算と表示
-	summary := calculateSummary(reports)
+	summary, err := calculateSummary(reports, cal)
+	if err != nil {
+		log.Fatal("サマリー計算エラー:", err)
+	}
+	if *includeDisabled {
+		if disabled.Sales {
+			summary.InactiveSales = summary.TotalSales
+		}
+		if disabled.Cost {
+			summary.InactiveCost = summary.TotalCost
+		}
+	}
 	printSummary(summary)
 }
 
-// getShipmentData データベースから出荷データを取得
-func getShipmentData(db *sql.DB) ([]ShipmentData, error) {
+// withQueryTimeout 1回のクエリに timeout を設定した context で fn を実行する（0以下は無制限）
+func withQueryTimeout(ctx context.Context, timeout time.Duration, fn func(ctx context.Context) error) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return fn(ctx)
+}
+
+// exitIfCanceled 停止シグナル・タイムアウトによる中断であれば、中断した処理を表示して終了する
+// （終了コードは timeout(1) に合わせてタイムアウト時 124、シグナル受信時 130）
+func exitIfCanceled(ctx context.Context, step string, err error) {
+	switch {
+	case ctx.Err() != nil && !errors.Is(ctx.Err(), context.DeadlineExceeded):
+		fmt.Fprintf(os.Stderr, "停止シグナルを受信したため %s を中断しました: %v\n", step, err)
+		os.Exit(130)
+	case errors.Is(err, context.DeadlineExceeded):
+		fmt.Fprintf(os.Stderr, "タイムアウトのため %s を中断しました: %v\n", step, err)
+		os.Exit(124)
+	}
+}
+
+// getDisabledTitles 出荷の売上科目・原価科目の無効フラグを取得
+func getDisabledTitles(ctx context.Context, db *sql.DB) (DisabledTitles, error) {
+	var disabled DisabledTitles
+	err := db.QueryRowContext(ctx, `
+SELECT
+	COALESCE((SELECT disabled FROM sales_account_titles WHERE code = ?), 0),
+	COALESCE((SELECT disabled FROM cost_account_titles WHERE code = ?), 0)
+`, shipmentTitleCode, shipmentTitleCode).Scan(&disabled.Sales, &disabled.Cost)
+	return disabled, err
+}
+
+// getShipmentData データベースから出荷データを取得（includeDisabled が false の場合は無効な科目を除外）
+func getShipmentData(ctx context.Context, db *sql.DB, includeDisabled bool) ([]ShipmentData, error) {
+	activeOnly := " AND disabled = 0"
+	if includeDisabled {
+		activeOnly = ""
+	}
+	salesTitle := "(SELECT id FROM sales_account_titles WHERE code = '" + shipmentTitleCode + "'" + activeOnly + ")"
+	costTitle := "(SELECT id FROM cost_account_titles WHERE code = '" + shipmentTitleCode + "'" + activeOnly + ")"
+
 	query := `
 SELECT 
 	s.target_date,
@@ -94,11 +240,11 @@ SELECT
 FROM (
 	SELECT DISTINCT target_date, company_id, warehouse_base_id
 	FROM sales_daily_reports 
-	WHERE sales_account_title_id = (SELECT id FROM sales_account_titles WHERE code = 'shipment')
+	WHERE sales_account_title_id = ` + salesTitle + `
 	UNION
 	SELECT DISTINCT target_date, company_id, warehouse_base_id  
 	FROM cost_daily_reports
-	WHERE cost_account_title_id = (SELECT id FROM cost_account_titles WHERE code = 'shipment')
+	WHERE cost_account_title_id = ` + costTitle + `
 ) s
 JOIN companies c ON s.company_id = c.id
 JOIN warehouse_bases w ON s.warehouse_base_id = w.id
@@ -111,7 +257,7 @@ LEFT JOIN (
 		SUM(si.quantity) as total_quantity
 	FROM sales_daily_reports sdr
 	JOIN sales_daily_report_items si ON sdr.id = si.sales_daily_report_id
-	WHERE sdr.sales_account_title_id = (SELECT id FROM sales_account_titles WHERE code = 'shipment')
+	WHERE sdr.sales_account_title_id = ` + salesTitle + `
 	GROUP BY sdr.target_date, sdr.company_id, sdr.warehouse_base_id
 ) sales_summary ON s.target_date = sales_summary.target_date 
 	AND s.company_id = sales_summary.company_id 
@@ -125,7 +271,7 @@ LEFT JOIN (
 		SUM(ci.quantity) as total_quantity
 	FROM cost_daily_reports cdr
 	JOIN cost_daily_report_items ci ON cdr.id = ci.cost_daily_report_id
-	WHERE cdr.cost_account_title_id = (SELECT id FROM cost_account_titles WHERE code = 'shipment')
+	WHERE cdr.cost_account_title_id = ` + costTitle + `
 	GROUP BY cdr.target_date, cdr.company_id, cdr.warehouse_base_id
 ) cost_summary ON s.target_date = cost_summary.target_date 
 	AND s.company_id = cost_summary.company_id 
@@ -133,7 +279,7 @@ LEFT JOIN (
 ORDER BY s.target_date DESC, c.name, w.name
 `
 
-	rows, err := db.Query(query)
+	rows, err := db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, err
 	}
@@ -168,6 +314,11 @@ ORDER BY s.target_date DESC, c.name, w.name
 		data = append(data, item)
 	}
 
+	// 読み込み途中のキャンセルは rows.Err で返る
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
 	return data, nil
 }
 
@@ -230,7 +381,7 @@ func printSeparator() {
 }
 
 // calculateSummary サマリーを計算
-func calculateSummary(reports []ProfitReport) Summary {
+func calculateSummary(reports []ProfitReport, cal *calendar.Calendar) (Summary, error) {
 	var summary Summary
 	dateMap := make(map[string]bool)
 
@@ -240,7 +391,31 @@ func calculateSummary(reports []ProfitReport) Summary {
 		summary.TotalProfit += report.Profit
 		summary.TotalSalesQty += report.SalesQuantity
 		summary.TotalCostQty += report.CostQuantity
-		dateMap[report.Date] = true
+
+		// 日付を解釈できない行を営業日として数えると営業日平均がずれるため、エラーにする
+		date, err := parseDate(report.Date, reportDateLayouts)
+		if err != nil {
+			return Summary{}, fmt.Errorf("invalid report date %q: %w", report.Date, err)
+		}
+		businessDay := cal.IsBusinessDay(date)
+		if businessDay {
+			summary.BusinessDaySales += report.SalesAmount
+			summary.BusinessDayCost += report.CostAmount
+			summary.BusinessDayProfit += report.Profit
+		} else {
+			summary.NonBusinessDaySales += report.SalesAmount
+			summary.NonBusinessDayCost += report.CostAmount
+			summary.NonBusinessDayProfit += report.Profit
+		}
+
+		if !dateMap[report.Date] {
+			dateMap[report.Date] = true
+			if businessDay {
+				summary.BusinessDays++
+			} else {
+				summary.NonBusinessDays++
+			}
+		}
 	}
 
 	summary.Days = len(dateMap)
@@ -250,7 +425,7 @@ func calculateSummary(reports []ProfitReport) Summary {
 		summary.AvgProfitMargin = (summary.TotalProfit / summary.TotalSales) * 100
 	}
 
-	return summary
+	return summary, nil
 }
 
 // printSummary サマリーを表示
@@ -263,6 +438,9 @@ func printSummary(summary Summary) {
 	fmt.Printf("平均粗利率: %.1f%%\n", summary.AvgProfitMargin)
 	fmt.Printf("総売上数量: %d\n", summary.TotalSalesQty)
 	fmt.Printf("総原価数量: %d\n", summary.TotalCostQty)
+	if summary.InactiveSales != 0 || summary.InactiveCost != 0 {
+		fmt.Printf("無効科目: 売上 %s / 原価 %s (上記の合計に含む)\n", formatCurrency(summary.InactiveSales), formatCurrency(summary.InactiveCost))
+	}
 	fmt.Println()
 
 	// 日別平均
@@ -273,6 +451,22 @@ func printSummary(summary Summary) {
 		fmt.Printf("平均日粗利: %s\n", formatCurrency(summary.TotalProfit/float64(summary.Days)))
 		fmt.Printf("平均日売上数量: %.0f\n", float64(summary.TotalSalesQty)/float64(summary.Days))
 		fmt.Printf("平均日原価数量: %.0f\n", float64(summary.TotalCostQty)/float64(summary.Days))
+		fmt.Println()
+	}
+
+	// 営業日・休日別平均
+	if summary.BusinessDays > 0 {
+		fmt.Printf("=== 営業日平均 (%d日) ===\n", summary.BusinessDays)
+		fmt.Printf("平均日売上: %s\n", formatCurrency(summary.BusinessDaySales/float64(summary.BusinessDays)))
+		fmt.Printf("平均日原価: %s\n", formatCurrency(summary.BusinessDayCost/float64(summary.BusinessDays)))
+		fmt.Printf("平均日粗利: %s\n", formatCurrency(summary.BusinessDayProfit/float64(summary.BusinessDays)))
+		fmt.Println()
+	}
+	if summary.NonBusinessDays > 0 {
+		fmt.Printf("=== 休日平均 (土日祝 %d日) ===\n", summary.NonBusinessDays)
+		fmt.Printf("平均日売上: %s\n", formatCurrency(summary.NonBusinessDaySales/float64(summary.NonBusinessDays)))
+		fmt.Printf("平均日原価: %s\n", formatCurrency(summary.NonBusinessDayCost/float64(summary.NonBusinessDays)))
+		fmt.Printf("平均日粗利: %s\n", formatCurrency(summary.NonBusinessDayProfit/float64(summary.NonBusinessDays)))
 	}
 }
 
@@ -314,3 +508,32 @@ func truncateString(s string, maxLen int) string {
 	}
 	return s[:maxLen-3] + "..."
 }
+
+// reportPeriod レポートの最初と最後の日付を返す
+func reportPeriod(reports []ProfitReport) (time.Time, time.Time, error) {
+	var start, end time.Time
+	for i, report := range reports {
+		date, err := parseDate(report.Date, reportDateLayouts)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid report date %q: %w", report.Date, err)
+		}
+		if i == 0 || date.Before(start) {
+			start = date
+		}
+		if i == 0 || date.After(end) {
+			end = date
+		}
+	}
+	return start, end, nil
+}
+
+// parseDate 複数の日付形式を順に試して解析する
+func parseDate(s string, layouts []string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range layouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unsupported date format: %s", s)
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+
+	"github.com/taka512/golang/pkg/calendar"
+
+	"profit-trend-display/internal/calculator"
+	"profit-trend-display/internal/database"
+	"profit-trend-display/internal/notification"
+	"profit-trend-display/internal/scheduler"
+)
+
+// runDaemon runs the jobs of the configuration file on their schedules until SIGINT/SIGTERM
+func runDaemon(args []string) {
+	fs := flag.NewFlagSet("daemon", flag.ExitOnError)
+	var (
+		configPath      = fs.String("config", "jobs.yaml", "Job definition file (YAML)")
+		dsn             = fs.String("dsn", defaultDSN, "Database connection string")
+		holidays        = fs.String("holidays", "", "Holiday CSV file to merge into the embedded calendar")
+		fontPath        = fs.String("font", "", "TrueType/OpenType font for Japanese labels in PNG images")
+		runJob          = fs.String("run", "", "Run the named job once and exit")
+		list            = fs.Bool("list", false, "List jobs with their next run time and exit")
+		history         = fs.Int("history", 0, "Show the latest N runs and exit")
+		shutdownTimeout = fs.Duration("shutdown-timeout", 5*time.Minute, "Time to wait for running jobs on shutdown")
+		jobTimeout      = fs.Duration("job-timeout", 30*time.Minute, "Timeout of each job run (0: no limit)")
+		queryTimeout    = fs.Duration("query-timeout", defaultQueryTimeout, "Timeout of each database query (0: no limit)")
+		includeDisabled = fs.Bool("include-disabled", false, "Include reports of disabled account titles")
+	)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "使用方法: profit-trend-display daemon [オプション]")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	config, err := scheduler.LoadConfig(*configPath)
+	if err != nil {
+		log.Fatalf("ジョブ定義読み込みエラー: %v", err)
+	}
+
+	runHistory, err := scheduler.NewHistory(config.HistoryFile, config.HistorySize)
+	if err != nil {
+		log.Fatalf("実行履歴読み込みエラー: %v", err)
+	}
+
+	if *history > 0 {
+		printRunHistory(runHistory.Recent(*history))
+		return
+	}
+
+	if *list {
+		sched, err := scheduler.NewScheduler(config, nil, runHistory)
+		if err != nil {
+			log.Fatalf("スケジュール登録エラー: %v", err)
+		}
+		printJobs(sched, runHistory)
+		return
+	}
+
+	cal, err := calendar.NewCalendar()
+	if err != nil {
+		log.Fatalf("祝日カレンダー読み込みエラー: %v", err)
+	}
+	if *holidays != "" {
+		if err := cal.LoadFile(*holidays); err != nil {
+			log.Fatalf("祝日ファイル読み込みエラー: %v", err)
+		}
+	}
+	// Job ranges are relative to each run, so only the current year is checked at startup
+	warnCalendarRange(cal, time.Now(), time.Now())
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	repo, err := database.NewProfitRepository(ctx, *dsn, *queryTimeout, *includeDisabled)
+	if err != nil {
+		log.Fatalf("データベース接続エラー: %v", err)
+	}
+	defer repo.Close()
+
+	var slackNotifier *notification.SlackNotifier
+	if slackHookURL := os.Getenv("SLACK_HOOK"); slackHookURL != "" {
+		slackNotifier = notification.NewSlackNotifier(slackHookURL)
+		botToken, channelID := os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_CHANNEL_ID")
+		if botToken != "" && channelID != "" {
+			slackNotifier.EnableFileUpload(botToken, channelID)
+		}
+	}
+
+	runner := scheduler.NewRunner(repo, calculator.NewProfitCalculator(cal), slackNotifier, *fontPath)
+	sched, err := scheduler.NewScheduler(config, runner, runHistory)
+	if err != nil {
+		log.Fatalf("スケジュール登録エラー: %v", err)
+	}
+	sched.SetJobTimeout(*jobTimeout)
+
+	if *runJob != "" {
+		run, err := sched.RunNow(ctx, *runJob)
+		if err != nil {
+			log.Fatalf("ジョブ実行エラー: %v", err)
+		}
+		if run.Status != scheduler.StatusSucceeded {
+			os.Exit(1)
+		}
+		return
+	}
+
+	sched.Start()
+	log.Printf("スケジューラを起動しました (ジョブ数: %d, 接続先: %s)", len(config.Jobs), maskPassword(*dsn))
+	printJobs(sched, runHistory)
+
+	<-ctx.Done()
+	log.Println("停止シグナルを受信しました。実行中のジョブの完了を待機します...")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+	defer cancel()
+	if err := sched.Stop(shutdownCtx); err != nil {
+		log.Printf("スケジューラ停止エラー: %v", err)
+		return
+	}
+	log.Println("スケジューラを停止しました")
+}
+
+func printJobs(sched *scheduler.Scheduler, history *scheduler.History) {
+	next := sched.NextRuns()
+	fmt.Println(runewidth.FillRight("ジョブ", 21) + runewidth.FillRight("スケジュール", 19) +
+		runewidth.FillRight("タイムゾーン", 17) + runewidth.FillRight("出力先", 11) +
+		runewidth.FillRight("次回実行", 21) + "前回結果")
+	for _, job := range sched.Jobs() {
+		last := "-"
+		if run, ok := history.LastRun(job.Name); ok {
+			last = fmt.Sprintf("%s (%s)", run.Status, run.StartedAt.In(job.Location()).Format("2006-01-02 15:04"))
+		}
+		fmt.Printf("%-20s %-18s %-16s %-10s %-20s %s\n",
+			job.Name, job.Schedule, job.Location(), job.Destination.Type,
+			next[job.Name].In(job.Location()).Format("2006-01-02 15:04"), last)
+	}
+}
+
+func printRunHistory(runs []scheduler.Run) {
+	if len(runs) == 0 {
+		fmt.Println("実行履歴はありません")
+		return
+	}
+	for _, run := range runs {
+		line := fmt.Sprintf("%s %-20s %-9s %8s",
+			run.StartedAt.Format("2006-01-02 15:04:05"), run.Job, run.Status, run.Duration().Round(time.Millisecond))
+		if run.StartDate != "" {
+			line += fmt.Sprintf(" 期間: %s - %s 対象組織数: %d", run.StartDate, run.EndDate, run.Trends)
+		}
+		if len(run.Outputs) > 0 {
+			line += " 出力: " + strings.Join(run.Outputs, ", ")
+		}
+		if run.Error != "" {
+			line += " エラー: " + run.Error
+		}
+		fmt.Println(line)
+	}
+}
@@ -4,6 +4,34 @@ go 1.21.0
 
 toolchain go1.24.5
 
-require github.com/go-sql-driver/mysql v1.9.3
+require (
+	github.com/charmbracelet/bubbletea v1.3.4
+	github.com/go-sql-driver/mysql v1.9.3
+	github.com/mattn/go-runewidth v0.0.16
+	github.com/robfig/cron/v3 v3.0.1
+	github.com/taka512/golang/pkg/calendar v0.0.0-00010101000000-000000000000
+	golang.org/x/image v0.18.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
-require filippo.io/edwards25519 v1.1.0 // indirect
+require (
+	filippo.io/edwards25519 v1.1.0 // indirect
+	github.com/aymanbagabas/go-osc52/v2 v2.0.1 // indirect
+	github.com/charmbracelet/lipgloss v1.0.0 // indirect
+	github.com/charmbracelet/x/ansi v0.8.0 // indirect
+	github.com/charmbracelet/x/term v0.2.1 // indirect
+	github.com/erikgeiser/coninput v0.0.0-20211004153227-1c3628e74d0f // indirect
+	github.com/lucasb-eyer/go-colorful v1.2.0 // indirect
+	github.com/mattn/go-isatty v0.0.20 // indirect
+	github.com/mattn/go-localereader v0.0.1 // indirect
+	github.com/muesli/ansi v0.0.0-20230316100256-276c6243b2f6 // indirect
+	github.com/muesli/cancelreader v0.2.2 // indirect
+	github.com/muesli/termenv v0.15.2 // indirect
+	github.com/rivo/uniseg v0.4.7 // indirect
+	golang.org/x/sync v0.11.0 // indirect
+	golang.org/x/sys v0.30.0 // indirect
+	golang.org/x/text v0.16.0 // indirect
+)
+
+// 祝日カレンダーはリポジトリ内で共有する
+replace github.com/taka512/golang/pkg/calendar => ../../pkg/calendar
@@ -5,15 +5,30 @@ import (
 	"sort"
 	"time"
 
+	"github.com/taka512/golang/pkg/calendar"
+
 	"profit-trend-display/internal/models"
 )
 
 // ProfitCalculator handles profit calculation and trend analysis
-type ProfitCalculator struct{}
+type ProfitCalculator struct {
+	calendar *calendar.Calendar
+}
 
-// NewProfitCalculator creates a new profit calculator
-func NewProfitCalculator() *ProfitCalculator {
-	return &ProfitCalculator{}
+// NewProfitCalculator creates a new profit calculator.
+// A nil calendar treats only weekends as non-business days.
+func NewProfitCalculator(cal *calendar.Calendar) *ProfitCalculator {
+	return &ProfitCalculator{calendar: cal}
+}
+
+// BuildTrends groups raw profit data, fills missing dates in the period
+// and converts the result into profit trends with statistics
+func (c *ProfitCalculator) BuildTrends(data []models.ProfitData, startDate, endDate time.Time) []models.ProfitTrend {
+	groupedData := c.GroupByCompanyWarehouse(data)
+	for key, group := range groupedData {
+		groupedData[key] = c.FillMissingDates(group, startDate, endDate)
+	}
+	return c.CreateProfitTrends(groupedData)
 }
 
 // GroupByCompanyWarehouse groups profit data by company and warehouse combination
@@ -44,6 +59,8 @@ func (c *ProfitCalculator) CreateProfitTrends(groupedData map[string][]models.Pr
 			continue
 		}
 
+		c.AnnotateBusinessDays(data)
+
 		trend := models.ProfitTrend{
 			CompanyID:       data[0].CompanyID,
 			CompanyName:     data[0].CompanyName,
@@ -81,11 +98,19 @@ func (c *ProfitCalculator) calculateStats(data []models.ProfitData) models.Profi
 		DaysCount: len(data),
 	}
 
-	var totalProfit float64
+	var totalProfit, businessProfit, nonBusinessProfit float64
 
 	for _, item := range data {
 		totalProfit += item.ProfitAmount
 
+		if item.IsBusinessDay {
+			businessProfit += item.ProfitAmount
+			stats.BusinessDays++
+		} else {
+			nonBusinessProfit += item.ProfitAmount
+			stats.NonBusinessDays++
+		}
+
 		if item.ProfitAmount > stats.MaxProfit {
 			stats.MaxProfit = item.ProfitAmount
 			stats.MaxDate = item.TargetDate
@@ -100,9 +125,29 @@ func (c *ProfitCalculator) calculateStats(data []models.ProfitData) models.Profi
 	stats.TotalProfit = totalProfit
 	stats.AvgProfit = totalProfit / float64(len(data))
 
+	if stats.BusinessDays > 0 {
+		stats.BusinessDayAvgProfit = businessProfit / float64(stats.BusinessDays)
+	}
+	if stats.NonBusinessDays > 0 {
+		stats.NonBusinessDayAvgProfit = nonBusinessProfit / float64(stats.NonBusinessDays)
+	}
+
 	return stats
 }
 
+// AnnotateBusinessDays marks each entry as a business day or not and records holiday names
+func (c *ProfitCalculator) AnnotateBusinessDays(data []models.ProfitData) {
+	for i := range data {
+		data[i].IsBusinessDay = c.IsBusinessDay(data[i].TargetDate)
+		data[i].HolidayName, _ = c.calendar.HolidayName(data[i].TargetDate)
+	}
+}
+
+// IsBusinessDay reports whether the date is a business day according to the calendar
+func (c *ProfitCalculator) IsBusinessDay(date time.Time) bool {
+	return c.calendar.IsBusinessDay(date)
+}
+
 // FillMissingDates fills in missing dates with zero profit for complete trend visualization
 func (c *ProfitCalculator) FillMissingDates(data []models.ProfitData, startDate, endDate time.Time) []models.ProfitData {
 	if len(data) == 0 {
@@ -53,6 +53,7 @@ func (c *TextChart) RenderProfitTrend(trend models.ProfitTrend) string {
 	// Statistics
 	if c.config.ShowStats {
 		result.WriteString(c.renderStats(trend.Stats))
+		result.WriteString(c.renderHolidays(trend.Data))
 	}
 
 	result.WriteString("\n")
@@ -246,7 +247,55 @@ func (c *TextChart) renderDateAxis(data []models.ProfitData) string {
 
 	dateLabels := string(dateLabelLine)
 
-	return string(axisLine) + "\n" + dateLabels
+	result := string(axisLine) + "\n" + dateLabels
+	if holidayLine, ok := c.renderHolidayMarkers(data); ok {
+		result += "\n" + holidayLine
+	}
+
+	return result
+}
+
+// renderHolidayMarkers marks the X position of each national holiday with '*'
+func (c *TextChart) renderHolidayMarkers(data []models.ProfitData) (string, bool) {
+	markerLine := make([]rune, c.config.Width+9)
+	for i := range markerLine {
+		markerLine[i] = ' '
+	}
+
+	found := false
+	for i, item := range data {
+		if item.HolidayName == "" {
+			continue
+		}
+
+		var xPos int
+		if len(data) > 1 {
+			xPos = int(float64(i) / float64(len(data)-1) * float64(c.config.Width-1))
+		} else {
+			xPos = c.config.Width / 2
+		}
+
+		if xPos+9 < len(markerLine) {
+			markerLine[xPos+9] = '*'
+			found = true
+		}
+	}
+
+	return strings.TrimRight(string(markerLine), " "), found
+}
+
+// renderHolidays lists the holidays contained in the data
+func (c *TextChart) renderHolidays(data []models.ProfitData) string {
+	var holidays []string
+	for _, item := range data {
+		if item.HolidayName != "" {
+			holidays = append(holidays, fmt.Sprintf("%s %s", item.TargetDate.Format("01/02"), item.HolidayName))
+		}
+	}
+	if len(holidays) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("  祝日(*): %s\n", strings.Join(holidays, ", "))
 }
 
 // renderStats creates a statistics summary
@@ -259,6 +308,8 @@ func (c *TextChart) renderStats(stats models.ProfitStats) string {
 	result.WriteString(fmt.Sprintf("  平均粗利: %10.0f\n", stats.AvgProfit))
 	result.WriteString(fmt.Sprintf("  合計粗利: %10.0f\n", stats.TotalProfit))
 	result.WriteString(fmt.Sprintf("  データ日数: %d日\n", stats.DaysCount))
+	result.WriteString(fmt.Sprintf("  営業日平均粗利: %10.0f (%d日)\n", stats.BusinessDayAvgProfit, stats.BusinessDays))
+	result.WriteString(fmt.Sprintf("  休日平均粗利: %10.0f (%d日)\n", stats.NonBusinessDayAvgProfit, stats.NonBusinessDays))
 
 	return result.String()
 }
@@ -278,6 +329,8 @@ func (c *TextChart) RenderSummary(trends []models.ProfitTrend) string {
 	var totalProfit, maxProfit, minProfit float64
 	var maxDate, minDate time.Time
 	totalDays := 0
+	var businessProfit, nonBusinessProfit float64
+	businessDays, nonBusinessDays := 0, 0
 	
 	for i, trend := range trends {
 		if i == 0 {
@@ -298,6 +351,10 @@ func (c *TextChart) RenderSummary(trends []models.ProfitTrend) string {
 		
 		totalProfit += trend.Stats.TotalProfit
 		totalDays += trend.Stats.DaysCount
+		businessProfit += trend.Stats.BusinessDayAvgProfit * float64(trend.Stats.BusinessDays)
+		nonBusinessProfit += trend.Stats.NonBusinessDayAvgProfit * float64(trend.Stats.NonBusinessDays)
+		businessDays += trend.Stats.BusinessDays
+		nonBusinessDays += trend.Stats.NonBusinessDays
 	}
 
 	avgProfit := 0.0
@@ -308,6 +365,12 @@ func (c *TextChart) RenderSummary(trends []models.ProfitTrend) string {
 	result.WriteString("全体統計:\n")
 	result.WriteString(fmt.Sprintf("  合計粗利: %10.0f\n", totalProfit))
 	result.WriteString(fmt.Sprintf("  平均粗利: %10.0f\n", avgProfit))
+	if businessDays > 0 {
+		result.WriteString(fmt.Sprintf("  営業日平均粗利: %10.0f\n", businessProfit/float64(businessDays)))
+	}
+	if nonBusinessDays > 0 {
+		result.WriteString(fmt.Sprintf("  休日平均粗利: %10.0f\n", nonBusinessProfit/float64(nonBusinessDays)))
+	}
 	result.WriteString(fmt.Sprintf("  最大粗利: %10.0f (%s)\n", maxProfit, maxDate.Format("01/02")))
 	result.WriteString(fmt.Sprintf("  最小粗利: %10.0f (%s)\n", minProfit, minDate.Format("01/02")))
 	result.WriteString(fmt.Sprintf("  対象組織数: %d\n", len(trends)))
@@ -316,9 +379,10 @@ func (c *TextChart) RenderSummary(trends []models.ProfitTrend) string {
 	// Individual trend summaries
 	result.WriteString("組織別サマリー:\n")
 	for _, trend := range trends {
-		result.WriteString(fmt.Sprintf("  %s - %s: 合計=%.0f, 平均=%.0f\n",
+		result.WriteString(fmt.Sprintf("  %s - %s: 合計=%.0f, 平均=%.0f, 営業日平均=%.0f, 休日平均=%.0f\n",
 			trend.CompanyName, trend.WarehouseName,
-			trend.Stats.TotalProfit, trend.Stats.AvgProfit))
+			trend.Stats.TotalProfit, trend.Stats.AvgProfit,
+			trend.Stats.BusinessDayAvgProfit, trend.Stats.NonBusinessDayAvgProfit))
 	}
 
 	return result.String()
@@ -0,0 +1,458 @@
+package chart
+
+import (
+	"bytes"
+	"fmt"
+	"image/color"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/image/font"
+
+	"profit-trend-display/internal/models"
+)
+
+// ImageFormat identifies the output format of an image chart
+type ImageFormat string
+
+const (
+	FormatPNG ImageFormat = "png"
+	FormatSVG ImageFormat = "svg"
+)
+
+const (
+	defaultImageWidth  = 960
+	defaultImageHeight = 480
+	yTicks             = 5
+)
+
+var (
+	colorBackground = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	colorText       = color.RGBA{0x33, 0x33, 0x33, 0xff}
+	colorAxis       = color.RGBA{0x99, 0x99, 0x99, 0xff}
+	colorGrid       = color.RGBA{0xe6, 0xe6, 0xe6, 0xff}
+	colorHoliday    = color.RGBA{0xf2, 0xf2, 0xf2, 0xff}
+	colorSales      = color.RGBA{0x2e, 0x86, 0xde, 0xff}
+	colorCost       = color.RGBA{0xe6, 0x7e, 0x22, 0xff}
+	colorProfit     = color.RGBA{0x27, 0xae, 0x60, 0xff}
+	colorLoss       = color.RGBA{0xc0, 0x39, 0x2b, 0xff}
+)
+
+// textAnchor is the horizontal alignment of a text label
+type textAnchor int
+
+const (
+	anchorStart textAnchor = iota
+	anchorMiddle
+	anchorEnd
+)
+
+// canvas is the drawing surface shared by the PNG and SVG backends
+type canvas interface {
+	rect(x, y, w, h float64, c color.RGBA)
+	line(x1, y1, x2, y2, width float64, c color.RGBA)
+	text(x, y float64, s string, anchor textAnchor, c color.RGBA)
+	// measure returns the rendered width of s in pixels
+	measure(s string) float64
+	// canRender reports whether every rune of s can be drawn with the current font
+	canRender(s string) bool
+}
+
+// ImageRenderer renders profit trends as PNG or SVG images
+type ImageRenderer struct {
+	config models.ChartConfig
+	face   font.Face
+}
+
+// NewImageRenderer creates an image chart renderer.
+// When config.FontPath is set the font is loaded for PNG labels;
+// otherwise PNG output falls back to a built-in ASCII font.
+func NewImageRenderer(config models.ChartConfig) (*ImageRenderer, error) {
+	if config.ImageWidth == 0 {
+		config.ImageWidth = defaultImageWidth
+	}
+	if config.ImageHeight == 0 {
+		config.ImageHeight = defaultImageHeight
+	}
+	if config.Type == "" {
+		config.Type = models.ChartLine
+	}
+
+	switch config.Type {
+	case models.ChartLine, models.ChartBar, models.ChartStacked:
+	default:
+		return nil, fmt.Errorf("unsupported chart type: %s", config.Type)
+	}
+
+	r := &ImageRenderer{config: config}
+	if config.FontPath != "" {
+		face, err := loadFontFace(config.FontPath)
+		if err != nil {
+			return nil, err
+		}
+		r.face = face
+	}
+	return r, nil
+}
+
+// ParseImageFormat converts a format name or file extension into an ImageFormat
+func ParseImageFormat(s string) (ImageFormat, error) {
+	switch strings.ToLower(strings.TrimPrefix(s, ".")) {
+	case "png":
+		return FormatPNG, nil
+	case "svg":
+		return FormatSVG, nil
+	}
+	return "", fmt.Errorf("unsupported image format: %s", s)
+}
+
+// Render writes the trend chart to w in the given format
+func (r *ImageRenderer) Render(w io.Writer, trend models.ProfitTrend, format ImageFormat) error {
+	switch format {
+	case FormatPNG:
+		c := newPNGCanvas(r.config.ImageWidth, r.config.ImageHeight, r.face)
+		r.draw(c, trend)
+		return c.encode(w)
+	case FormatSVG:
+		c := newSVGCanvas(r.config.ImageWidth, r.config.ImageHeight)
+		r.draw(c, trend)
+		return c.encode(w)
+	}
+	return fmt.Errorf("unsupported image format: %s", format)
+}
+
+// WriteFile renders the trend to path, choosing the format from the file extension
+func (r *ImageRenderer) WriteFile(path string, trend models.ProfitTrend) error {
+	format, err := ParseImageFormat(filepath.Ext(path))
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := r.Render(&buf, trend, format); err != nil {
+		return fmt.Errorf("failed to render chart: %w", err)
+	}
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("failed to write chart file: %w", err)
+	}
+	return nil
+}
+
+// WriteFiles renders one file per trend into dir and returns the written paths
+func (r *ImageRenderer) WriteFiles(dir string, trends []models.ProfitTrend, format ImageFormat) ([]string, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create chart directory: %w", err)
+	}
+
+	paths := make([]string, 0, len(trends))
+	for _, trend := range trends {
+		path := filepath.Join(dir, r.FileName(trend, format))
+		if err := r.WriteFile(path, trend); err != nil {
+			return paths, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+// FileName returns a file name for the trend that is safe on every platform
+func (r *ImageRenderer) FileName(trend models.ProfitTrend, format ImageFormat) string {
+	name := fmt.Sprintf("profit_%s_c%d_w%d", r.config.Type, trend.CompanyID, trend.WarehouseBaseID)
+	if len(trend.Data) > 0 {
+		name += "_" + trend.Data[0].TargetDate.Format("20060102") +
+			"-" + trend.Data[len(trend.Data)-1].TargetDate.Format("20060102")
+	}
+	return name + "." + string(format)
+}
+
+// plotArea maps data coordinates onto the canvas
+type plotArea struct {
+	left, top, width, height float64
+	lo, hi                   float64
+	count                    int
+}
+
+func (p plotArea) step() float64 {
+	return p.width / math.Max(float64(p.count), 1)
+}
+
+// x returns the horizontal center of the i-th day
+func (p plotArea) x(i int) float64 {
+	return p.left + p.step()*float64(i) + p.step()/2
+}
+
+func (p plotArea) y(v float64) float64 {
+	return p.top + (p.hi-v)/(p.hi-p.lo)*p.height
+}
+
+// draw renders the whole chart onto the canvas
+func (r *ImageRenderer) draw(c canvas, trend models.ProfitTrend) {
+	width, height := float64(r.config.ImageWidth), float64(r.config.ImageHeight)
+	c.rect(0, 0, width, height, colorBackground)
+
+	c.text(16, 24, r.title(c, trend), anchorStart, colorText)
+	if len(trend.Data) == 0 {
+		c.text(width/2, height/2, label(c, "データがありません", "No data"), anchorMiddle, colorText)
+		return
+	}
+
+	bottom := 56.0
+	if r.config.ShowStats {
+		bottom += 20
+	}
+	lo, hi := r.valueRange(trend)
+	area := plotArea{
+		left:   88,
+		top:    56,
+		width:  width - 88 - 24,
+		height: height - 56 - bottom,
+		lo:     lo,
+		hi:     hi,
+		count:  len(trend.Data),
+	}
+
+	r.drawBackground(c, area, trend)
+	switch r.config.Type {
+	case models.ChartBar:
+		r.drawProfitBars(c, area, trend)
+	case models.ChartStacked:
+		r.drawStackedBars(c, area, trend)
+	default:
+		r.drawLines(c, area, trend)
+	}
+	c.line(area.left, area.y(0), area.left+area.width, area.y(0), 1, colorAxis)
+
+	r.drawLegend(c, area)
+	if r.config.ShowStats {
+		c.text(16, height-16, r.statsLine(c, trend), anchorStart, colorText)
+	}
+}
+
+func (r *ImageRenderer) title(c canvas, trend models.ProfitTrend) string {
+	period := ""
+	if len(trend.Data) > 0 {
+		period = fmt.Sprintf(" (%s ~ %s)",
+			trend.Data[0].TargetDate.Format("2006-01-02"),
+			trend.Data[len(trend.Data)-1].TargetDate.Format("2006-01-02"))
+	}
+
+	ja := fmt.Sprintf("%s - %s %s", trend.CompanyName, trend.WarehouseName, chartTitle(r.config.Type))
+	if c.canRender(ja) {
+		return ja + period
+	}
+	return fmt.Sprintf("Company %d - Warehouse %d%s", trend.CompanyID, trend.WarehouseBaseID, period)
+}
+
+func chartTitle(t models.ChartType) string {
+	switch t {
+	case models.ChartBar:
+		return "日別粗利"
+	case models.ChartStacked:
+		return "売上・原価内訳"
+	default:
+		return "売上・原価・粗利推移"
+	}
+}
+
+// valueRange returns the vertical range, honoring a fixed MinValue/MaxValue
+func (r *ImageRenderer) valueRange(trend models.ProfitTrend) (float64, float64) {
+	if r.config.MinValue != 0 || r.config.MaxValue != 0 {
+		if r.config.MaxValue > r.config.MinValue {
+			return r.config.MinValue, r.config.MaxValue
+		}
+	}
+
+	lo, hi := 0.0, 0.0
+	for _, item := range trend.Data {
+		var values []float64
+		switch r.config.Type {
+		case models.ChartBar:
+			values = []float64{item.ProfitAmount}
+		case models.ChartStacked:
+			values = []float64{item.SalesAmount, item.CostAmount}
+		default:
+			values = []float64{item.SalesAmount, item.CostAmount, item.ProfitAmount}
+		}
+		for _, v := range values {
+			lo = math.Min(lo, v)
+			hi = math.Max(hi, v)
+		}
+	}
+	if hi == lo {
+		hi = lo + 1
+	}
+	step := niceStep((hi - lo) / yTicks)
+	return math.Floor(lo/step) * step, math.Ceil(hi/step) * step
+}
+
+// niceStep rounds a raw tick interval up to 1, 2 or 5 times a power of ten
+func niceStep(raw float64) float64 {
+	if raw <= 0 {
+		return 1
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, m := range []float64{1, 2, 5, 10} {
+		if raw <= m*magnitude {
+			return m * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+// drawBackground shades non-business days and draws grid lines and axis labels
+func (r *ImageRenderer) drawBackground(c canvas, area plotArea, trend models.ProfitTrend) {
+	for i, item := range trend.Data {
+		if !item.IsBusinessDay {
+			c.rect(area.left+area.step()*float64(i), area.top, area.step(), area.height, colorHoliday)
+		}
+	}
+
+	step := niceStep((area.hi - area.lo) / yTicks)
+	for v := math.Ceil(area.lo/step) * step; v <= area.hi+step/1000; v += step {
+		y := area.y(v)
+		if r.config.ShowGrid {
+			c.line(area.left, y, area.left+area.width, y, 1, colorGrid)
+		}
+		c.text(area.left-8, y+4, formatAxisValue(v), anchorEnd, colorText)
+	}
+
+	c.line(area.left, area.top, area.left, area.top+area.height, 1, colorAxis)
+
+	every := int(math.Ceil(float64(len(trend.Data)) / 10))
+	if every < 1 {
+		every = 1
+	}
+	// Count back from the last day so that the most recent date is always labeled
+	for i, item := range trend.Data {
+		if (len(trend.Data)-1-i)%every == 0 {
+			c.text(area.x(i), area.top+area.height+16, item.TargetDate.Format("01/02"), anchorMiddle, colorText)
+		}
+	}
+}
+
+func (r *ImageRenderer) drawLines(c canvas, area plotArea, trend models.ProfitTrend) {
+	series := []struct {
+		color color.RGBA
+		value func(models.ProfitData) float64
+	}{
+		{colorSales, func(d models.ProfitData) float64 { return d.SalesAmount }},
+		{colorCost, func(d models.ProfitData) float64 { return d.CostAmount }},
+		{colorProfit, func(d models.ProfitData) float64 { return d.ProfitAmount }},
+	}
+
+	for _, s := range series {
+		for i := 1; i < len(trend.Data); i++ {
+			c.line(area.x(i-1), area.y(s.value(trend.Data[i-1])), area.x(i), area.y(s.value(trend.Data[i])), 2, s.color)
+		}
+		for i, item := range trend.Data {
+			x, y := area.x(i), area.y(s.value(item))
+			c.rect(x-2, y-2, 4, 4, s.color)
+		}
+	}
+}
+
+func (r *ImageRenderer) drawProfitBars(c canvas, area plotArea, trend models.ProfitTrend) {
+	barWidth := math.Max(1, area.step()*0.7)
+	for i, item := range trend.Data {
+		col := colorProfit
+		if item.ProfitAmount < 0 {
+			col = colorLoss
+		}
+		bar(c, area.x(i)-barWidth/2, barWidth, area.y(0), area.y(item.ProfitAmount), col)
+	}
+}
+
+// drawStackedBars stacks profit on top of cost so that the bar reaches sales.
+// When cost exceeds sales the uncovered part is drawn as a loss.
+func (r *ImageRenderer) drawStackedBars(c canvas, area plotArea, trend models.ProfitTrend) {
+	barWidth := math.Max(1, area.step()*0.7)
+	for i, item := range trend.Data {
+		x := area.x(i) - barWidth/2
+		covered := math.Min(item.CostAmount, item.SalesAmount)
+		bar(c, x, barWidth, area.y(0), area.y(covered), colorCost)
+		if item.SalesAmount > item.CostAmount {
+			bar(c, x, barWidth, area.y(covered), area.y(item.SalesAmount), colorProfit)
+		} else if item.CostAmount > item.SalesAmount {
+			bar(c, x, barWidth, area.y(covered), area.y(item.CostAmount), colorLoss)
+		}
+	}
+}
+
+// bar draws a vertical bar between two y coordinates
+func bar(c canvas, x, width, y1, y2 float64, col color.RGBA) {
+	top, bottom := math.Min(y1, y2), math.Max(y1, y2)
+	if bottom-top < 0.5 {
+		return
+	}
+	c.rect(x, top, width, bottom-top, col)
+}
+
+func (r *ImageRenderer) drawLegend(c canvas, area plotArea) {
+	type entry struct {
+		color  color.RGBA
+		ja, en string
+	}
+	var entries []entry
+	switch r.config.Type {
+	case models.ChartBar:
+		entries = []entry{{colorProfit, "粗利", "Profit"}, {colorLoss, "損失", "Loss"}}
+	case models.ChartStacked:
+		entries = []entry{{colorCost, "原価", "Cost"}, {colorProfit, "粗利", "Profit"}, {colorLoss, "損失", "Loss"}}
+	default:
+		entries = []entry{{colorSales, "売上", "Sales"}, {colorCost, "原価", "Cost"}, {colorProfit, "粗利", "Profit"}}
+	}
+	entries = append(entries, entry{colorHoliday, "土日・祝日", "Weekend/Holiday"})
+
+	x := area.left
+	for _, e := range entries {
+		text := label(c, e.ja, e.en)
+		c.rect(x, 34, 12, 12, e.color)
+		c.text(x+16, 45, text, anchorStart, colorText)
+		x += 32 + c.measure(text)
+	}
+}
+
+func (r *ImageRenderer) statsLine(c canvas, trend models.ProfitTrend) string {
+	stats := trend.Stats
+	ja := fmt.Sprintf("最大粗利: %s  最小粗利: %s  平均粗利: %s  合計粗利: %s",
+		formatAxisValue(stats.MaxProfit), formatAxisValue(stats.MinProfit),
+		formatAxisValue(stats.AvgProfit), formatAxisValue(stats.TotalProfit))
+	if c.canRender(ja) {
+		return ja
+	}
+	return fmt.Sprintf("Max: %s  Min: %s  Avg: %s  Total: %s",
+		formatAxisValue(stats.MaxProfit), formatAxisValue(stats.MinProfit),
+		formatAxisValue(stats.AvgProfit), formatAxisValue(stats.TotalProfit))
+}
+
+// label returns ja when the canvas can draw it, otherwise the ASCII fallback
+func label(c canvas, ja, en string) string {
+	if c.canRender(ja) {
+		return ja
+	}
+	return en
+}
+
+// formatAxisValue formats a value with thousand separators
+func formatAxisValue(v float64) string {
+	str := fmt.Sprintf("%.0f", math.Round(v))
+	sign := ""
+	if strings.HasPrefix(str, "-") {
+		sign, str = "-", str[1:]
+	}
+
+	var result strings.Builder
+	for i, digit := range str {
+		if i > 0 && (len(str)-i)%3 == 0 {
+			result.WriteString(",")
+		}
+		result.WriteRune(digit)
+	}
+	if result.String() == "0" {
+		sign = ""
+	}
+	return sign + result.String()
+}
@@ -0,0 +1,123 @@
+package chart
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+	"golang.org/x/image/vector"
+)
+
+const pngFontSize = 12
+
+// pngCanvas draws charts onto an RGBA image
+type pngCanvas struct {
+	img  *image.RGBA
+	face font.Face
+}
+
+func newPNGCanvas(width, height int, face font.Face) *pngCanvas {
+	if face == nil {
+		face = basicfont.Face7x13
+	}
+	return &pngCanvas{
+		img:  image.NewRGBA(image.Rect(0, 0, width, height)),
+		face: face,
+	}
+}
+
+func (c *pngCanvas) rect(x, y, w, h float64, col color.RGBA) {
+	r := image.Rect(int(math.Round(x)), int(math.Round(y)), int(math.Round(x+w)), int(math.Round(y+h)))
+	draw.Draw(c.img, r, image.NewUniform(col), image.Point{}, draw.Over)
+}
+
+// line rasterizes an anti-aliased line as a thin quadrilateral
+func (c *pngCanvas) line(x1, y1, x2, y2, width float64, col color.RGBA) {
+	dx, dy := x2-x1, y2-y1
+	length := math.Hypot(dx, dy)
+	if length == 0 {
+		return
+	}
+	nx, ny := -dy/length*width/2, dx/length*width/2
+
+	bounds := c.img.Bounds()
+	z := vector.NewRasterizer(bounds.Dx(), bounds.Dy())
+	z.MoveTo(float32(x1+nx), float32(y1+ny))
+	z.LineTo(float32(x2+nx), float32(y2+ny))
+	z.LineTo(float32(x2-nx), float32(y2-ny))
+	z.LineTo(float32(x1-nx), float32(y1-ny))
+	z.ClosePath()
+	z.Draw(c.img, bounds, image.NewUniform(col), image.Point{})
+}
+
+func (c *pngCanvas) text(x, y float64, s string, anchor textAnchor, col color.RGBA) {
+	switch anchor {
+	case anchorMiddle:
+		x -= c.measure(s) / 2
+	case anchorEnd:
+		x -= c.measure(s)
+	}
+
+	d := &font.Drawer{
+		Dst:  c.img,
+		Src:  image.NewUniform(col),
+		Face: c.face,
+		Dot:  fixed.P(int(math.Round(x)), int(math.Round(y))),
+	}
+	d.DrawString(s)
+}
+
+func (c *pngCanvas) measure(s string) float64 {
+	return float64(font.MeasureString(c.face, s)) / 64
+}
+
+func (c *pngCanvas) canRender(s string) bool {
+	for _, r := range s {
+		if _, ok := c.face.GlyphAdvance(r); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+func (c *pngCanvas) encode(w io.Writer) error {
+	return png.Encode(w, c.img)
+}
+
+// loadFontFace loads a TrueType/OpenType font or the first font of a collection (.ttc)
+func loadFontFace(path string) (font.Face, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read font file: %w", err)
+	}
+
+	var f *opentype.Font
+	if strings.EqualFold(filepath.Ext(path), ".ttc") {
+		collection, err := opentype.ParseCollection(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse font collection %s: %w", path, err)
+		}
+		if f, err = collection.Font(0); err != nil {
+			return nil, fmt.Errorf("failed to load font from collection %s: %w", path, err)
+		}
+	} else if f, err = opentype.Parse(data); err != nil {
+		return nil, fmt.Errorf("failed to parse font %s: %w", path, err)
+	}
+
+	face, err := opentype.NewFace(f, &opentype.FaceOptions{Size: pngFontSize, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create font face: %w", err)
+	}
+	return face, nil
+}
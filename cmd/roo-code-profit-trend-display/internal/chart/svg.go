@@ -0,0 +1,64 @@
+package chart
+
+import (
+	"fmt"
+	"image/color"
+	"io"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+)
+
+const svgFontSize = 12
+
+// svgCanvas draws charts as SVG elements
+type svgCanvas struct {
+	width, height int
+	body          strings.Builder
+}
+
+func newSVGCanvas(width, height int) *svgCanvas {
+	return &svgCanvas{width: width, height: height}
+}
+
+func (c *svgCanvas) rect(x, y, w, h float64, col color.RGBA) {
+	fmt.Fprintf(&c.body, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"/>`+"\n",
+		x, y, w, h, svgColor(col))
+}
+
+func (c *svgCanvas) line(x1, y1, x2, y2, width float64, col color.RGBA) {
+	fmt.Fprintf(&c.body, `<line x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="%.1f" stroke-linecap="round"/>`+"\n",
+		x1, y1, x2, y2, svgColor(col), width)
+}
+
+func (c *svgCanvas) text(x, y float64, s string, anchor textAnchor, col color.RGBA) {
+	anchors := map[textAnchor]string{anchorStart: "start", anchorMiddle: "middle", anchorEnd: "end"}
+	fmt.Fprintf(&c.body, `<text x="%.1f" y="%.1f" text-anchor="%s" fill="%s">%s</text>`+"\n",
+		x, y, anchors[anchor], svgColor(col), escapeXML(s))
+}
+
+// measure estimates the width from the display width of the string
+func (c *svgCanvas) measure(s string) float64 {
+	return float64(runewidth.StringWidth(s)) * svgFontSize * 0.55
+}
+
+// canRender is always true because the viewer supplies the fonts
+func (c *svgCanvas) canRender(string) bool {
+	return true
+}
+
+func (c *svgCanvas) encode(w io.Writer) error {
+	_, err := fmt.Fprintf(w, `<?xml version="1.0" encoding="UTF-8"?>
+<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="'Hiragino Sans','Noto Sans JP','Yu Gothic',sans-serif" font-size="%d">
+%s</svg>
+`, c.width, c.height, c.width, c.height, svgFontSize, c.body.String())
+	return err
+}
+
+func svgColor(col color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", col.R, col.G, col.B)
+}
+
+func escapeXML(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "'", "&apos;").Replace(s)
+}
@@ -1,6 +1,7 @@
 package database
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"time"
@@ -12,21 +13,47 @@ import (
 
 // ProfitRepository handles database operations for profit data
 type ProfitRepository struct {
-	db *sql.DB
+	db           *sql.DB
+	queryTimeout time.Duration
+	// includeDisabled also reads reports of disabled account titles
+	includeDisabled bool
 }
 
-// NewProfitRepository creates a new profit repository
-func NewProfitRepository(dsn string) (*ProfitRepository, error) {
+// NewProfitRepository creates a new profit repository; every query including the
+// initial ping is cancelled after queryTimeout (no limit when zero).
+// Reports of disabled account titles are excluded unless includeDisabled is set.
+func NewProfitRepository(ctx context.Context, dsn string, queryTimeout time.Duration, includeDisabled bool) (*ProfitRepository, error) {
 	db, err := sql.Open("mysql", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("failed to open database: %w", err)
 	}
 
-	if err := db.Ping(); err != nil {
+	r := &ProfitRepository{db: db, queryTimeout: queryTimeout, includeDisabled: includeDisabled}
+	pingCtx, cancel := r.queryContext(ctx)
+	defer cancel()
+	if err := db.PingContext(pingCtx); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("failed to ping database: %w", err)
 	}
 
-	return &ProfitRepository{db: db}, nil
+	return r, nil
+}
+
+// queryContext derives the context of a single query from ctx
+func (r *ProfitRepository) queryContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.queryTimeout > 0 {
+		return context.WithTimeout(ctx, r.queryTimeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// activeTitleCondition returns the join condition that drops reports of disabled
+// account titles ("" when disabled titles are included)
+func (r *ProfitRepository) activeTitleCondition(alias, titleColumn, titleTable string) string {
+	if r.includeDisabled {
+		return ""
+	}
+	return " AND " + alias + "." + titleColumn + " IN (SELECT id FROM " + titleTable + " WHERE disabled = 0)"
 }
 
 // Close closes the database connection
@@ -34,8 +61,9 @@ func (r *ProfitRepository) Close() error {
 	return r.db.Close()
 }
 
-// GetProfitTrendsForPeriod retrieves profit data for the specified period
-func (r *ProfitRepository) GetProfitTrendsForPeriod(startDate, endDate time.Time) ([]models.ProfitData, error) {
+// GetProfitTrendsForPeriod retrieves profit data for the specified period.
+// Only company/warehouse pairs with a contract overlapping the period are included.
+func (r *ProfitRepository) GetProfitTrendsForPeriod(ctx context.Context, startDate, endDate time.Time) ([]models.ProfitData, error) {
 	query := `
 		SELECT 
 			c.id as company_id,
@@ -45,22 +73,30 @@ func (r *ProfitRepository) GetProfitTrendsForPeriod(startDate, endDate time.Time
 			DATE(COALESCE(sdr.target_date, cdr.target_date)) as target_date,
 			COALESCE(SUM(sdri.amount), 0) as sales_amount,
 			COALESCE(SUM(cdri.cost_amount), 0) as cost_amount
-		FROM companies c
-		CROSS JOIN warehouse_bases wb
+		FROM (
+			SELECT DISTINCT company_id, warehouse_base_id
+			FROM company_warehouse_contracts
+			WHERE start_date <= ? AND (end_date IS NULL OR end_date >= ?)
+		) cwc
+		JOIN companies c ON c.id = cwc.company_id
+		JOIN warehouse_bases wb ON wb.id = cwc.warehouse_base_id
 		LEFT JOIN sales_daily_reports sdr ON c.id = sdr.company_id 
 			AND wb.id = sdr.warehouse_base_id 
-			AND sdr.target_date BETWEEN ? AND ?
+			AND sdr.target_date BETWEEN ? AND ?` + r.activeTitleCondition("sdr", "sales_account_title_id", "sales_account_titles") + `
 		LEFT JOIN sales_daily_report_items sdri ON sdr.id = sdri.sales_daily_report_id
 		LEFT JOIN cost_daily_reports cdr ON c.id = cdr.company_id 
 			AND wb.id = cdr.warehouse_base_id 
-			AND cdr.target_date BETWEEN ? AND ?
+			AND cdr.target_date BETWEEN ? AND ?` + r.activeTitleCondition("cdr", "cost_account_title_id", "cost_account_titles") + `
 		LEFT JOIN cost_daily_report_items cdri ON cdr.id = cdri.cost_daily_report_id
 		WHERE (sdr.target_date IS NOT NULL OR cdr.target_date IS NOT NULL)
 		GROUP BY c.id, c.name, wb.id, wb.name, DATE(COALESCE(sdr.target_date, cdr.target_date))
 		ORDER BY c.name, wb.name, DATE(COALESCE(sdr.target_date, cdr.target_date))
 	`
 
-	rows, err := r.db.Query(query, startDate, endDate, startDate, endDate)
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, endDate, startDate, startDate, endDate, startDate, endDate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
@@ -101,20 +137,105 @@ func (r *ProfitRepository) GetProfitTrendsForPeriod(startDate, endDate time.Time
 	return profitData, nil
 }
 
-// GetCompaniesWithWarehouses retrieves all companies with their warehouse bases
-func (r *ProfitRepository) GetCompaniesWithWarehouses() (map[string][]models.ProfitData, error) {
+// GetDailyDetail retrieves account-title level sales and cost items
+// for a company-warehouse combination on the specified date
+func (r *ProfitRepository) GetDailyDetail(ctx context.Context, companyID, warehouseBaseID int, date time.Time) ([]models.DailyDetail, error) {
+	query := `
+		SELECT 
+			'sales' as kind,
+			sat.code,
+			sat.name,
+			sdri.size,
+			sdri.quantity,
+			sdri.price,
+			sdri.amount
+		FROM sales_daily_reports sdr
+		JOIN sales_account_titles sat ON sdr.sales_account_title_id = sat.id
+		JOIN sales_daily_report_items sdri ON sdr.id = sdri.sales_daily_report_id
+		WHERE sdr.company_id = ?
+			AND sdr.warehouse_base_id = ?
+			AND sdr.target_date = ?` + r.activeTitleCondition("sdr", "sales_account_title_id", "sales_account_titles") + `
+		UNION ALL
+		SELECT 
+			'cost' as kind,
+			cat.code,
+			cat.name,
+			cdri.size,
+			cdri.quantity,
+			cdri.cost_price,
+			cdri.cost_amount
+		FROM cost_daily_reports cdr
+		JOIN cost_account_titles cat ON cdr.cost_account_title_id = cat.id
+		JOIN cost_daily_report_items cdri ON cdr.id = cdri.cost_daily_report_id
+		WHERE cdr.company_id = ?
+			AND cdr.warehouse_base_id = ?
+			AND cdr.target_date = ?` + r.activeTitleCondition("cdr", "cost_account_title_id", "cost_account_titles") + `
+		ORDER BY kind DESC, code, size
+	`
+
+	day := date.Format("2006-01-02")
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query, companyID, warehouseBaseID, day, companyID, warehouseBaseID, day)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute query: %w", err)
+	}
+	defer rows.Close()
+
+	var details []models.DailyDetail
+	for rows.Next() {
+		var detail models.DailyDetail
+		var size sql.NullString
+
+		err := rows.Scan(
+			&detail.Kind,
+			&detail.AccountTitleCode,
+			&detail.AccountTitleName,
+			&size,
+			&detail.Quantity,
+			&detail.Price,
+			&detail.Amount,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan row: %w", err)
+		}
+
+		if size.Valid {
+			detail.Size = size.String
+		}
+
+		details = append(details, detail)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating rows: %w", err)
+	}
+
+	return details, nil
+}
+
+// GetCompaniesWithWarehouses retrieves the company/warehouse pairs that have a contract
+func (r *ProfitRepository) GetCompaniesWithWarehouses(ctx context.Context) (map[string][]models.ProfitData, error) {
 	query := `
 		SELECT 
 			c.id as company_id,
 			c.name as company_name,
 			wb.id as warehouse_base_id,
 			wb.name as warehouse_name
-		FROM companies c
-		CROSS JOIN warehouse_bases wb
+		FROM (
+			SELECT DISTINCT company_id, warehouse_base_id
+			FROM company_warehouse_contracts
+		) cwc
+		JOIN companies c ON c.id = cwc.company_id
+		JOIN warehouse_bases wb ON wb.id = cwc.warehouse_base_id
 		ORDER BY c.name, wb.name
 	`
 
-	rows, err := r.db.Query(query)
+	ctx, cancel := r.queryContext(ctx)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, query)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
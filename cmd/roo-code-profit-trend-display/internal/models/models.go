@@ -12,6 +12,8 @@ type ProfitData struct {
 	SalesAmount     float64   `json:"sales_amount"`
 	CostAmount      float64   `json:"cost_amount"`
 	ProfitAmount    float64   `json:"profit_amount"`
+	IsBusinessDay   bool      `json:"is_business_day"`
+	HolidayName     string    `json:"holiday_name,omitempty"`
 }
 
 // ProfitTrend represents a series of profit data for trend analysis
@@ -33,6 +35,67 @@ type ProfitStats struct {
 	MaxDate       time.Time `json:"max_date"`
 	MinDate       time.Time `json:"min_date"`
 	DaysCount     int       `json:"days_count"`
+
+	// Business-day breakdown (weekends and national holidays are non-business days)
+	BusinessDays            int     `json:"business_days"`
+	NonBusinessDays         int     `json:"non_business_days"`
+	BusinessDayAvgProfit    float64 `json:"business_day_avg_profit"`
+	NonBusinessDayAvgProfit float64 `json:"non_business_day_avg_profit"`
+}
+
+// Metric identifies which value of ProfitData is displayed
+type Metric string
+
+const (
+	MetricSales  Metric = "sales"
+	MetricCost   Metric = "cost"
+	MetricProfit Metric = "profit"
+	MetricMargin Metric = "margin"
+)
+
+// Metrics lists all metrics in display order
+var Metrics = []Metric{MetricSales, MetricCost, MetricProfit, MetricMargin}
+
+// Label returns the Japanese display name of the metric
+func (m Metric) Label() string {
+	switch m {
+	case MetricSales:
+		return "売上"
+	case MetricCost:
+		return "原価"
+	case MetricMargin:
+		return "粗利率"
+	default:
+		return "粗利"
+	}
+}
+
+// Value returns the value of the given metric (margin is a percentage of sales)
+func (d ProfitData) Value(m Metric) float64 {
+	switch m {
+	case MetricSales:
+		return d.SalesAmount
+	case MetricCost:
+		return d.CostAmount
+	case MetricMargin:
+		if d.SalesAmount == 0 {
+			return 0
+		}
+		return d.ProfitAmount / d.SalesAmount * 100
+	default:
+		return d.ProfitAmount
+	}
+}
+
+// DailyDetail represents an account-title level item line for a single day
+type DailyDetail struct {
+	Kind             string  `json:"kind"` // "sales" or "cost"
+	AccountTitleCode string  `json:"account_title_code"`
+	AccountTitleName string  `json:"account_title_name"`
+	Size             string  `json:"size"`
+	Quantity         int     `json:"quantity"`
+	Price            float64 `json:"price"`
+	Amount           float64 `json:"amount"`
 }
 
 // ChartPoint represents a point in the text-based chart
@@ -42,7 +105,18 @@ type ChartPoint struct {
 	Symbol string    `json:"symbol"`
 }
 
-// ChartConfig contains configuration for chart rendering
+// ChartType identifies the kind of image chart
+type ChartType string
+
+const (
+	ChartLine    ChartType = "line"    // sales, cost and profit lines
+	ChartBar     ChartType = "bar"     // daily profit bars
+	ChartStacked ChartType = "stacked" // cost and profit stacked up to sales
+)
+
+// ChartConfig contains configuration for chart rendering.
+// Width and Height are in characters for text charts; image charts
+// use ImageWidth and ImageHeight in pixels.
 type ChartConfig struct {
 	Width     int     `json:"width"`
 	Height    int     `json:"height"`
@@ -50,6 +124,11 @@ type ChartConfig struct {
 	MaxValue  float64 `json:"max_value"`
 	ShowGrid  bool    `json:"show_grid"`
 	ShowStats bool    `json:"show_stats"`
+
+	Type        ChartType `json:"type"`
+	ImageWidth  int       `json:"image_width"`
+	ImageHeight int       `json:"image_height"`
+	FontPath    string    `json:"font_path"` // TrueType/OpenType font for PNG labels
 }
 
 // NotificationConfig contains configuration for notifications
@@ -17,6 +17,10 @@ import (
 type SlackNotifier struct {
 	webhookURL string
 	client     *http.Client
+
+	// File uploads use the Web API because incoming webhooks cannot carry files
+	botToken  string
+	channelID string
 }
 
 // NewSlackNotifier creates a new Slack notifier instance
@@ -113,6 +117,26 @@ func (s *SlackNotifier) formatProfitMessage(trends []models.ProfitTrend, period
 		avgProfit = totalProfit / float64(totalDays)
 	}
 
+	// Calculate business-day and non-business-day averages
+	var businessProfit, nonBusinessProfit float64
+	var businessDays, nonBusinessDays int
+	for _, item := range allData {
+		if item.IsBusinessDay {
+			businessProfit += item.ProfitAmount
+			businessDays++
+		} else {
+			nonBusinessProfit += item.ProfitAmount
+			nonBusinessDays++
+		}
+	}
+	businessAvg, nonBusinessAvg := float64(0), float64(0)
+	if businessDays > 0 {
+		businessAvg = businessProfit / float64(businessDays)
+	}
+	if nonBusinessDays > 0 {
+		nonBusinessAvg = nonBusinessProfit / float64(nonBusinessDays)
+	}
+
 	// Create main message
 	message := models.SlackMessage{
 		Text: fmt.Sprintf("📊 粗利推移分析結果 (過去%d日間)", period),
@@ -131,6 +155,16 @@ func (s *SlackNotifier) formatProfitMessage(trends []models.ProfitTrend, period
 						Value: s.formatCurrency(avgProfit),
 						Short: true,
 					},
+					{
+						Title: "営業日平均粗利",
+						Value: s.formatCurrency(businessAvg),
+						Short: true,
+					},
+					{
+						Title: "休日平均粗利",
+						Value: s.formatCurrency(nonBusinessAvg),
+						Short: true,
+					},
 					{
 						Title: "最大粗利",
 						Value: fmt.Sprintf("%s (%s)", s.formatCurrency(maxProfit), maxDate.Format("01/02")),
@@ -0,0 +1,118 @@
+package notification
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+const slackAPIBaseURL = "https://slack.com/api"
+
+// slackAPIResponse is the common part of Slack Web API responses
+type slackAPIResponse struct {
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// EnableFileUpload configures the bot token and channel used to attach files
+func (s *SlackNotifier) EnableFileUpload(botToken, channelID string) {
+	s.botToken = botToken
+	s.channelID = channelID
+}
+
+// CanUploadFiles returns whether file attachments are configured
+func (s *SlackNotifier) CanUploadFiles() bool {
+	return s.botToken != "" && s.channelID != ""
+}
+
+// UploadFile attaches a file such as a chart image to the configured channel
+func (s *SlackNotifier) UploadFile(path, title, comment string) error {
+	if !s.CanUploadFiles() {
+		return fmt.Errorf("slack file upload not enabled")
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read file: %w", err)
+	}
+
+	// 1. Reserve an upload URL
+	var reserved struct {
+		slackAPIResponse
+		UploadURL string `json:"upload_url"`
+		FileID    string `json:"file_id"`
+	}
+	form := url.Values{
+		"filename": {filepath.Base(path)},
+		"length":   {strconv.Itoa(len(content))},
+	}
+	req, err := http.NewRequest(http.MethodPost, slackAPIBaseURL+"/files.getUploadURLExternal", bytes.NewBufferString(form.Encode()))
+	if err != nil {
+		return fmt.Errorf("failed to create upload request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if err := s.callAPI(req, &reserved); err != nil {
+		return err
+	}
+
+	// 2. Send the file content
+	resp, err := s.client.Post(reserved.UploadURL, "application/octet-stream", bytes.NewReader(content))
+	if err != nil {
+		return fmt.Errorf("failed to upload file to Slack: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("slack file upload returned status %d", resp.StatusCode)
+	}
+
+	// 3. Share the uploaded file in the channel
+	payload, err := json.Marshal(map[string]interface{}{
+		"files":           []map[string]string{{"id": reserved.FileID, "title": title}},
+		"channel_id":      s.channelID,
+		"initial_comment": comment,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal upload completion: %w", err)
+	}
+	req, err = http.NewRequest(http.MethodPost, slackAPIBaseURL+"/files.completeUploadExternal", bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create completion request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	var completed slackAPIResponse
+	return s.callAPI(req, &completed)
+}
+
+// callAPI sends an authenticated Web API request and checks the "ok" flag
+func (s *SlackNotifier) callAPI(req *http.Request, result interface{}) error {
+	req.Header.Set("Authorization", "Bearer "+s.botToken)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to call Slack API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("slack API %s returned status %d", req.URL.Path, resp.StatusCode)
+	}
+
+	var body bytes.Buffer
+	if _, err := body.ReadFrom(resp.Body); err != nil {
+		return fmt.Errorf("failed to read Slack API response: %w", err)
+	}
+
+	var status slackAPIResponse
+	if err := json.Unmarshal(body.Bytes(), &status); err != nil {
+		return fmt.Errorf("failed to decode Slack API response: %w", err)
+	}
+	if !status.OK {
+		return fmt.Errorf("slack API %s failed: %s", req.URL.Path, status.Error)
+	}
+	return json.Unmarshal(body.Bytes(), result)
+}
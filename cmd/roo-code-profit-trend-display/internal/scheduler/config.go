@@ -0,0 +1,258 @@
+package scheduler
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"gopkg.in/yaml.v3"
+)
+
+// ReportType identifies what a job generates
+type ReportType string
+
+const (
+	ReportSummary ReportType = "summary" // Summary of all trends
+	ReportCharts  ReportType = "charts"  // Text chart per trend followed by the summary
+	ReportImages  ReportType = "images"  // PNG/SVG chart image per trend
+)
+
+// RangeType identifies how the date range is derived from the run time
+type RangeType string
+
+const (
+	RangeDays          RangeType = "days"           // Last N days
+	RangeMonthToDate   RangeType = "month_to_date"  // From the 1st of the month
+	RangePreviousMonth RangeType = "previous_month" // The whole previous month
+	RangePreviousWeek  RangeType = "previous_week"  // Monday to Sunday of the previous week
+)
+
+// DestinationType identifies where a job delivers its report
+type DestinationType string
+
+const (
+	DestinationStdout DestinationType = "stdout"
+	DestinationFile   DestinationType = "file"
+	DestinationSlack  DestinationType = "slack"
+)
+
+// Config is the daemon configuration file
+type Config struct {
+	// Timezone is the default timezone of job schedules and date ranges
+	Timezone string `yaml:"timezone"`
+	// HistoryFile is the JSON Lines file run history is appended to (disabled when empty)
+	HistoryFile string `yaml:"history_file"`
+	// HistorySize is the number of runs kept in memory
+	HistorySize int   `yaml:"history_size"`
+	Jobs        []Job `yaml:"jobs"`
+}
+
+// Job defines one recurring report
+type Job struct {
+	Name        string      `yaml:"name"`
+	Schedule    string      `yaml:"schedule"`
+	Timezone    string      `yaml:"timezone"`
+	Report      ReportType  `yaml:"report"`
+	Range       DateRange   `yaml:"range"`
+	Filter      Filter      `yaml:"filter"`
+	Destination Destination `yaml:"destination"`
+
+	location *time.Location
+}
+
+// DateRange is a date range relative to the run time
+type DateRange struct {
+	Type RangeType `yaml:"type"`
+	// Days is the number of days for the days range
+	Days int `yaml:"days"`
+	// Offset moves the end of the range back by N days (e.g. 1 to end yesterday)
+	Offset int `yaml:"offset"`
+}
+
+// Filter limits a report to specific companies and warehouses (all when empty)
+type Filter struct {
+	Companies  []int `yaml:"companies"`
+	Warehouses []int `yaml:"warehouses"`
+}
+
+// Destination defines where and how a report is delivered
+type Destination struct {
+	Type DestinationType `yaml:"type"`
+	// Dir is the output directory for file destinations and chart images
+	Dir         string `yaml:"dir"`
+	ImageFormat string `yaml:"image_format"`
+	ImageType   string `yaml:"image_type"`
+}
+
+// LoadConfig reads and validates a daemon configuration file
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config: %w", err)
+	}
+
+	// Reject unknown keys so a misspelled field is not silently ignored
+	var config Config
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse config: %w", err)
+	}
+
+	if err := config.normalize(); err != nil {
+		return nil, err
+	}
+	return &config, nil
+}
+
+// normalize applies defaults and validates every job
+func (c *Config) normalize() error {
+	if c.Timezone == "" {
+		c.Timezone = "Local"
+	}
+	if c.HistorySize <= 0 {
+		c.HistorySize = 100
+	}
+	if len(c.Jobs) == 0 {
+		return fmt.Errorf("no jobs defined")
+	}
+
+	names := make(map[string]bool)
+	parser := cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)
+	for i := range c.Jobs {
+		job := &c.Jobs[i]
+		if job.Name == "" {
+			return fmt.Errorf("job #%d: name is required", i+1)
+		}
+		if names[job.Name] {
+			return fmt.Errorf("job %s: duplicate name", job.Name)
+		}
+		names[job.Name] = true
+
+		if _, err := parser.Parse(job.Schedule); err != nil {
+			return fmt.Errorf("job %s: invalid schedule %q: %w", job.Name, job.Schedule, err)
+		}
+
+		// A timezone prefix in the schedule also applies to the date range
+		if scheduleTZ, ok := scheduleTimezone(job.Schedule); ok {
+			if job.Timezone != "" && job.Timezone != scheduleTZ {
+				return fmt.Errorf("job %s: timezone %q conflicts with schedule timezone %q", job.Name, job.Timezone, scheduleTZ)
+			}
+			job.Timezone = scheduleTZ
+		}
+		if job.Timezone == "" {
+			job.Timezone = c.Timezone
+		}
+		location, err := time.LoadLocation(job.Timezone)
+		if err != nil {
+			return fmt.Errorf("job %s: invalid timezone %q: %w", job.Name, job.Timezone, err)
+		}
+		job.location = location
+
+		if err := job.normalize(); err != nil {
+			return fmt.Errorf("job %s: %w", job.Name, err)
+		}
+	}
+	return nil
+}
+
+func (j *Job) normalize() error {
+	switch j.Report {
+	case "":
+		j.Report = ReportSummary
+	case ReportSummary, ReportCharts, ReportImages:
+	default:
+		return fmt.Errorf("unknown report type: %s", j.Report)
+	}
+
+	switch j.Range.Type {
+	case "":
+		j.Range.Type = RangeDays
+	case RangeDays, RangeMonthToDate, RangePreviousMonth, RangePreviousWeek:
+	default:
+		return fmt.Errorf("unknown range type: %s", j.Range.Type)
+	}
+	if j.Range.Type == RangeDays && j.Range.Days <= 0 {
+		j.Range.Days = 30
+	}
+	if j.Range.Offset < 0 {
+		return fmt.Errorf("range offset must not be negative")
+	}
+
+	switch j.Destination.Type {
+	case "":
+		j.Destination.Type = DestinationStdout
+	case DestinationStdout, DestinationSlack:
+	case DestinationFile:
+		if j.Destination.Dir == "" {
+			return fmt.Errorf("destination dir is required for file destination")
+		}
+	default:
+		return fmt.Errorf("unknown destination type: %s", j.Destination.Type)
+	}
+	if j.Report == ReportImages && j.Destination.Dir == "" {
+		return fmt.Errorf("destination dir is required for images report")
+	}
+	if j.Destination.ImageFormat == "" {
+		j.Destination.ImageFormat = "png"
+	}
+	if j.Destination.ImageType == "" {
+		j.Destination.ImageType = "line"
+	}
+	return nil
+}
+
+// Location returns the timezone of the job
+func (j Job) Location() *time.Location {
+	if j.location == nil {
+		return time.Local
+	}
+	return j.location
+}
+
+// Period returns the date range of a run started at now, as dates in the local timezone
+func (j Job) Period(now time.Time) (time.Time, time.Time) {
+	now = now.In(j.Location())
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	switch j.Range.Type {
+	case RangeMonthToDate:
+		end := today.AddDate(0, 0, -j.Range.Offset)
+		return time.Date(end.Year(), end.Month(), 1, 0, 0, 0, 0, time.Local), end
+	case RangePreviousMonth:
+		start := time.Date(today.Year(), today.Month()-1, 1, 0, 0, 0, 0, time.Local)
+		return start, start.AddDate(0, 1, -1)
+	case RangePreviousWeek:
+		// Weeks start on Monday
+		weekday := (int(today.Weekday()) + 6) % 7
+		start := today.AddDate(0, 0, -weekday-7)
+		return start, start.AddDate(0, 0, 6)
+	default:
+		end := today.AddDate(0, 0, -j.Range.Offset)
+		return end.AddDate(0, 0, -j.Range.Days+1), end
+	}
+}
+
+// spec returns the cron spec including the job timezone
+func (j Job) spec() string {
+	if _, ok := scheduleTimezone(j.Schedule); ok {
+		return j.Schedule
+	}
+	return "CRON_TZ=" + j.Location().String() + " " + j.Schedule
+}
+
+// scheduleTimezone returns the timezone of a schedule starting with CRON_TZ= or TZ=
+func scheduleTimezone(schedule string) (string, bool) {
+	for _, prefix := range []string{"CRON_TZ=", "TZ="} {
+		if strings.HasPrefix(schedule, prefix) {
+			name, _, _ := strings.Cut(strings.TrimPrefix(schedule, prefix), " ")
+			return name, true
+		}
+	}
+	return "", false
+}
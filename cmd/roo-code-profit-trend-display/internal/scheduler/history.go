@@ -0,0 +1,138 @@
+package scheduler
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// RunStatus is the result of a job run
+type RunStatus string
+
+const (
+	StatusSucceeded RunStatus = "succeeded"
+	StatusFailed    RunStatus = "failed"
+	// StatusSkipped means the previous run of the same job was still in progress
+	StatusSkipped RunStatus = "skipped"
+)
+
+// Run is one entry of the run history
+type Run struct {
+	Job        string    `json:"job"`
+	Status     RunStatus `json:"status"`
+	StartedAt  time.Time `json:"started_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	StartDate  string    `json:"start_date,omitempty"`
+	EndDate    string    `json:"end_date,omitempty"`
+	Trends     int       `json:"trends"`
+	Outputs    []string  `json:"outputs,omitempty"`
+	Error      string    `json:"error,omitempty"`
+}
+
+// Duration returns how long the run took
+func (r Run) Duration() time.Duration {
+	return r.FinishedAt.Sub(r.StartedAt)
+}
+
+// History keeps recent runs in memory and appends every run to a JSON Lines file
+type History struct {
+	mu   sync.Mutex
+	path string
+	size int
+	runs []Run
+}
+
+// NewHistory creates a history, loading the latest runs from path when it exists
+func NewHistory(path string, size int) (*History, error) {
+	h := &History{path: path, size: size}
+	if path == "" {
+		return h, nil
+	}
+
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return h, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to open history: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		var run Run
+		if err := json.Unmarshal(scanner.Bytes(), &run); err != nil {
+			// Skip broken lines (e.g. a partial write on crash)
+			continue
+		}
+		h.keep(run)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read history: %w", err)
+	}
+	return h, nil
+}
+
+// Add records a run
+func (h *History) Add(run Run) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.keep(run)
+	if h.path == "" {
+		return nil
+	}
+
+	line, err := json.Marshal(run)
+	if err != nil {
+		return fmt.Errorf("failed to encode run: %w", err)
+	}
+	file, err := os.OpenFile(h.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to open history: %w", err)
+	}
+	if _, err := file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write history: %w", err)
+	}
+	return file.Close()
+}
+
+// Recent returns up to n runs, newest first (all runs when n <= 0)
+func (h *History) Recent(n int) []Run {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if n <= 0 || n > len(h.runs) {
+		n = len(h.runs)
+	}
+	runs := make([]Run, 0, n)
+	for i := len(h.runs) - 1; i >= len(h.runs)-n; i-- {
+		runs = append(runs, h.runs[i])
+	}
+	return runs
+}
+
+// LastRun returns the latest run of the job
+func (h *History) LastRun(job string) (Run, bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i := len(h.runs) - 1; i >= 0; i-- {
+		if h.runs[i].Job == job {
+			return h.runs[i], true
+		}
+	}
+	return Run{}, false
+}
+
+func (h *History) keep(run Run) {
+	h.runs = append(h.runs, run)
+	if h.size > 0 && len(h.runs) > h.size {
+		h.runs = h.runs[len(h.runs)-h.size:]
+	}
+}
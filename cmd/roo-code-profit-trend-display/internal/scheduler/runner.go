@@ -0,0 +1,197 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"profit-trend-display/internal/calculator"
+	"profit-trend-display/internal/chart"
+	"profit-trend-display/internal/database"
+	"profit-trend-display/internal/models"
+	"profit-trend-display/internal/notification"
+)
+
+// Runner generates and delivers the report of a job
+type Runner struct {
+	repo     *database.ProfitRepository
+	calc     *calculator.ProfitCalculator
+	slack    *notification.SlackNotifier
+	fontPath string
+	out      io.Writer
+}
+
+// NewRunner creates a runner; slack may be nil when no job uses the slack destination
+func NewRunner(repo *database.ProfitRepository, calc *calculator.ProfitCalculator, slack *notification.SlackNotifier, fontPath string) *Runner {
+	return &Runner{
+		repo:     repo,
+		calc:     calc,
+		slack:    slack,
+		fontPath: fontPath,
+		out:      os.Stdout,
+	}
+}
+
+// Run executes the job for the given run time and returns its history entry;
+// when ctx is done the run fails with the outputs delivered so far
+func (r *Runner) Run(ctx context.Context, job Job, now time.Time) Run {
+	startDate, endDate := job.Period(now)
+	run := Run{
+		Job:       job.Name,
+		StartedAt: now,
+		StartDate: startDate.Format("2006-01-02"),
+		EndDate:   endDate.Format("2006-01-02"),
+	}
+
+	outputs, trends, err := r.execute(ctx, job, startDate, endDate)
+	run.FinishedAt = time.Now()
+	run.Trends = trends
+	run.Outputs = outputs
+	if err != nil {
+		run.Status = StatusFailed
+		run.Error = err.Error()
+	} else {
+		run.Status = StatusSucceeded
+	}
+	return run
+}
+
+func (r *Runner) execute(ctx context.Context, job Job, startDate, endDate time.Time) ([]string, int, error) {
+	data, err := r.repo.GetProfitTrendsForPeriod(ctx, startDate, endDate)
+	if err != nil {
+		return nil, 0, fmt.Errorf("データ取得エラー: %w", err)
+	}
+
+	trends := r.calc.BuildTrends(filterData(data, job.Filter), startDate, endDate)
+	days := int(endDate.Sub(startDate).Hours()/24) + 1
+
+	var outputs []string
+	if job.Report == ReportImages {
+		outputs, err = r.writeImages(job, trends)
+		if err != nil {
+			return outputs, len(trends), err
+		}
+	}
+
+	if err := ctx.Err(); err != nil {
+		return outputs, len(trends), fmt.Errorf("中断されました: %w", err)
+	}
+
+	switch job.Destination.Type {
+	case DestinationSlack:
+		if r.slack == nil || !r.slack.IsEnabled() {
+			return outputs, len(trends), fmt.Errorf("SLACK_HOOK環境変数が未設定です")
+		}
+		if err := r.slack.SendProfitSummary(trends, days); err != nil {
+			return outputs, len(trends), fmt.Errorf("Slack通知送信エラー: %w", err)
+		}
+		if len(outputs) > 0 && r.slack.CanUploadFiles() {
+			for _, path := range outputs {
+				if err := ctx.Err(); err != nil {
+					return outputs, len(trends), fmt.Errorf("中断されました: %w", err)
+				}
+				if err := r.slack.UploadFile(path, filepath.Base(path), ""); err != nil {
+					return outputs, len(trends), fmt.Errorf("Slackへのグラフ画像添付エラー: %w", err)
+				}
+			}
+		}
+		outputs = append(outputs, "slack")
+
+	case DestinationFile:
+		if job.Report == ReportImages {
+			break
+		}
+		if err := os.MkdirAll(job.Destination.Dir, 0o755); err != nil {
+			return outputs, len(trends), fmt.Errorf("出力先ディレクトリ作成エラー: %w", err)
+		}
+		path := filepath.Join(job.Destination.Dir, fmt.Sprintf("%s_%s-%s.txt",
+			job.Name, startDate.Format("20060102"), endDate.Format("20060102")))
+		if err := os.WriteFile(path, []byte(r.renderText(job, trends)), 0o644); err != nil {
+			return outputs, len(trends), fmt.Errorf("レポート出力エラー: %w", err)
+		}
+		outputs = append(outputs, path)
+
+	default:
+		if job.Report == ReportImages {
+			for _, path := range outputs {
+				fmt.Fprintf(r.out, "[%s] %s\n", job.Name, path)
+			}
+			break
+		}
+		fmt.Fprintf(r.out, "=== %s (%s から %s まで) ===\n", job.Name,
+			startDate.Format("2006-01-02"), endDate.Format("2006-01-02"))
+		fmt.Fprint(r.out, r.renderText(job, trends))
+		outputs = append(outputs, "stdout")
+	}
+
+	return outputs, len(trends), nil
+}
+
+// renderText renders the summary or text charts of the trends
+func (r *Runner) renderText(job Job, trends []models.ProfitTrend) string {
+	if len(trends) == 0 {
+		return "指定された期間にデータが見つかりませんでした。\n"
+	}
+
+	renderer := chart.NewTextChart(models.ChartConfig{ShowGrid: true, ShowStats: true})
+	if job.Report == ReportSummary {
+		return renderer.RenderSummary(trends)
+	}
+
+	var sb strings.Builder
+	for _, trend := range trends {
+		sb.WriteString(renderer.RenderProfitTrend(trend))
+		sb.WriteString(strings.Repeat("-", 80) + "\n\n")
+	}
+	sb.WriteString(renderer.RenderSummary(trends))
+	return sb.String()
+}
+
+func (r *Runner) writeImages(job Job, trends []models.ProfitTrend) ([]string, error) {
+	format, err := chart.ParseImageFormat(job.Destination.ImageFormat)
+	if err != nil {
+		return nil, err
+	}
+	renderer, err := chart.NewImageRenderer(models.ChartConfig{
+		Type:      models.ChartType(job.Destination.ImageType),
+		FontPath:  r.fontPath,
+		ShowGrid:  true,
+		ShowStats: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return renderer.WriteFiles(job.Destination.Dir, trends, format)
+}
+
+// filterData keeps only the rows matching the job filter
+func filterData(data []models.ProfitData, filter Filter) []models.ProfitData {
+	if len(filter.Companies) == 0 && len(filter.Warehouses) == 0 {
+		return data
+	}
+
+	var filtered []models.ProfitData
+	for _, item := range data {
+		if len(filter.Companies) > 0 && !containsID(filter.Companies, item.CompanyID) {
+			continue
+		}
+		if len(filter.Warehouses) > 0 && !containsID(filter.Warehouses, item.WarehouseBaseID) {
+			continue
+		}
+		filtered = append(filtered, item)
+	}
+	return filtered
+}
+
+func containsID(ids []int, id int) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
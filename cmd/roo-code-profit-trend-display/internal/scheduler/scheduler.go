@@ -0,0 +1,173 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+)
+
+// JobRunner executes a job; implemented by Runner
+type JobRunner interface {
+	Run(ctx context.Context, job Job, now time.Time) Run
+}
+
+// Scheduler runs jobs on their cron schedules in-process
+type Scheduler struct {
+	cron    *cron.Cron
+	runner  JobRunner
+	history *History
+	jobs    []Job
+	entries map[string]cron.EntryID
+
+	// ctx is cancelled when running jobs are abandoned on Stop
+	ctx        context.Context
+	cancel     context.CancelFunc
+	jobTimeout time.Duration
+
+	mu      sync.Mutex
+	running map[string]bool
+}
+
+// NewScheduler registers all jobs of the configuration
+func NewScheduler(config *Config, runner JobRunner, history *History) (*Scheduler, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Scheduler{
+		ctx:    ctx,
+		cancel: cancel,
+		cron: cron.New(
+			cron.WithParser(cron.NewParser(cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor)),
+			cron.WithLocation(time.Local),
+		),
+		runner:  runner,
+		history: history,
+		jobs:    config.Jobs,
+		entries: make(map[string]cron.EntryID),
+		running: make(map[string]bool),
+	}
+
+	for _, job := range config.Jobs {
+		job := job
+		id, err := s.cron.AddFunc(job.spec(), func() { s.execute(s.ctx, job) })
+		if err != nil {
+			return nil, fmt.Errorf("job %s: failed to schedule: %w", job.Name, err)
+		}
+		s.entries[job.Name] = id
+	}
+	return s, nil
+}
+
+// Start starts the schedules in the background
+func (s *Scheduler) Start() {
+	s.cron.Start()
+}
+
+// SetJobTimeout limits the duration of every run (no limit when zero)
+func (s *Scheduler) SetJobTimeout(timeout time.Duration) {
+	s.jobTimeout = timeout
+}
+
+// Stop stops scheduling new runs and waits for running jobs until ctx is done,
+// then cancels them so that they record their partial outputs as failed
+func (s *Scheduler) Stop(ctx context.Context) error {
+	done := s.cron.Stop()
+	select {
+	case <-done.Done():
+		s.cancel()
+		return nil
+	case <-ctx.Done():
+		s.cancel()
+		<-done.Done()
+		return fmt.Errorf("running jobs did not finish: %w", ctx.Err())
+	}
+}
+
+// RunNow runs the named job immediately and waits for it; the run is cancelled when ctx is done
+func (s *Scheduler) RunNow(ctx context.Context, name string) (Run, error) {
+	for _, job := range s.jobs {
+		if job.Name == name {
+			return s.execute(ctx, job), nil
+		}
+	}
+	return Run{}, fmt.Errorf("job not found: %s", name)
+}
+
+// NextRuns returns the next run time of every job
+func (s *Scheduler) NextRuns() map[string]time.Time {
+	next := make(map[string]time.Time, len(s.entries))
+	for name, id := range s.entries {
+		entry := s.cron.Entry(id)
+		if entry.Next.IsZero() {
+			// Not started yet: compute from the schedule
+			next[name] = entry.Schedule.Next(time.Now())
+			continue
+		}
+		next[name] = entry.Next
+	}
+	return next
+}
+
+// Jobs returns the registered jobs in configuration order
+func (s *Scheduler) Jobs() []Job {
+	return s.jobs
+}
+
+// execute runs a job unless its previous run is still in progress, and records the result
+func (s *Scheduler) execute(ctx context.Context, job Job) Run {
+	now := time.Now()
+	if !s.acquire(job.Name) {
+		run := Run{
+			Job:        job.Name,
+			Status:     StatusSkipped,
+			StartedAt:  now,
+			FinishedAt: now,
+			Error:      "previous run is still in progress",
+		}
+		log.Printf("[%s] 前回の実行が完了していないためスキップしました", job.Name)
+		s.record(run)
+		return run
+	}
+	defer s.release(job.Name)
+
+	if s.jobTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.jobTimeout)
+		defer cancel()
+	}
+
+	log.Printf("[%s] 実行開始", job.Name)
+	run := s.runner.Run(ctx, job, now)
+	if run.Status == StatusFailed {
+		log.Printf("[%s] 実行失敗 (%s): %s", job.Name, run.Duration().Round(time.Millisecond), run.Error)
+	} else {
+		log.Printf("[%s] 実行完了 (%s) 期間: %s - %s 対象組織数: %d",
+			job.Name, run.Duration().Round(time.Millisecond), run.StartDate, run.EndDate, run.Trends)
+	}
+	s.record(run)
+	return run
+}
+
+func (s *Scheduler) acquire(name string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running[name] {
+		return false
+	}
+	s.running[name] = true
+	return true
+}
+
+func (s *Scheduler) release(name string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.running, name)
+}
+
+func (s *Scheduler) record(run Run) {
+	if err := s.history.Add(run); err != nil {
+		log.Printf("[%s] 実行履歴の保存に失敗しました: %v", run.Job, err)
+	}
+}
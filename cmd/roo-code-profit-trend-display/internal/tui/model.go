@@ -0,0 +1,355 @@
+package tui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"profit-trend-display/internal/calculator"
+	"profit-trend-display/internal/database"
+	"profit-trend-display/internal/models"
+)
+
+// viewMode identifies the screen currently displayed
+type viewMode int
+
+const (
+	viewTrends viewMode = iota
+	viewDetail
+)
+
+const (
+	minDays       = 7
+	maxDays       = 365
+	rangeStepDays = 7
+)
+
+// Options contains the initial state of the dashboard
+type Options struct {
+	Days   int
+	Metric models.Metric
+}
+
+// Model is the bubbletea model of the profit trend dashboard
+type Model struct {
+	ctx  context.Context
+	repo *database.ProfitRepository
+	calc *calculator.ProfitCalculator
+
+	days      int
+	endDate   time.Time
+	startDate time.Time
+	metric    int
+
+	trends     []models.ProfitTrend
+	selected   int
+	listOffset int
+	day        int
+
+	mode         viewMode
+	details      []models.DailyDetail
+	detailOffset int
+
+	loading bool
+	err     error
+	width   int
+	height  int
+}
+
+type trendsLoadedMsg struct {
+	trends []models.ProfitTrend
+}
+
+type detailLoadedMsg struct {
+	details []models.DailyDetail
+}
+
+type errMsg struct {
+	err error
+}
+
+// NewModel creates a dashboard model covering the last N days; loads are cancelled when ctx is done
+func NewModel(ctx context.Context, repo *database.ProfitRepository, calc *calculator.ProfitCalculator, opts Options) Model {
+	days := opts.Days
+	if days < 1 {
+		days = 30
+	}
+
+	if opts.Metric == "" {
+		opts.Metric = models.MetricProfit
+	}
+	metric := 0
+	for i, m := range models.Metrics {
+		if m == opts.Metric {
+			metric = i
+		}
+	}
+
+	startDate, endDate := calc.GetDateRange(days)
+
+	return Model{
+		ctx:       ctx,
+		repo:      repo,
+		calc:      calc,
+		days:      days,
+		startDate: startDate,
+		endDate:   endDate,
+		metric:    metric,
+		day:       days - 1,
+		loading:   true,
+		width:     100,
+		height:    30,
+	}
+}
+
+// Run starts the dashboard in the alternate screen and blocks until it exits or ctx is done
+func Run(ctx context.Context, repo *database.ProfitRepository, calc *calculator.ProfitCalculator, opts Options) error {
+	program := tea.NewProgram(NewModel(ctx, repo, calc, opts), tea.WithAltScreen(), tea.WithContext(ctx))
+	_, err := program.Run()
+	return err
+}
+
+// Init loads the initial trends
+func (m Model) Init() tea.Cmd {
+	return m.loadTrends()
+}
+
+// loadTrends fetches and aggregates the trends for the current date range
+func (m Model) loadTrends() tea.Cmd {
+	ctx, repo, calc := m.ctx, m.repo, m.calc
+	startDate, endDate := m.startDate, m.endDate
+
+	return func() tea.Msg {
+		data, err := repo.GetProfitTrendsForPeriod(ctx, startDate, endDate)
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return trendsLoadedMsg{trends: calc.BuildTrends(data, startDate, endDate)}
+	}
+}
+
+// loadDetail fetches account-title and item detail for the selected day
+func (m Model) loadDetail() tea.Cmd {
+	trend, ok := m.selectedTrend()
+	if !ok || m.day >= len(trend.Data) {
+		return nil
+	}
+	ctx, repo := m.ctx, m.repo
+	date := trend.Data[m.day].TargetDate
+
+	return func() tea.Msg {
+		details, err := repo.GetDailyDetail(ctx, trend.CompanyID, trend.WarehouseBaseID, date)
+		if err != nil {
+			return errMsg{err: err}
+		}
+		return detailLoadedMsg{details: details}
+	}
+}
+
+// Update handles key input and asynchronous load results
+func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width, m.height = msg.Width, msg.Height
+		m.clampList()
+		return m, nil
+
+	case trendsLoadedMsg:
+		m.loading = false
+		m.err = nil
+		m.trends = msg.trends
+		if m.selected >= len(m.trends) {
+			m.selected = 0
+		}
+		if m.day >= m.days {
+			m.day = m.days - 1
+		}
+		m.clampList()
+		return m, nil
+
+	case detailLoadedMsg:
+		m.loading = false
+		m.err = nil
+		m.details = msg.details
+		m.detailOffset = 0
+		m.mode = viewDetail
+		return m, nil
+
+	case errMsg:
+		m.loading = false
+		m.err = msg.err
+		return m, nil
+
+	case tea.KeyMsg:
+		if m.mode == viewDetail {
+			return m.updateDetail(msg)
+		}
+		return m.updateTrends(msg)
+	}
+
+	return m, nil
+}
+
+func (m Model) updateTrends(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "ctrl+c":
+		return m, tea.Quit
+	case "up", "k":
+		if m.selected > 0 {
+			m.selected--
+		}
+	case "down", "j":
+		if m.selected < len(m.trends)-1 {
+			m.selected++
+		}
+	case "pgup":
+		m.selected -= m.listHeight()
+		if m.selected < 0 {
+			m.selected = 0
+		}
+	case "pgdown":
+		m.selected += m.listHeight()
+		if m.selected > len(m.trends)-1 {
+			m.selected = len(m.trends) - 1
+		}
+	case "left", "h":
+		if m.day > 0 {
+			m.day--
+		}
+	case "right", "l":
+		if m.day < m.days-1 {
+			m.day++
+		}
+	case "home":
+		m.day = 0
+	case "end":
+		m.day = m.days - 1
+	case "m", "tab":
+		m.metric = (m.metric + 1) % len(models.Metrics)
+	case "1", "2", "3", "4":
+		m.metric = int(msg.String()[0] - '1')
+	case "[":
+		return m.shiftRange(-m.days)
+	case "]":
+		return m.shiftRange(m.days)
+	case "+", "=":
+		return m.resizeRange(rangeStepDays)
+	case "-":
+		return m.resizeRange(-rangeStepDays)
+	case "r":
+		m.loading = true
+		return m, m.loadTrends()
+	case "enter":
+		if cmd := m.loadDetail(); cmd != nil {
+			m.loading = true
+			return m, cmd
+		}
+	}
+
+	m.clampList()
+	return m, nil
+}
+
+func (m Model) updateDetail(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "ctrl+c":
+		return m, tea.Quit
+	case "esc", "backspace", "q":
+		m.mode = viewTrends
+		m.details = nil
+	case "up", "k":
+		if m.detailOffset > 0 {
+			m.detailOffset--
+		}
+	case "down", "j":
+		if m.detailOffset < len(m.details)-1 {
+			m.detailOffset++
+		}
+	case "left", "h":
+		if m.day > 0 {
+			m.day--
+			m.loading = true
+			return m, m.loadDetail()
+		}
+	case "right", "l":
+		if m.day < m.days-1 {
+			m.day++
+			m.loading = true
+			return m, m.loadDetail()
+		}
+	}
+	return m, nil
+}
+
+// shiftRange moves the date range by the given number of days, never past today
+func (m Model) shiftRange(offset int) (tea.Model, tea.Cmd) {
+	_, today := m.calc.GetDateRange(1)
+	endDate := m.endDate.AddDate(0, 0, offset)
+	if endDate.After(today) {
+		endDate = today
+	}
+	if endDate.Equal(m.endDate) {
+		return m, nil
+	}
+
+	m.endDate = endDate
+	m.startDate = endDate.AddDate(0, 0, -m.days+1)
+	m.loading = true
+	return m, m.loadTrends()
+}
+
+// resizeRange extends or shrinks the date range while keeping the end date
+func (m Model) resizeRange(delta int) (tea.Model, tea.Cmd) {
+	days := m.days + delta
+	if days < minDays {
+		days = minDays
+	}
+	if days > maxDays {
+		days = maxDays
+	}
+	if days == m.days {
+		return m, nil
+	}
+
+	m.days = days
+	m.startDate = m.endDate.AddDate(0, 0, -days+1)
+	if m.day >= days {
+		m.day = days - 1
+	}
+	m.loading = true
+	return m, m.loadTrends()
+}
+
+func (m Model) selectedTrend() (models.ProfitTrend, bool) {
+	if m.selected < 0 || m.selected >= len(m.trends) {
+		return models.ProfitTrend{}, false
+	}
+	return m.trends[m.selected], true
+}
+
+func (m Model) currentMetric() models.Metric {
+	return models.Metrics[m.metric]
+}
+
+// clampList keeps the selected trend inside the list (0 when the list is empty)
+// and inside the visible part of the list
+func (m *Model) clampList() {
+	if m.selected > len(m.trends)-1 {
+		m.selected = len(m.trends) - 1
+	}
+	if m.selected < 0 {
+		m.selected = 0
+	}
+
+	height := m.listHeight()
+	if m.selected < m.listOffset {
+		m.listOffset = m.selected
+	}
+	if m.selected >= m.listOffset+height {
+		m.listOffset = m.selected - height + 1
+	}
+	if m.listOffset < 0 {
+		m.listOffset = 0
+	}
+}
@@ -0,0 +1,311 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/mattn/go-runewidth"
+
+	"profit-trend-display/internal/models"
+)
+
+const (
+	axisWidth   = 12
+	chartHeight = 10
+	reverseOn   = "\x1b[7m"
+	reverseOff  = "\x1b[0m"
+)
+
+var weekdays = []string{"日", "月", "火", "水", "木", "金", "土"}
+
+// View renders the current screen
+func (m Model) View() string {
+	var b strings.Builder
+
+	if m.mode == viewDetail {
+		m.renderDetail(&b)
+	} else {
+		m.renderTrends(&b)
+	}
+
+	b.WriteString("\n")
+	switch {
+	case m.loading:
+		b.WriteString("読み込み中...\n")
+	case m.err != nil:
+		b.WriteString(fmt.Sprintf("エラー: %v\n", m.err))
+	}
+
+	return b.String()
+}
+
+// listHeight returns how many trends fit in the list pane
+func (m Model) listHeight() int {
+	height := m.height - chartHeight - 12
+	if height < 3 {
+		height = 3
+	}
+	return height
+}
+
+func (m Model) renderTrends(b *strings.Builder) {
+	metric := m.currentMetric()
+
+	b.WriteString(fmt.Sprintf("粗利推移ダッシュボード  期間: %s ~ %s (%d日)  指標: %s\n",
+		m.startDate.Format("2006-01-02"), m.endDate.Format("2006-01-02"), m.days, metric.Label()))
+	b.WriteString(strings.Repeat("─", m.lineWidth()) + "\n")
+
+	if len(m.trends) == 0 {
+		if !m.loading {
+			b.WriteString("指定された期間にデータが見つかりませんでした。\n")
+		}
+		m.renderHelp(b)
+		return
+	}
+
+	// Company-warehouse list
+	end := m.listOffset + m.listHeight()
+	if end > len(m.trends) {
+		end = len(m.trends)
+	}
+	for i := m.listOffset; i < end; i++ {
+		trend := m.trends[i]
+		marker := "  "
+		if i == m.selected {
+			marker = "> "
+		}
+		name := runewidth.FillRight(runewidth.Truncate(trend.CompanyName+" - "+trend.WarehouseName, 32, "…"), 32)
+		line := fmt.Sprintf("%s%s %s: %14s", marker, name, totalLabel(metric), formatValue(metric, trendTotal(trend, metric)))
+		if i == m.selected {
+			line = reverseOn + line + reverseOff
+		}
+		b.WriteString(line + "\n")
+	}
+	b.WriteString(fmt.Sprintf("  (%d/%d)\n", m.selected+1, len(m.trends)))
+	b.WriteString(strings.Repeat("─", m.lineWidth()) + "\n")
+
+	trend, _ := m.selectedTrend()
+	m.renderChart(b, trend, metric)
+	m.renderSelectedDay(b, trend)
+	m.renderHelp(b)
+}
+
+// renderChart draws a bar chart of the metric, one column group per day
+func (m Model) renderChart(b *strings.Builder, trend models.ProfitTrend, metric models.Metric) {
+	if len(trend.Data) == 0 {
+		return
+	}
+
+	values := make([]float64, len(trend.Data))
+	lo, hi := 0.0, 0.0
+	for i, item := range trend.Data {
+		values[i] = item.Value(metric)
+		lo = math.Min(lo, values[i])
+		hi = math.Max(hi, values[i])
+	}
+	if hi == lo {
+		hi = lo + 1
+	}
+
+	colWidth := (m.lineWidth() - axisWidth) / len(values)
+	if colWidth < 1 {
+		colWidth = 1
+	}
+	if colWidth > 3 {
+		colWidth = 3
+	}
+
+	position := func(v float64) int {
+		return int(math.Round((hi - v) / (hi - lo) * float64(chartHeight-1)))
+	}
+	zeroRow := position(0)
+
+	for row := 0; row < chartHeight; row++ {
+		label := ""
+		switch row {
+		case 0:
+			label = formatValue(metric, hi)
+		case zeroRow:
+			label = formatValue(metric, 0)
+		case chartHeight - 1:
+			label = formatValue(metric, lo)
+		}
+		b.WriteString(fmt.Sprintf("%*s │", axisWidth-2, label))
+
+		for i, v := range values {
+			top, bottom := position(v), zeroRow
+			if top > bottom {
+				top, bottom = bottom, top
+			}
+
+			cell := " "
+			if row >= top && row <= bottom {
+				cell = "█"
+				if v == 0 {
+					cell = "·"
+				}
+			}
+			cells := strings.Repeat(cell, colWidth)
+			if i == m.day {
+				cells = reverseOn + cells + reverseOff
+			}
+			b.WriteString(cells)
+		}
+		b.WriteString("\n")
+	}
+
+	// Day markers: '*' holiday, '.' weekend
+	b.WriteString(strings.Repeat(" ", axisWidth-1) + "└")
+	for i, item := range trend.Data {
+		marker := "─"
+		if item.HolidayName != "" {
+			marker = "*"
+		} else if !item.IsBusinessDay {
+			marker = "."
+		}
+		cells := marker + strings.Repeat("─", colWidth-1)
+		if i == m.day {
+			cells = reverseOn + cells + reverseOff
+		}
+		b.WriteString(cells)
+	}
+	b.WriteString("\n")
+
+	first, last := trend.Data[0].TargetDate.Format("01/02"), trend.Data[len(trend.Data)-1].TargetDate.Format("01/02")
+	gap := len(values)*colWidth - len(first) - len(last)
+	if gap < 1 {
+		gap = 1
+	}
+	b.WriteString(strings.Repeat(" ", axisWidth) + first + strings.Repeat(" ", gap) + last + "\n")
+}
+
+func (m Model) renderSelectedDay(b *strings.Builder, trend models.ProfitTrend) {
+	if m.day >= len(trend.Data) {
+		return
+	}
+	item := trend.Data[m.day]
+
+	b.WriteString(fmt.Sprintf("\n%s  売上: %s  原価: %s  粗利: %s  粗利率: %s",
+		formatDay(item),
+		formatValue(models.MetricSales, item.SalesAmount),
+		formatValue(models.MetricCost, item.CostAmount),
+		formatValue(models.MetricProfit, item.ProfitAmount),
+		formatValue(models.MetricMargin, item.Value(models.MetricMargin))))
+	if item.HolidayName != "" {
+		b.WriteString("  [" + item.HolidayName + "]")
+	}
+	b.WriteString("\n")
+}
+
+func (m Model) renderHelp(b *strings.Builder) {
+	b.WriteString("\n↑↓:組織選択  ←→:日付選択  m/1-4:指標切替  [ ]:期間移動  +/-:期間変更  Enter:明細  r:再読込  q:終了\n")
+}
+
+func (m Model) renderDetail(b *strings.Builder) {
+	trend, ok := m.selectedTrend()
+	if !ok || m.day >= len(trend.Data) {
+		return
+	}
+	item := trend.Data[m.day]
+
+	b.WriteString(fmt.Sprintf("[%s - %s] %s 明細", trend.CompanyName, trend.WarehouseName, formatDay(item)))
+	if item.HolidayName != "" {
+		b.WriteString("  [" + item.HolidayName + "]")
+	}
+	b.WriteString("\n")
+	b.WriteString(strings.Repeat("─", m.lineWidth()) + "\n")
+
+	if len(m.details) == 0 {
+		b.WriteString("この日の明細はありません。\n")
+	} else {
+		b.WriteString(fmt.Sprintf("%s %s %s %8s %12s %14s\n",
+			pad("区分", 4), pad("科目", 16), pad("サイズ", 8), "数量", "単価", "金額"))
+
+		visible := m.height - 10
+		if visible < 5 {
+			visible = 5
+		}
+		end := m.detailOffset + visible
+		if end > len(m.details) {
+			end = len(m.details)
+		}
+		for _, detail := range m.details[m.detailOffset:end] {
+			kind := "売上"
+			if detail.Kind == "cost" {
+				kind = "原価"
+			}
+			b.WriteString(fmt.Sprintf("%s %s %s %8d %12.3f %14s\n",
+				pad(kind, 4), pad(detail.AccountTitleName, 16), pad(detail.Size, 8),
+				detail.Quantity, detail.Price, formatAmount(detail.Amount)))
+		}
+		if len(m.details) > visible {
+			b.WriteString(fmt.Sprintf("  (%d-%d/%d)\n", m.detailOffset+1, end, len(m.details)))
+		}
+	}
+
+	b.WriteString(strings.Repeat("─", m.lineWidth()) + "\n")
+	b.WriteString(fmt.Sprintf("合計  売上: %s  原価: %s  粗利: %s\n",
+		formatAmount(item.SalesAmount), formatAmount(item.CostAmount), formatAmount(item.ProfitAmount)))
+	b.WriteString("\n↑↓:スクロール  ←→:前日/翌日  Esc:戻る\n")
+}
+
+func (m Model) lineWidth() int {
+	if m.width < 40 {
+		return 40
+	}
+	return m.width - 1
+}
+
+// trendTotal returns the period total of the metric (overall margin for margin)
+func trendTotal(trend models.ProfitTrend, metric models.Metric) float64 {
+	var total models.ProfitData
+	for _, item := range trend.Data {
+		total.SalesAmount += item.SalesAmount
+		total.CostAmount += item.CostAmount
+		total.ProfitAmount += item.ProfitAmount
+	}
+	return total.Value(metric)
+}
+
+func totalLabel(metric models.Metric) string {
+	if metric == models.MetricMargin {
+		return "期間粗利率"
+	}
+	return "期間合計"
+}
+
+func formatDay(item models.ProfitData) string {
+	return fmt.Sprintf("%s (%s)", item.TargetDate.Format("2006-01-02"), weekdays[item.TargetDate.Weekday()])
+}
+
+func formatValue(metric models.Metric, v float64) string {
+	if metric == models.MetricMargin {
+		return fmt.Sprintf("%.1f%%", v)
+	}
+	return formatAmount(v)
+}
+
+// formatAmount formats an amount with thousand separators
+func formatAmount(v float64) string {
+	str := strconv.FormatInt(int64(math.Round(v)), 10)
+	sign := ""
+	if strings.HasPrefix(str, "-") {
+		sign, str = "-", str[1:]
+	}
+
+	var result strings.Builder
+	for i, digit := range str {
+		if i > 0 && (len(str)-i)%3 == 0 {
+			result.WriteString(",")
+		}
+		result.WriteRune(digit)
+	}
+	return sign + result.String()
+}
+
+// pad right-pads s to the given display width, accounting for wide characters
+func pad(s string, width int) string {
+	return runewidth.FillRight(runewidth.Truncate(s, width, "…"), width)
+}
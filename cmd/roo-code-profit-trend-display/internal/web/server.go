@@ -0,0 +1,267 @@
+package web
+
+import (
+	"context"
+	"embed"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"profit-trend-display/internal/calculator"
+	"profit-trend-display/internal/database"
+	"profit-trend-display/internal/models"
+)
+
+//go:embed static
+var staticFiles embed.FS
+
+const maxRangeDays = 366
+
+// Server serves the profit trend dashboard and its JSON/CSV API
+type Server struct {
+	repo        *database.ProfitRepository
+	calc        *calculator.ProfitCalculator
+	defaultDays int
+	mux         *http.ServeMux
+}
+
+// Query represents the filters accepted by the dashboard API
+type Query struct {
+	StartDate       time.Time
+	EndDate         time.Time
+	CompanyID       int
+	WarehouseBaseID int
+}
+
+// Option represents a selectable company or warehouse in the filters
+type Option struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// TrendsResponse is the JSON payload returned by /api/trends
+type TrendsResponse struct {
+	StartDate  string               `json:"start_date"`
+	EndDate    string               `json:"end_date"`
+	Companies  []Option             `json:"companies"`
+	Warehouses []Option             `json:"warehouses"`
+	Trends     []models.ProfitTrend `json:"trends"`
+}
+
+// NewServer creates a dashboard server
+func NewServer(repo *database.ProfitRepository, calc *calculator.ProfitCalculator, defaultDays int) (*Server, error) {
+	static, err := fs.Sub(staticFiles, "static")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load embedded assets: %w", err)
+	}
+
+	s := &Server{
+		repo:        repo,
+		calc:        calc,
+		defaultDays: defaultDays,
+		mux:         http.NewServeMux(),
+	}
+	s.mux.Handle("/", http.FileServer(http.FS(static)))
+	s.mux.HandleFunc("/api/trends", s.handleTrends)
+	s.mux.HandleFunc("/api/trends.csv", s.handleTrendsCSV)
+
+	return s, nil
+}
+
+// ServeHTTP implements http.Handler
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// handleTrends returns filtered profit trends as JSON
+func (s *Server) handleTrends(w http.ResponseWriter, r *http.Request) {
+	query, err := s.parseQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	companies, warehouses, err := s.options(r.Context())
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	trends, err := s.trends(r.Context(), query)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	response := TrendsResponse{
+		StartDate:  query.StartDate.Format("2006-01-02"),
+		EndDate:    query.EndDate.Format("2006-01-02"),
+		Companies:  companies,
+		Warehouses: warehouses,
+		Trends:     trends,
+	}
+	if response.Trends == nil {
+		response.Trends = []models.ProfitTrend{}
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Printf("レスポンス書き込みエラー: %v", err)
+	}
+}
+
+// handleTrendsCSV returns filtered daily profit rows as a CSV download
+func (s *Server) handleTrendsCSV(w http.ResponseWriter, r *http.Request) {
+	query, err := s.parseQuery(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	trends, err := s.trends(r.Context(), query)
+	if err != nil {
+		s.serverError(w, err)
+		return
+	}
+
+	filename := fmt.Sprintf("profit_trends_%s_to_%s.csv",
+		query.StartDate.Format("2006-01-02"), query.EndDate.Format("2006-01-02"))
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+	// BOM so that Excel opens the UTF-8 file with Japanese names correctly
+	w.Write([]byte("\xEF\xBB\xBF"))
+
+	writer := csv.NewWriter(w)
+	writer.Write([]string{"会社ID", "会社名", "倉庫ID", "倉庫名", "日付", "売上", "原価", "粗利", "粗利率(%)", "営業日", "祝日"})
+	for _, trend := range trends {
+		for _, item := range trend.Data {
+			businessDay := "0"
+			if item.IsBusinessDay {
+				businessDay = "1"
+			}
+			writer.Write([]string{
+				strconv.Itoa(item.CompanyID),
+				item.CompanyName,
+				strconv.Itoa(item.WarehouseBaseID),
+				item.WarehouseName,
+				item.TargetDate.Format("2006-01-02"),
+				fmt.Sprintf("%.3f", item.SalesAmount),
+				fmt.Sprintf("%.3f", item.CostAmount),
+				fmt.Sprintf("%.3f", item.ProfitAmount),
+				fmt.Sprintf("%.2f", item.Value(models.MetricMargin)),
+				businessDay,
+				item.HolidayName,
+			})
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		log.Printf("CSV書き込みエラー: %v", err)
+	}
+}
+
+// parseQuery reads start, end, company and warehouse parameters
+func (s *Server) parseQuery(r *http.Request) (Query, error) {
+	var query Query
+	values := r.URL.Query()
+
+	query.StartDate, query.EndDate = s.calc.GetDateRange(s.defaultDays)
+	if v := values.Get("start"); v != "" {
+		t, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			return query, fmt.Errorf("invalid start date: %s", v)
+		}
+		query.StartDate = t
+	}
+	if v := values.Get("end"); v != "" {
+		t, err := time.ParseInLocation("2006-01-02", v, time.Local)
+		if err != nil {
+			return query, fmt.Errorf("invalid end date: %s", v)
+		}
+		query.EndDate = t
+	}
+	if query.StartDate.After(query.EndDate) {
+		return query, fmt.Errorf("start date must be before or equal to end date")
+	}
+	if query.EndDate.Sub(query.StartDate) > maxRangeDays*24*time.Hour {
+		return query, fmt.Errorf("date range must be within %d days", maxRangeDays)
+	}
+
+	var err error
+	if v := values.Get("company"); v != "" {
+		if query.CompanyID, err = strconv.Atoi(v); err != nil {
+			return query, fmt.Errorf("invalid company id: %s", v)
+		}
+	}
+	if v := values.Get("warehouse"); v != "" {
+		if query.WarehouseBaseID, err = strconv.Atoi(v); err != nil {
+			return query, fmt.Errorf("invalid warehouse id: %s", v)
+		}
+	}
+
+	return query, nil
+}
+
+// trends loads the period and keeps only the trends matching the filters;
+// ctx is the request context so a closed connection cancels the query
+func (s *Server) trends(ctx context.Context, query Query) ([]models.ProfitTrend, error) {
+	data, err := s.repo.GetProfitTrendsForPeriod(ctx, query.StartDate, query.EndDate)
+	if err != nil {
+		return nil, err
+	}
+
+	var filtered []models.ProfitData
+	for _, item := range data {
+		if query.CompanyID > 0 && item.CompanyID != query.CompanyID {
+			continue
+		}
+		if query.WarehouseBaseID > 0 && item.WarehouseBaseID != query.WarehouseBaseID {
+			continue
+		}
+		filtered = append(filtered, item)
+	}
+
+	return s.calc.BuildTrends(filtered, query.StartDate, query.EndDate), nil
+}
+
+// options lists companies and warehouses for the filter dropdowns
+func (s *Server) options(ctx context.Context) ([]Option, []Option, error) {
+	pairs, err := s.repo.GetCompaniesWithWarehouses(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	companySet := make(map[int]string)
+	warehouseSet := make(map[int]string)
+	for _, entries := range pairs {
+		for _, entry := range entries {
+			companySet[entry.CompanyID] = entry.CompanyName
+			warehouseSet[entry.WarehouseBaseID] = entry.WarehouseName
+		}
+	}
+
+	return sortedOptions(companySet), sortedOptions(warehouseSet), nil
+}
+
+func (s *Server) serverError(w http.ResponseWriter, err error) {
+	log.Printf("データ取得エラー: %v", err)
+	http.Error(w, "internal server error", http.StatusInternalServerError)
+}
+
+func sortedOptions(set map[int]string) []Option {
+	options := make([]Option, 0, len(set))
+	for id, name := range set {
+		options = append(options, Option{ID: id, Name: name})
+	}
+	sort.Slice(options, func(i, j int) bool {
+		return options[i].ID < options[j].ID
+	})
+	return options
+}
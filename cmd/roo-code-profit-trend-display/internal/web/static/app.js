@@ -0,0 +1,272 @@
+(function () {
+  'use strict';
+
+  var SVG_NS = 'http://www.w3.org/2000/svg';
+  var WIDTH = 960;
+  var HEIGHT = 280;
+  var PADDING = { top: 16, right: 16, bottom: 32, left: 80 };
+  var COLORS = { sales: '#2e86de', cost: '#e67e22', profit: '#27ae60', loss: '#c0392b', holiday: '#e8e8e8' };
+
+  var form = document.getElementById('filters');
+  var statusLine = document.getElementById('status');
+  var optionsLoaded = false;
+
+  function formatAmount(v) {
+    return Math.round(v).toLocaleString('ja-JP');
+  }
+
+  function formatMargin(profit, sales) {
+    return sales === 0 ? '-' : (profit / sales * 100).toFixed(1) + '%';
+  }
+
+  function el(name, attrs, text) {
+    var node = document.createElementNS(SVG_NS, name);
+    Object.keys(attrs || {}).forEach(function (key) {
+      node.setAttribute(key, attrs[key]);
+    });
+    if (text !== undefined) {
+      node.textContent = text;
+    }
+    return node;
+  }
+
+  function queryString() {
+    var params = new URLSearchParams();
+    new FormData(form).forEach(function (value, key) {
+      if (value !== '') {
+        params.set(key, value);
+      }
+    });
+    return params.toString();
+  }
+
+  // aggregate sums all selected trends into one series per day
+  function aggregate(trends) {
+    var days = {};
+    var order = [];
+    trends.forEach(function (trend) {
+      trend.data.forEach(function (item) {
+        var date = item.target_date.slice(0, 10);
+        if (!days[date]) {
+          days[date] = {
+            date: date,
+            sales: 0,
+            cost: 0,
+            profit: 0,
+            businessDay: item.is_business_day,
+            holiday: item.holiday_name || ''
+          };
+          order.push(date);
+        }
+        days[date].sales += item.sales_amount;
+        days[date].cost += item.cost_amount;
+        days[date].profit += item.profit_amount;
+      });
+    });
+    order.sort();
+    return order.map(function (date) { return days[date]; });
+  }
+
+  // frame creates an SVG with holiday shading and a value axis
+  function frame(series, lo, hi) {
+    var svg = el('svg', { viewBox: '0 0 ' + WIDTH + ' ' + HEIGHT });
+    var plotWidth = WIDTH - PADDING.left - PADDING.right;
+    var plotHeight = HEIGHT - PADDING.top - PADDING.bottom;
+    var step = plotWidth / Math.max(series.length, 1);
+
+    var x = function (i) { return PADDING.left + step * i + step / 2; };
+    var y = function (v) { return PADDING.top + (hi - v) / (hi - lo) * plotHeight; };
+
+    series.forEach(function (day, i) {
+      if (!day.businessDay) {
+        var shade = el('rect', {
+          x: PADDING.left + step * i, y: PADDING.top,
+          width: step, height: plotHeight, fill: COLORS.holiday
+        });
+        shade.appendChild(el('title', {}, day.date + (day.holiday ? ' ' + day.holiday : '')));
+        svg.appendChild(shade);
+      }
+    });
+
+    for (var t = 0; t <= 4; t++) {
+      var v = lo + (hi - lo) * t / 4;
+      svg.appendChild(el('line', {
+        x1: PADDING.left, x2: WIDTH - PADDING.right, y1: y(v), y2: y(v), stroke: '#eee'
+      }));
+      svg.appendChild(el('text', { x: PADDING.left - 6, y: y(v) + 4, 'text-anchor': 'end' }, formatAmount(v)));
+    }
+    svg.appendChild(el('line', {
+      x1: PADDING.left, x2: WIDTH - PADDING.right, y1: y(0), y2: y(0), stroke: '#999'
+    }));
+
+    var labelEvery = Math.max(1, Math.ceil(series.length / 10));
+    series.forEach(function (day, i) {
+      if (i % labelEvery === 0 || i === series.length - 1) {
+        svg.appendChild(el('text', {
+          x: x(i), y: HEIGHT - PADDING.bottom + 16, 'text-anchor': 'middle'
+        }, day.date.slice(5).replace('-', '/')));
+      }
+    });
+
+    return { svg: svg, x: x, y: y, step: step };
+  }
+
+  function range(values) {
+    var lo = Math.min.apply(null, values.concat([0]));
+    var hi = Math.max.apply(null, values.concat([0]));
+    if (hi === lo) {
+      hi = lo + 1;
+    }
+    return [lo, hi];
+  }
+
+  function renderLineChart(container, series) {
+    container.textContent = '';
+    if (series.length === 0) {
+      return;
+    }
+
+    var values = [];
+    series.forEach(function (day) { values.push(day.sales, day.cost, day.profit); });
+    var bounds = range(values);
+    var f = frame(series, bounds[0], bounds[1]);
+
+    ['sales', 'cost', 'profit'].forEach(function (key) {
+      var points = series.map(function (day, i) { return f.x(i) + ',' + f.y(day[key]); }).join(' ');
+      f.svg.appendChild(el('polyline', {
+        points: points, fill: 'none', stroke: COLORS[key], 'stroke-width': 2
+      }));
+      series.forEach(function (day, i) {
+        var dot = el('circle', { cx: f.x(i), cy: f.y(day[key]), r: 2.5, fill: COLORS[key] });
+        dot.appendChild(el('title', {}, day.date + ' ' + formatAmount(day[key])));
+        f.svg.appendChild(dot);
+      });
+    });
+
+    container.appendChild(f.svg);
+  }
+
+  function renderBarChart(container, series) {
+    container.textContent = '';
+    if (series.length === 0) {
+      return;
+    }
+
+    var bounds = range(series.map(function (day) { return day.profit; }));
+    var f = frame(series, bounds[0], bounds[1]);
+    var barWidth = Math.max(1, f.step * 0.7);
+
+    series.forEach(function (day, i) {
+      var top = Math.min(f.y(day.profit), f.y(0));
+      var bar = el('rect', {
+        x: f.x(i) - barWidth / 2, y: top,
+        width: barWidth, height: Math.abs(f.y(day.profit) - f.y(0)),
+        fill: day.profit < 0 ? COLORS.loss : COLORS.profit
+      });
+      bar.appendChild(el('title', {}, day.date + ' 粗利 ' + formatAmount(day.profit) +
+        ' (粗利率 ' + formatMargin(day.profit, day.sales) + ')'));
+      f.svg.appendChild(bar);
+    });
+
+    container.appendChild(f.svg);
+  }
+
+  function renderCards(series) {
+    var total = { sales: 0, cost: 0, profit: 0 };
+    series.forEach(function (day) {
+      total.sales += day.sales;
+      total.cost += day.cost;
+      total.profit += day.profit;
+    });
+    document.getElementById('total-sales').textContent = formatAmount(total.sales);
+    document.getElementById('total-cost').textContent = formatAmount(total.cost);
+    document.getElementById('total-profit').textContent = formatAmount(total.profit);
+    document.getElementById('total-margin').textContent = formatMargin(total.profit, total.sales);
+  }
+
+  function renderTable(trends) {
+    var tbody = document.querySelector('#trend-table tbody');
+    tbody.textContent = '';
+
+    trends.forEach(function (trend) {
+      var sales = 0;
+      var cost = 0;
+      trend.data.forEach(function (item) {
+        sales += item.sales_amount;
+        cost += item.cost_amount;
+      });
+      var profit = trend.stats.total_profit;
+
+      var row = document.createElement('tr');
+      [
+        [trend.company_name, false],
+        [trend.warehouse_name, false],
+        [formatAmount(sales), true],
+        [formatAmount(cost), true],
+        [formatAmount(profit), true, profit < 0],
+        [formatMargin(profit, sales), true, profit < 0],
+        [formatAmount(trend.stats.avg_profit), true],
+        [formatAmount(trend.stats.business_day_avg_profit), true],
+        [formatAmount(trend.stats.non_business_day_avg_profit), true]
+      ].forEach(function (cell) {
+        var td = document.createElement('td');
+        td.textContent = cell[0];
+        if (cell[1]) {
+          td.className = 'number' + (cell[2] ? ' negative' : '');
+        }
+        row.appendChild(td);
+      });
+      tbody.appendChild(row);
+    });
+  }
+
+  function fillOptions(select, options) {
+    options.forEach(function (option) {
+      var node = document.createElement('option');
+      node.value = option.id;
+      node.textContent = option.name;
+      select.appendChild(node);
+    });
+  }
+
+  function load() {
+    var query = queryString();
+    document.getElementById('download').href = 'api/trends.csv' + (query ? '?' + query : '');
+    statusLine.textContent = '読み込み中...';
+
+    fetch('api/trends' + (query ? '?' + query : ''))
+      .then(function (res) {
+        if (!res.ok) {
+          return res.text().then(function (text) { throw new Error(text.trim()); });
+        }
+        return res.json();
+      })
+      .then(function (data) {
+        if (!optionsLoaded) {
+          fillOptions(document.getElementById('company'), data.companies);
+          fillOptions(document.getElementById('warehouse'), data.warehouses);
+          document.getElementById('start').value = data.start_date;
+          document.getElementById('end').value = data.end_date;
+          document.getElementById('download').href = 'api/trends.csv?' + queryString();
+          optionsLoaded = true;
+        }
+
+        var series = aggregate(data.trends);
+        renderCards(series);
+        renderLineChart(document.getElementById('line-chart'), series);
+        renderBarChart(document.getElementById('bar-chart'), series);
+        renderTable(data.trends);
+        statusLine.textContent = data.trends.length === 0 ? '指定された期間にデータが見つかりませんでした。' : '';
+      })
+      .catch(function (err) {
+        statusLine.textContent = 'エラー: ' + err.message;
+      });
+  }
+
+  form.addEventListener('submit', function (event) {
+    event.preventDefault();
+    load();
+  });
+
+  load();
+})();
@@ -1,12 +1,20 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"syscall"
+	"time"
+
+	"github.com/taka512/golang/pkg/calendar"
 
 	"profit-trend-display/internal/calculator"
 	"profit-trend-display/internal/chart"
@@ -16,22 +24,49 @@ import (
 )
 
 const (
-	defaultDSN  = "root:mypass@tcp(mysql.local:3306)/sample_mysql?parseTime=true"
-	defaultDays = 30
+	defaultDSN          = "root:mypass@tcp(mysql.local:3306)/sample_mysql?parseTime=true"
+	defaultDays         = 30
+	defaultTimeout      = 10 * time.Minute
+	defaultQueryTimeout = 2 * time.Minute
 )
 
 func main() {
+	// Subcommands
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "tui":
+			runTUI(os.Args[2:])
+			return
+		case "web":
+			runWeb(os.Args[2:])
+			return
+		case "daemon":
+			runDaemon(os.Args[2:])
+			return
+		}
+	}
+
 	// Command line flags
 	var (
-		dsn         = flag.String("dsn", defaultDSN, "Database connection string")
-		days        = flag.Int("days", defaultDays, "Number of days to analyze (default: 30)")
-		width       = flag.Int("width", 60, "Chart width (default: 60)")
-		height      = flag.Int("height", 15, "Chart height (default: 15)")
-		showGrid    = flag.Bool("grid", true, "Show grid lines (default: true)")
-		showStats   = flag.Bool("stats", true, "Show statistics (default: true)")
-		summaryOnly = flag.Bool("summary", false, "Show only summary (default: false)")
-		slackNotify = flag.Bool("slack", false, "Send notification to Slack (default: false)")
-		help        = flag.Bool("help", false, "Show help message")
+		dsn             = flag.String("dsn", defaultDSN, "Database connection string")
+		days            = flag.Int("days", defaultDays, "Number of days to analyze (default: 30)")
+		width           = flag.Int("width", 60, "Chart width (default: 60)")
+		height          = flag.Int("height", 15, "Chart height (default: 15)")
+		showGrid        = flag.Bool("grid", true, "Show grid lines (default: true)")
+		showStats       = flag.Bool("stats", true, "Show statistics (default: true)")
+		summaryOnly     = flag.Bool("summary", false, "Show only summary (default: false)")
+		slackNotify     = flag.Bool("slack", false, "Send notification to Slack (default: false)")
+		holidays        = flag.String("holidays", "", "Holiday CSV file to merge into the embedded calendar")
+		imageDir        = flag.String("image-dir", "", "Directory to write chart images to (disabled when empty)")
+		imageFormat     = flag.String("image-format", "png", "Chart image format: png or svg")
+		imageType       = flag.String("image-type", "line", "Chart image type: line, bar or stacked")
+		imageWidth      = flag.Int("image-width", 960, "Chart image width in pixels")
+		imageHeight     = flag.Int("image-height", 480, "Chart image height in pixels")
+		fontPath        = flag.String("font", "", "TrueType/OpenType font for Japanese labels in PNG images")
+		timeout         = flag.Duration("timeout", defaultTimeout, "Overall timeout (0: no limit)")
+		queryTimeout    = flag.Duration("query-timeout", defaultQueryTimeout, "Timeout of each database query (0: no limit)")
+		includeDisabled = flag.Bool("include-disabled", false, "Include reports of disabled account titles")
+		help            = flag.Bool("help", false, "Show help message")
 	)
 
 	flag.Parse()
@@ -56,11 +91,27 @@ func main() {
 		if slackHookURL != "" {
 			slackNotifier = notification.NewSlackNotifier(slackHookURL)
 			log.Println("Slack通知が有効です")
+
+			botToken, channelID := os.Getenv("SLACK_BOT_TOKEN"), os.Getenv("SLACK_CHANNEL_ID")
+			if botToken != "" && channelID != "" {
+				slackNotifier.EnableFileUpload(botToken, channelID)
+				log.Println("Slackへのグラフ画像添付が有効です")
+			}
 		} else {
 			log.Println("SLACK_HOOK環境変数が未設定のため、Slack通知を無効にします")
 		}
 	}
 
+	// Cancel queries on SIGINT/SIGTERM or when the overall timeout expires
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+	progress := &runProgress{}
+
 	fmt.Printf("=== 粗利推移表示プログラム ===\n")
 	fmt.Printf("分析期間: 過去%d日間\n", *days)
 	
@@ -74,8 +125,11 @@ func main() {
 	fmt.Println()
 
 	// Initialize database repository
-	repo, err := database.NewProfitRepository(*dsn)
+	repo, err := database.NewProfitRepository(ctx, *dsn, *queryTimeout, *includeDisabled)
 	if err != nil {
+		if ctx.Err() != nil {
+			progress.abort(ctx, err)
+		}
 		log.Printf("データベース接続エラー: %v", err)
 		
 		// Send error notification to Slack if enabled
@@ -89,8 +143,20 @@ func main() {
 	}
 	defer repo.Close()
 
+	// Initialize business-day calendar
+	cal, err := calendar.NewCalendar()
+	if err != nil {
+		log.Fatalf("祝日カレンダー読み込みエラー: %v", err)
+	}
+	if *holidays != "" {
+		if err := cal.LoadFile(*holidays); err != nil {
+			log.Fatalf("祝日ファイル読み込みエラー: %v", err)
+		}
+	}
+	warnCalendarRange(cal, time.Now().AddDate(0, 0, -*days), time.Now())
+
 	// Initialize calculator
-	calc := calculator.NewProfitCalculator()
+	calc := calculator.NewProfitCalculator(cal)
 
 	// Calculate date range
 	startDate, endDate := calc.GetDateRange(*days)
@@ -99,8 +165,11 @@ func main() {
 
 	// Fetch profit data
 	fmt.Println("データを取得中...")
-	profitData, err := repo.GetProfitTrendsForPeriod(startDate, endDate)
+	profitData, err := repo.GetProfitTrendsForPeriod(ctx, startDate, endDate)
 	if err != nil {
+		if ctx.Err() != nil {
+			progress.abort(ctx, err)
+		}
 		log.Printf("データ取得エラー: %v", err)
 		
 		// Send error notification to Slack if enabled
@@ -119,6 +188,7 @@ func main() {
 	}
 
 	fmt.Printf("取得データ数: %d件\n", len(profitData))
+	progress.done("データ取得")
 
 	// Group by company and warehouse
 	fmt.Println("データを分析中...")
@@ -171,15 +241,49 @@ func main() {
 		fmt.Println(strings.Repeat("=", 80))
 		fmt.Print(chartRenderer.RenderSummary(trends))
 	}
+	progress.done("推移表示")
+
+	// Write chart images if requested
+	var imagePaths []string
+	if *imageDir != "" {
+		imagePaths, err = writeChartImages(trends, *imageDir, *imageFormat, models.ChartConfig{
+			Type:        models.ChartType(*imageType),
+			ImageWidth:  *imageWidth,
+			ImageHeight: *imageHeight,
+			FontPath:    *fontPath,
+			ShowGrid:    *showGrid,
+			ShowStats:   *showStats,
+		})
+		if err != nil {
+			log.Printf("グラフ画像の出力に失敗しました: %v", err)
+		}
+		fmt.Printf("\nグラフ画像を出力しました (%d件):\n", len(imagePaths))
+		for _, path := range imagePaths {
+			fmt.Printf("  %s\n", path)
+		}
+		progress.done("グラフ画像出力")
+	}
 
 	// Send Slack notification if enabled
 	if slackNotifier != nil && slackNotifier.IsEnabled() {
+		if ctx.Err() != nil {
+			progress.abort(ctx, ctx.Err())
+		}
 		fmt.Println("\nSlack通知を送信中...")
 		if err := slackNotifier.SendProfitSummary(trends, *days); err != nil {
 			log.Printf("Slack通知送信に失敗しました: %v", err)
 		} else {
 			fmt.Println("Slack通知送信完了")
 		}
+
+		if len(imagePaths) > 0 && slackNotifier.CanUploadFiles() {
+			for _, path := range imagePaths {
+				if err := slackNotifier.UploadFile(path, filepath.Base(path), ""); err != nil {
+					log.Printf("Slackへのグラフ画像添付に失敗しました: %v", err)
+				}
+			}
+			fmt.Printf("グラフ画像を添付しました (%d件)\n", len(imagePaths))
+		}
 	} else if *slackNotify {
 		fmt.Println("\nSlack通知が無効のため、通知をスキップします")
 	}
@@ -192,6 +296,9 @@ func showHelp() {
 	fmt.Println()
 	fmt.Println("使用方法:")
 	fmt.Println("  profit-trend-display [オプション] [日数]")
+	fmt.Println("  profit-trend-display tui [オプション]   # 対話型ダッシュボード")
+	fmt.Println("  profit-trend-display web [オプション]   # ブラウザ向けダッシュボード")
+	fmt.Println("  profit-trend-display daemon [オプション] # ジョブ定義に従って定期実行")
 	fmt.Println()
 	fmt.Println("オプション:")
 	fmt.Println("  -dsn string       データベース接続文字列 (default: root:mypass@tcp(mysql.local:3306)/sample_mysql?parseTime=true)")
@@ -202,10 +309,22 @@ func showHelp() {
 	fmt.Println("  -stats            統計情報を表示 (default: true)")
 	fmt.Println("  -summary          サマリーのみ表示 (default: false)")
 	fmt.Println("  -slack            Slack通知を有効化 (default: false)")
+	fmt.Println("  -holidays string  祝日CSVファイル (YYYY-MM-DD,名称) を組み込みカレンダーに追加")
+	fmt.Println("  -image-dir string グラフ画像の出力先ディレクトリ (未指定時は出力しない)")
+	fmt.Println("  -image-format     グラフ画像の形式 png / svg (default: png)")
+	fmt.Println("  -image-type       グラフ画像の種類 line / bar / stacked (default: line)")
+	fmt.Println("  -image-width int  グラフ画像の幅 (px) (default: 960)")
+	fmt.Println("  -image-height int グラフ画像の高さ (px) (default: 480)")
+	fmt.Println("  -font string      PNG画像の日本語表示に使うフォントファイル (.ttf/.otf/.ttc)")
+	fmt.Println("  -timeout          全体のタイムアウト (default: 10m, 0: 無制限)")
+	fmt.Println("  -query-timeout    1回のクエリのタイムアウト (default: 2m, 0: 無制限)")
+	fmt.Println("  -include-disabled 無効化された科目の日報も集計に含める (default: false)")
 	fmt.Println("  -help             このヘルプを表示")
 	fmt.Println()
 	fmt.Println("環境変数:")
 	fmt.Println("  SLACK_HOOK        SlackのIncoming Webhook URL")
+	fmt.Println("  SLACK_BOT_TOKEN   グラフ画像添付に使うSlackボットトークン (files:write)")
+	fmt.Println("  SLACK_CHANNEL_ID  グラフ画像の添付先チャンネルID")
 	fmt.Println()
 	fmt.Println("例:")
 	fmt.Println("  profit-trend-display                    # デフォルト設定で実行")
@@ -214,6 +333,7 @@ func showHelp() {
 	fmt.Println("  profit-trend-display -slack             # Slack通知付きで実行")
 	fmt.Println("  profit-trend-display -slack -days 14 -summary  # 14日間サマリーをSlack通知")
 	fmt.Println("  profit-trend-display -width 80 -height 20      # グラフサイズ変更")
+	fmt.Println("  profit-trend-display -image-dir charts -image-type stacked  # グラフ画像を出力")
 	fmt.Println()
 	fmt.Println("機能:")
 	fmt.Println("  - 売上データと原価データから粗利を計算")
@@ -221,7 +341,34 @@ func showHelp() {
 	fmt.Println("  - テキストベースのグラフで推移を視覚化")
 	fmt.Println("  - 統計情報（最大・最小・平均・合計）を表示")
 	fmt.Println("  - 欠損日のデータは0として補完")
+	fmt.Println("  - 土日・祝日を除く営業日と休日の平均粗利を表示")
 	fmt.Println("  - Slack通知による結果共有")
+	fmt.Println("  - tuiサブコマンドによる対話型ダッシュボード（指標切替・期間変更・日別明細）")
+	fmt.Println("  - webサブコマンドによるブラウザ向けダッシュボード（SVGグラフ・絞り込み・CSVダウンロード）")
+	fmt.Println("  - PNG/SVGのグラフ画像出力とSlackへの添付")
+	fmt.Println("  - daemonサブコマンドによる常駐スケジューラ（cron式・タイムゾーン・実行履歴）")
+}
+
+// writeChartImages renders one chart image per trend into dir
+func writeChartImages(trends []models.ProfitTrend, dir, format string, config models.ChartConfig) ([]string, error) {
+	imageFormat, err := chart.ParseImageFormat(format)
+	if err != nil {
+		return nil, err
+	}
+
+	renderer, err := chart.NewImageRenderer(config)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderer.WriteFiles(dir, trends, imageFormat)
+}
+
+// warnCalendarRange warns when the period includes years without registered holidays
+func warnCalendarRange(cal *calendar.Calendar, start, end time.Time) {
+	if err := cal.CheckRange(start, end); err != nil {
+		log.Printf("警告: 祝日カレンダー: %v", err)
+	}
 }
 
 // maskPassword masks the password in DSN for display purposes
@@ -240,4 +387,36 @@ func maskPassword(dsn string) string {
 	}
 	
 	return dsn
-}
\ No newline at end of file
+}
+// Exit codes of an aborted run, following timeout(1) and the shell convention for SIGINT
+const (
+	exitTimeout     = 124
+	exitInterrupted = 130
+)
+
+// runProgress records the completed steps to report them when the run is aborted
+type runProgress struct {
+	steps []string
+}
+
+func (p *runProgress) done(step string) {
+	p.steps = append(p.steps, step)
+}
+
+// abort reports why the run stopped and which steps had completed, then exits
+func (p *runProgress) abort(ctx context.Context, err error) {
+	code := exitInterrupted
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		fmt.Fprintln(os.Stderr, "\nタイムアウトのため処理を中断しました")
+		code = exitTimeout
+	} else {
+		fmt.Fprintln(os.Stderr, "\n停止シグナルを受信したため処理を中断しました")
+	}
+	if len(p.steps) > 0 {
+		fmt.Fprintf(os.Stderr, "完了した処理: %s\n", strings.Join(p.steps, ", "))
+	} else {
+		fmt.Fprintln(os.Stderr, "完了した処理はありません")
+	}
+	fmt.Fprintf(os.Stderr, "原因: %v\n", err)
+	os.Exit(code)
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/taka512/golang/pkg/calendar"
+
+	"profit-trend-display/internal/calculator"
+	"profit-trend-display/internal/database"
+	"profit-trend-display/internal/models"
+	"profit-trend-display/internal/tui"
+)
+
+// runTUI starts the interactive terminal dashboard
+func runTUI(args []string) {
+	fs := flag.NewFlagSet("tui", flag.ExitOnError)
+	var (
+		dsn             = fs.String("dsn", defaultDSN, "Database connection string")
+		days            = fs.Int("days", defaultDays, "Number of days to display (default: 30)")
+		metric          = fs.String("metric", string(models.MetricProfit), "Initial metric: sales, cost, profit or margin")
+		holidays        = fs.String("holidays", "", "Holiday CSV file to merge into the embedded calendar")
+		queryTimeout    = fs.Duration("query-timeout", defaultQueryTimeout, "Timeout of each database query (0: no limit)")
+		includeDisabled = fs.Bool("include-disabled", false, "Include reports of disabled account titles")
+	)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "使用方法: profit-trend-display tui [オプション]")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	cal, err := calendar.NewCalendar()
+	if err != nil {
+		log.Fatalf("祝日カレンダー読み込みエラー: %v", err)
+	}
+	if *holidays != "" {
+		if err := cal.LoadFile(*holidays); err != nil {
+			log.Fatalf("祝日ファイル読み込みエラー: %v", err)
+		}
+	}
+	warnCalendarRange(cal, time.Now().AddDate(0, 0, -*days), time.Now())
+
+	// The dashboard handles Ctrl+C itself; SIGTERM cancels loading queries and quits
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM)
+	defer stop()
+
+	repo, err := database.NewProfitRepository(ctx, *dsn, *queryTimeout, *includeDisabled)
+	if err != nil {
+		log.Fatalf("データベース接続エラー: %v", err)
+	}
+	defer repo.Close()
+
+	opts := tui.Options{
+		Days:   *days,
+		Metric: models.Metric(*metric),
+	}
+	if err := tui.Run(ctx, repo, calculator.NewProfitCalculator(cal), opts); err != nil && ctx.Err() == nil {
+		log.Fatalf("ダッシュボードエラー: %v", err)
+	}
+}
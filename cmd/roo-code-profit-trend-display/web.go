@@ -0,0 +1,104 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/taka512/golang/pkg/calendar"
+
+	"profit-trend-display/internal/calculator"
+	"profit-trend-display/internal/database"
+	"profit-trend-display/internal/web"
+)
+
+// runWeb starts the HTML dashboard server
+func runWeb(args []string) {
+	fs := flag.NewFlagSet("web", flag.ExitOnError)
+	var (
+		dsn             = fs.String("dsn", defaultDSN, "Database connection string")
+		addr            = fs.String("addr", ":8080", "Listen address")
+		days            = fs.Int("days", defaultDays, "Default number of days to display (default: 30)")
+		holidays        = fs.String("holidays", "", "Holiday CSV file to merge into the embedded calendar")
+		queryTimeout    = fs.Duration("query-timeout", defaultQueryTimeout, "Timeout of each database query (0: no limit)")
+		includeDisabled = fs.Bool("include-disabled", false, "Include reports of disabled account titles")
+		shutdownTimeout = fs.Duration("shutdown-timeout", 30*time.Second, "Time to wait for in-flight requests on shutdown")
+	)
+	fs.Usage = func() {
+		fmt.Fprintln(os.Stderr, "使用方法: profit-trend-display web [オプション]")
+		fs.PrintDefaults()
+	}
+	fs.Parse(args)
+
+	cal, err := calendar.NewCalendar()
+	if err != nil {
+		log.Fatalf("祝日カレンダー読み込みエラー: %v", err)
+	}
+	if *holidays != "" {
+		if err := cal.LoadFile(*holidays); err != nil {
+			log.Fatalf("祝日ファイル読み込みエラー: %v", err)
+		}
+	}
+	warnCalendarRange(cal, time.Now().AddDate(0, 0, -*days), time.Now())
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	repo, err := database.NewProfitRepository(ctx, *dsn, *queryTimeout, *includeDisabled)
+	if err != nil {
+		log.Fatalf("データベース接続エラー: %v", err)
+	}
+	defer repo.Close()
+
+	handler, err := web.NewServer(repo, calculator.NewProfitCalculator(cal), *days)
+	if err != nil {
+		log.Fatalf("ダッシュボード初期化エラー: %v", err)
+	}
+
+	server := &http.Server{
+		Addr:              *addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+		WriteTimeout:      60 * time.Second,
+	}
+
+	serverErr := make(chan error, 1)
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			serverErr <- err
+		}
+		close(serverErr)
+	}()
+	log.Printf("ダッシュボードを起動しました: http://%s/", displayAddr(*addr))
+
+	select {
+	case err := <-serverErr:
+		if err != nil {
+			log.Fatalf("サーバーエラー: %v", err)
+		}
+	case <-ctx.Done():
+		log.Println("停止シグナルを受信しました。処理中のリクエストの完了を待機します...")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), *shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			log.Printf("サーバー停止エラー: %v", err)
+			return
+		}
+		log.Println("ダッシュボードを停止しました")
+	}
+}
+
+// displayAddr turns ":8080" into "localhost:8080" for the startup message
+func displayAddr(addr string) string {
+	if len(addr) > 0 && addr[0] == ':' {
+		return "localhost" + addr
+	}
+	return addr
+}
@@ -1,12 +1,18 @@
 package main
 
 import (
+	"context"
 	"database/sql"
 	"encoding/csv"
+	"errors"
+	"flag"
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
 	"strconv"
+	"strings"
+	"syscall"
 	"time"
 
 	_ "github.com/go-sql-driver/mysql"
@@ -29,11 +35,22 @@ func main() {
 	// Database connection string - adjust as needed
 	dsn := "root:mypass@tcp(mysql.local:3306)/sample_mysql?parseTime=true"
 	
+	format := flag.String("format", "csv", "Output format (csv or xlsx)")
+	output := flag.String("output", "", "Output file path (default: sale_cost_profit_report_<start>_to_<end>.<format>)")
+	timeout := flag.Duration("timeout", 10*time.Minute, "Overall timeout (0: no limit)")
+	queryTimeout := flag.Duration("query-timeout", 2*time.Minute, "Timeout for each database query (0: no limit)")
+	includeDisabled := flag.Bool("include-disabled", false, "Include reports of disabled account titles")
+	flag.Parse()
+
+	if *format != "csv" && *format != "xlsx" {
+		log.Fatalf("Invalid format: %s (must be csv or xlsx)", *format)
+	}
+
 	// Parse command line arguments for date range
 	var startDate, endDate string
-	if len(os.Args) >= 3 {
-		startDate = os.Args[1]
-		endDate = os.Args[2]
+	if args := flag.Args(); len(args) >= 2 {
+		startDate = args[0]
+		endDate = args[1]
 	} else {
 		// Default to current month
 		now := time.Now()
@@ -43,6 +60,16 @@ func main() {
 
 	fmt.Printf("Generating Sale Cost Profit Report from %s to %s\n", startDate, endDate)
 
+	// Cancel running queries on SIGINT/SIGTERM or when the overall timeout expires
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+	progress := &runProgress{}
+
 	db, err := sql.Open("mysql", dsn)
 	if err != nil {
 		log.Fatal("Failed to connect to database:", err)
@@ -50,19 +77,48 @@ func main() {
 	defer db.Close()
 
 	// Test database connection
-	if err := db.Ping(); err != nil {
+	if err := withQueryTimeout(ctx, *queryTimeout, db.PingContext); err != nil {
+		progress.exitIfCanceled(ctx, "database ping", err)
 		log.Fatal("Failed to ping database:", err)
 	}
 
-	reports, err := generateProfitReport(db, startDate, endDate)
+	var reports []SaleCostProfitReport
+	err = withQueryTimeout(ctx, *queryTimeout, func(ctx context.Context) (err error) {
+		reports, err = generateProfitReport(ctx, db, startDate, endDate, *includeDisabled)
+		return err
+	})
 	if err != nil {
+		progress.exitIfCanceled(ctx, "profit report query", err)
 		log.Fatal("Failed to generate report:", err)
 	}
+	progress.done("profit report query")
 
-	// Output to CSV file
-	filename := fmt.Sprintf("sale_cost_profit_report_%s_to_%s.csv", startDate, endDate)
-	if err := writeCSVReport(reports, filename); err != nil {
-		log.Fatal("Failed to write CSV report:", err)
+	filename := *output
+	if filename == "" {
+		filename = fmt.Sprintf("sale_cost_profit_report_%s_to_%s.%s", startDate, endDate, *format)
+	}
+
+	switch *format {
+	case "xlsx":
+		// Output to Excel workbook with account title breakdown
+		var titles []AccountTitleAmount
+		err := withQueryTimeout(ctx, *queryTimeout, func(ctx context.Context) (err error) {
+			titles, err = generateAccountTitleBreakdown(ctx, db, startDate, endDate, *includeDisabled)
+			return err
+		})
+		if err != nil {
+			progress.exitIfCanceled(ctx, "account title query", err)
+			log.Fatal("Failed to generate account title breakdown:", err)
+		}
+		progress.done("account title query")
+		if err := writeXLSXReport(reports, titles, startDate, endDate, filename); err != nil {
+			log.Fatal("Failed to write XLSX report:", err)
+		}
+	default:
+		// Output to CSV file
+		if err := writeCSVReport(reports, filename); err != nil {
+			log.Fatal("Failed to write CSV report:", err)
+		}
 	}
 
 	// Output summary to console
@@ -71,7 +127,61 @@ func main() {
 	fmt.Printf("Report saved to: %s\n", filename)
 }
 
-func generateProfitReport(db *sql.DB, startDate, endDate string) ([]SaleCostProfitReport, error) {
+// withQueryTimeout runs fn with timeout applied to a single query (0 or less means no limit)
+func withQueryTimeout(ctx context.Context, timeout time.Duration, fn func(ctx context.Context) error) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	return fn(ctx)
+}
+
+// runProgress records the finished steps so an interrupted run can report how far it got
+type runProgress struct {
+	steps []string
+}
+
+func (p *runProgress) done(step string) {
+	p.steps = append(p.steps, step)
+}
+
+// exitIfCanceled exits when err was caused by a signal or a timeout, printing the completed steps
+// (exit codes follow timeout(1): 124 on timeout, 130 on signal)
+func (p *runProgress) exitIfCanceled(ctx context.Context, step string, err error) {
+	code := 0
+	switch {
+	case ctx.Err() != nil && !errors.Is(ctx.Err(), context.DeadlineExceeded):
+		fmt.Fprintf(os.Stderr, "Interrupted by signal during %s: %v\n", step, err)
+		code = 130
+	case errors.Is(err, context.DeadlineExceeded):
+		fmt.Fprintf(os.Stderr, "Timed out during %s: %v\n", step, err)
+		code = 124
+	default:
+		return
+	}
+
+	if len(p.steps) > 0 {
+		fmt.Fprintf(os.Stderr, "Completed steps: %s\n", strings.Join(p.steps, ", "))
+	} else {
+		fmt.Fprintln(os.Stderr, "No steps completed")
+	}
+	fmt.Fprintln(os.Stderr, "No report file was written")
+	os.Exit(code)
+}
+
+// activeTitleCondition returns the condition that drops reports of disabled account titles
+// ("" when includeDisabled is set)
+func activeTitleCondition(includeDisabled bool, alias, titleColumn, titleTable string) string {
+	if includeDisabled {
+		return ""
+	}
+	return " AND " + alias + "." + titleColumn + " IN (SELECT id FROM " + titleTable + " WHERE disabled = 0)"
+}
+
+// generateProfitReport sums sales and cost per company, warehouse and day
+// (reports of disabled account titles are excluded unless includeDisabled is set)
+func generateProfitReport(ctx context.Context, db *sql.DB, startDate, endDate string, includeDisabled bool) ([]SaleCostProfitReport, error) {
 	query := `
 		SELECT 
 			c.id as company_id,
@@ -81,22 +191,28 @@ func generateProfitReport(db *sql.DB, startDate, endDate string) ([]SaleCostProf
 			DATE(COALESCE(sdr.target_date, cdr.target_date)) as target_date,
 			COALESCE(SUM(sdri.amount), 0) as sales_amount,
 			COALESCE(SUM(cdri.cost_amount), 0) as cost_amount
-		FROM companies c
-		CROSS JOIN warehouse_bases wb
+		FROM (
+			-- only company/warehouse pairs whose contract overlaps the period
+			SELECT DISTINCT company_id, warehouse_base_id
+			FROM company_warehouse_contracts
+			WHERE start_date <= ? AND (end_date IS NULL OR end_date >= ?)
+		) cwc
+		JOIN companies c ON c.id = cwc.company_id
+		JOIN warehouse_bases wb ON wb.id = cwc.warehouse_base_id
 		LEFT JOIN sales_daily_reports sdr ON c.id = sdr.company_id 
 			AND wb.id = sdr.warehouse_base_id 
-			AND sdr.target_date BETWEEN ? AND ?
+			AND sdr.target_date BETWEEN ? AND ?` + activeTitleCondition(includeDisabled, "sdr", "sales_account_title_id", "sales_account_titles") + `
 		LEFT JOIN sales_daily_report_items sdri ON sdr.id = sdri.sales_daily_report_id
 		LEFT JOIN cost_daily_reports cdr ON c.id = cdr.company_id 
 			AND wb.id = cdr.warehouse_base_id 
-			AND cdr.target_date BETWEEN ? AND ?
+			AND cdr.target_date BETWEEN ? AND ?` + activeTitleCondition(includeDisabled, "cdr", "cost_account_title_id", "cost_account_titles") + `
 		LEFT JOIN cost_daily_report_items cdri ON cdr.id = cdri.cost_daily_report_id
 		WHERE (sdr.target_date IS NOT NULL OR cdr.target_date IS NOT NULL)
 		GROUP BY c.id, c.name, wb.id, wb.name, DATE(COALESCE(sdr.target_date, cdr.target_date))
 		ORDER BY c.name, wb.name, DATE(COALESCE(sdr.target_date, cdr.target_date))
 	`
 
-	rows, err := db.Query(query, startDate, endDate, startDate, endDate)
+	rows, err := db.QueryContext(ctx, query, endDate, startDate, startDate, endDate, startDate, endDate)
 	if err != nil {
 		return nil, fmt.Errorf("failed to execute query: %w", err)
 	}
@@ -0,0 +1,584 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+const (
+	summarySheet      = "Summary"
+	dailySheet        = "Daily"
+	accountTitleSheet = "Account Titles"
+
+	// Yen with thousand separators, negative amounts in red
+	yenFormat  = `"¥"#,##0;[Red]"¥"-#,##0`
+	dateFormat = "yyyy-mm-dd"
+	// Built-in number format 10: 0.00%
+	percentFormat = 10
+
+	maxSheetNameLength = 31
+)
+
+// AccountTitleAmount represents the amount of one account title for a company, warehouse and date
+type AccountTitleAmount struct {
+	Kind            string // "Sales" or "Cost"
+	CompanyID       int
+	CompanyName     string
+	WarehouseBaseID int
+	WarehouseName   string
+	TargetDate      string
+	Code            string
+	Name            string
+	Amount          float64
+}
+
+// xlsxStyles holds the style IDs used in the workbook
+type xlsxStyles struct {
+	title, header, label, date, yen, percent int
+	totalLabel, totalYen, totalPercent       int
+}
+
+// companySheet describes a per-company sheet and where its total row is
+type companySheet struct {
+	CompanyID   int
+	CompanyName string
+	SheetName   string
+	Rows        []SaleCostProfitReport
+	TotalRow    int
+}
+
+// generateAccountTitleBreakdown fetches sales and cost amounts per account title
+// (disabled account titles are excluded unless includeDisabled is set)
+func generateAccountTitleBreakdown(ctx context.Context, db *sql.DB, startDate, endDate string, includeDisabled bool) ([]AccountTitleAmount, error) {
+	query := `
+		SELECT 'Sales', c.id, c.name, wb.id, wb.name, DATE(sdr.target_date),
+			sat.code, sat.name, COALESCE(SUM(sdri.amount), 0)
+		FROM sales_daily_reports sdr
+		INNER JOIN companies c ON c.id = sdr.company_id
+		INNER JOIN warehouse_bases wb ON wb.id = sdr.warehouse_base_id
+		INNER JOIN sales_account_titles sat ON sat.id = sdr.sales_account_title_id
+		LEFT JOIN sales_daily_report_items sdri ON sdr.id = sdri.sales_daily_report_id
+		WHERE sdr.target_date BETWEEN ? AND ?` + activeTitleCondition(includeDisabled, "sdr", "sales_account_title_id", "sales_account_titles") + `
+		GROUP BY c.id, c.name, wb.id, wb.name, DATE(sdr.target_date), sat.id, sat.code, sat.name
+		UNION ALL
+		SELECT 'Cost', c.id, c.name, wb.id, wb.name, DATE(cdr.target_date),
+			cat.code, cat.name, COALESCE(SUM(cdri.cost_amount), 0)
+		FROM cost_daily_reports cdr
+		INNER JOIN companies c ON c.id = cdr.company_id
+		INNER JOIN warehouse_bases wb ON wb.id = cdr.warehouse_base_id
+		INNER JOIN cost_account_titles cat ON cat.id = cdr.cost_account_title_id
+		LEFT JOIN cost_daily_report_items cdri ON cdr.id = cdri.cost_daily_report_id
+		WHERE cdr.target_date BETWEEN ? AND ?` + activeTitleCondition(includeDisabled, "cdr", "cost_account_title_id", "cost_account_titles") + `
+		GROUP BY c.id, c.name, wb.id, wb.name, DATE(cdr.target_date), cat.id, cat.code, cat.name
+	`
+
+	rows, err := db.QueryContext(ctx, query, startDate, endDate, startDate, endDate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute account title query: %w", err)
+	}
+	defer rows.Close()
+
+	var amounts []AccountTitleAmount
+	for rows.Next() {
+		var amount AccountTitleAmount
+		if err := rows.Scan(
+			&amount.Kind,
+			&amount.CompanyID,
+			&amount.CompanyName,
+			&amount.WarehouseBaseID,
+			&amount.WarehouseName,
+			&amount.TargetDate,
+			&amount.Code,
+			&amount.Name,
+			&amount.Amount,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan account title row: %w", err)
+		}
+		amounts = append(amounts, amount)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating account title rows: %w", err)
+	}
+
+	return amounts, nil
+}
+
+// writeXLSXReport writes a workbook with summary, daily, per-company and account title sheets
+func writeXLSXReport(reports []SaleCostProfitReport, titles []AccountTitleAmount, startDate, endDate, filename string) error {
+	f := excelize.NewFile()
+	defer f.Close()
+
+	st, err := newXLSXStyles(f)
+	if err != nil {
+		return err
+	}
+
+	if err := f.SetSheetName("Sheet1", summarySheet); err != nil {
+		return fmt.Errorf("failed to rename sheet: %w", err)
+	}
+
+	dailyTotalRow, err := writeDailySheet(f, st, reports)
+	if err != nil {
+		return err
+	}
+
+	companies := groupByCompany(reports)
+	for i := range companies {
+		if err := writeCompanySheet(f, st, &companies[i]); err != nil {
+			return err
+		}
+	}
+
+	if err := writeAccountTitleSheet(f, st, titles); err != nil {
+		return err
+	}
+
+	if err := writeSummarySheet(f, st, startDate, endDate, dailyTotalRow, companies); err != nil {
+		return err
+	}
+
+	f.SetActiveSheet(0)
+	if err := f.SaveAs(filename); err != nil {
+		return fmt.Errorf("failed to save workbook: %w", err)
+	}
+	return nil
+}
+
+func newXLSXStyles(f *excelize.File) (*xlsxStyles, error) {
+	yen := yenFormat
+	date := dateFormat
+	border := []excelize.Border{
+		{Type: "left", Color: "BFBFBF", Style: 1},
+		{Type: "right", Color: "BFBFBF", Style: 1},
+		{Type: "top", Color: "BFBFBF", Style: 1},
+		{Type: "bottom", Color: "BFBFBF", Style: 1},
+	}
+	totalFill := excelize.Fill{Type: "pattern", Color: []string{"F2F2F2"}, Pattern: 1}
+	bold := &excelize.Font{Bold: true}
+
+	st := &xlsxStyles{}
+	definitions := []struct {
+		target *int
+		style  *excelize.Style
+	}{
+		{&st.title, &excelize.Style{Font: &excelize.Font{Bold: true, Size: 14}}},
+		{&st.header, &excelize.Style{
+			Font:      bold,
+			Fill:      excelize.Fill{Type: "pattern", Color: []string{"DDEBF7"}, Pattern: 1},
+			Border:    border,
+			Alignment: &excelize.Alignment{Horizontal: "center"},
+		}},
+		{&st.label, &excelize.Style{Border: border}},
+		{&st.date, &excelize.Style{Border: border, CustomNumFmt: &date}},
+		{&st.yen, &excelize.Style{Border: border, CustomNumFmt: &yen}},
+		{&st.percent, &excelize.Style{Border: border, NumFmt: percentFormat}},
+		{&st.totalLabel, &excelize.Style{Border: border, Font: bold, Fill: totalFill}},
+		{&st.totalYen, &excelize.Style{Border: border, Font: bold, Fill: totalFill, CustomNumFmt: &yen}},
+		{&st.totalPercent, &excelize.Style{Border: border, Font: bold, Fill: totalFill, NumFmt: percentFormat}},
+	}
+
+	for _, def := range definitions {
+		id, err := f.NewStyle(def.style)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create style: %w", err)
+		}
+		*def.target = id
+	}
+
+	return st, nil
+}
+
+func writeSummarySheet(f *excelize.File, st *xlsxStyles, startDate, endDate string, dailyTotalRow int, companies []companySheet) error {
+	sheet := summarySheet
+	daily := sheetRef(dailySheet)
+
+	if err := setCell(f, sheet, "A1", "Sale Cost Profit Report", st.title); err != nil {
+		return err
+	}
+	if err := setCell(f, sheet, "A3", "Period", st.label); err != nil {
+		return err
+	}
+	if err := setCell(f, sheet, "B3", fmt.Sprintf("%s to %s", startDate, endDate), st.label); err != nil {
+		return err
+	}
+
+	if err := setRow(f, sheet, 5, st.header, "Total", "Amount"); err != nil {
+		return err
+	}
+	totals := []struct {
+		label   string
+		formula string
+		style   int
+	}{
+		{"Sales", fmt.Sprintf("%s!B%d", daily, dailyTotalRow), st.yen},
+		{"Costs", fmt.Sprintf("%s!C%d", daily, dailyTotalRow), st.yen},
+		{"Profit", "B6-B7", st.yen},
+		{"Profit Margin", "IF(B6=0,0,B8/B6)", st.percent},
+	}
+	for i, t := range totals {
+		row := 6 + i
+		if err := setCell(f, sheet, cellName(1, row), t.label, st.label); err != nil {
+			return err
+		}
+		if err := setFormula(f, sheet, cellName(2, row), t.formula, t.style); err != nil {
+			return err
+		}
+	}
+
+	// By company, referencing the total row of each company sheet
+	row := 11
+	if err := setRow(f, sheet, row, st.header, "Company", "Sales", "Costs", "Profit", "Profit Margin"); err != nil {
+		return err
+	}
+	first := row + 1
+	for _, company := range companies {
+		row++
+		ref := sheetRef(company.SheetName)
+		if err := setCell(f, sheet, cellName(1, row), company.CompanyName, st.label); err != nil {
+			return err
+		}
+		if err := setFormula(f, sheet, cellName(2, row), fmt.Sprintf("%s!C%d", ref, company.TotalRow), st.yen); err != nil {
+			return err
+		}
+		if err := setFormula(f, sheet, cellName(3, row), fmt.Sprintf("%s!D%d", ref, company.TotalRow), st.yen); err != nil {
+			return err
+		}
+		if err := setProfitFormulas(f, sheet, row, "B", "C", "D", "E", st.yen, st.percent); err != nil {
+			return err
+		}
+	}
+	if err := setTotalRow(f, st, sheet, row+1, first, row, "B", "C", "D", "E"); err != nil {
+		return err
+	}
+
+	return f.SetColWidth(sheet, "A", "E", 18)
+}
+
+// writeDailySheet writes one row per date across all companies and returns the total row
+func writeDailySheet(f *excelize.File, st *xlsxStyles, reports []SaleCostProfitReport) (int, error) {
+	sheet := dailySheet
+	if _, err := f.NewSheet(sheet); err != nil {
+		return 0, fmt.Errorf("failed to create sheet: %w", err)
+	}
+	if err := setRow(f, sheet, 1, st.header, "Target Date", "Sales Amount", "Cost Amount", "Profit Amount", "Profit Margin"); err != nil {
+		return 0, err
+	}
+
+	type dailyTotal struct{ sales, costs float64 }
+	totals := make(map[string]*dailyTotal)
+	var dates []string
+	for _, report := range reports {
+		t, ok := totals[report.TargetDate]
+		if !ok {
+			t = &dailyTotal{}
+			totals[report.TargetDate] = t
+			dates = append(dates, report.TargetDate)
+		}
+		t.sales += report.SalesAmount
+		t.costs += report.CostAmount
+	}
+	sort.Strings(dates)
+
+	row := 1
+	for _, date := range dates {
+		row++
+		if err := setDateCell(f, sheet, cellName(1, row), date, st); err != nil {
+			return 0, err
+		}
+		if err := setCell(f, sheet, cellName(2, row), totals[date].sales, st.yen); err != nil {
+			return 0, err
+		}
+		if err := setCell(f, sheet, cellName(3, row), totals[date].costs, st.yen); err != nil {
+			return 0, err
+		}
+		if err := setProfitFormulas(f, sheet, row, "B", "C", "D", "E", st.yen, st.percent); err != nil {
+			return 0, err
+		}
+	}
+
+	totalRow := row + 1
+	if err := setTotalRow(f, st, sheet, totalRow, 2, row, "B", "C", "D", "E"); err != nil {
+		return 0, err
+	}
+	if err := freezeHeader(f, sheet); err != nil {
+		return 0, err
+	}
+	return totalRow, f.SetColWidth(sheet, "A", "E", 16)
+}
+
+func writeCompanySheet(f *excelize.File, st *xlsxStyles, company *companySheet) error {
+	sheet := company.SheetName
+	if _, err := f.NewSheet(sheet); err != nil {
+		return fmt.Errorf("failed to create sheet: %w", err)
+	}
+	if err := setRow(f, sheet, 1, st.header, "Target Date", "Warehouse", "Sales Amount", "Cost Amount", "Profit Amount", "Profit Margin"); err != nil {
+		return err
+	}
+
+	row := 1
+	for _, report := range company.Rows {
+		row++
+		if err := setDateCell(f, sheet, cellName(1, row), report.TargetDate, st); err != nil {
+			return err
+		}
+		if err := setCell(f, sheet, cellName(2, row), report.WarehouseName, st.label); err != nil {
+			return err
+		}
+		if err := setCell(f, sheet, cellName(3, row), report.SalesAmount, st.yen); err != nil {
+			return err
+		}
+		if err := setCell(f, sheet, cellName(4, row), report.CostAmount, st.yen); err != nil {
+			return err
+		}
+		if err := setProfitFormulas(f, sheet, row, "C", "D", "E", "F", st.yen, st.percent); err != nil {
+			return err
+		}
+	}
+
+	company.TotalRow = row + 1
+	if err := setTotalRow(f, st, sheet, company.TotalRow, 2, row, "C", "D", "E", "F"); err != nil {
+		return err
+	}
+	if err := freezeHeader(f, sheet); err != nil {
+		return err
+	}
+	return f.SetColWidth(sheet, "A", "F", 16)
+}
+
+// writeAccountTitleSheet writes period totals per account title with their share within sales or costs
+func writeAccountTitleSheet(f *excelize.File, st *xlsxStyles, titles []AccountTitleAmount) error {
+	sheet := accountTitleSheet
+	if _, err := f.NewSheet(sheet); err != nil {
+		return fmt.Errorf("failed to create sheet: %w", err)
+	}
+	if err := setRow(f, sheet, 1, st.header, "Type", "Code", "Account Title", "Amount", "Share"); err != nil {
+		return err
+	}
+
+	type titleKey struct{ kind, code string }
+	totals := make(map[titleKey]*AccountTitleAmount)
+	var keys []titleKey
+	for _, t := range titles {
+		k := titleKey{t.Kind, t.Code}
+		total, ok := totals[k]
+		if !ok {
+			total = &AccountTitleAmount{Kind: t.Kind, Code: t.Code, Name: t.Name}
+			totals[k] = total
+			keys = append(keys, k)
+		}
+		total.Amount += t.Amount
+	}
+	// Sales first, then costs, each by code
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].kind != keys[j].kind {
+			return keys[i].kind == "Sales"
+		}
+		return keys[i].code < keys[j].code
+	})
+
+	last := len(keys) + 1
+	for i, k := range keys {
+		row := i + 2
+		total := totals[k]
+		for col, value := range []string{total.Kind, total.Code, total.Name} {
+			if err := setCell(f, sheet, cellName(col+1, row), value, st.label); err != nil {
+				return err
+			}
+		}
+		if err := setCell(f, sheet, cellName(4, row), total.Amount, st.yen); err != nil {
+			return err
+		}
+		share := fmt.Sprintf("IF(SUMIF($A$2:$A$%d,A%d,$D$2:$D$%d)=0,0,D%d/SUMIF($A$2:$A$%d,A%d,$D$2:$D$%d))",
+			last, row, last, row, last, row, last)
+		if err := setFormula(f, sheet, cellName(5, row), share, st.percent); err != nil {
+			return err
+		}
+	}
+
+	row := last + 1
+	for _, kind := range []string{"Sales", "Cost"} {
+		row++
+		if err := setRow(f, sheet, row, st.totalLabel, "Total "+kind, "", ""); err != nil {
+			return err
+		}
+		if err := setFormula(f, sheet, cellName(4, row), fmt.Sprintf(`SUMIF($A$2:$A$%d,"%s",$D$2:$D$%d)`, last, kind, last), st.totalYen); err != nil {
+			return err
+		}
+	}
+
+	if err := freezeHeader(f, sheet); err != nil {
+		return err
+	}
+	return f.SetColWidth(sheet, "A", "E", 18)
+}
+
+// groupByCompany splits report rows into one sheet per company, keeping the query order
+func groupByCompany(reports []SaleCostProfitReport) []companySheet {
+	var companies []companySheet
+	index := make(map[int]int)
+	used := map[string]bool{summarySheet: true, dailySheet: true, accountTitleSheet: true}
+
+	for _, report := range reports {
+		i, ok := index[report.CompanyID]
+		if !ok {
+			i = len(companies)
+			index[report.CompanyID] = i
+			companies = append(companies, companySheet{
+				CompanyID:   report.CompanyID,
+				CompanyName: report.CompanyName,
+				SheetName:   uniqueSheetName(report.CompanyName, used),
+			})
+		}
+		companies[i].Rows = append(companies[i].Rows, report)
+	}
+
+	for _, company := range companies {
+		sort.SliceStable(company.Rows, func(i, j int) bool {
+			return company.Rows[i].TargetDate < company.Rows[j].TargetDate
+		})
+	}
+	return companies
+}
+
+// setProfitFormulas sets profit (sales - cost) and profit margin formulas for a row
+func setProfitFormulas(f *excelize.File, sheet string, row int, salesCol, costCol, profitCol, marginCol string, yenStyle, percentStyle int) error {
+	profit := fmt.Sprintf("%s%d-%s%d", salesCol, row, costCol, row)
+	if err := setFormula(f, sheet, fmt.Sprintf("%s%d", profitCol, row), profit, yenStyle); err != nil {
+		return err
+	}
+	margin := fmt.Sprintf("IF(%s%d=0,0,%s%d/%s%d)", salesCol, row, profitCol, row, salesCol, row)
+	return setFormula(f, sheet, fmt.Sprintf("%s%d", marginCol, row), margin, percentStyle)
+}
+
+// setTotalRow writes a total row with SUM formulas over rows first..last
+func setTotalRow(f *excelize.File, st *xlsxStyles, sheet string, row, first, last int, salesCol, costCol, profitCol, marginCol string) error {
+	if err := setCell(f, sheet, cellName(1, row), "Total", st.totalLabel); err != nil {
+		return err
+	}
+
+	// Apply the total style to blank cells left of the amount columns
+	salesIndex, err := excelize.ColumnNameToNumber(salesCol)
+	if err != nil {
+		return fmt.Errorf("invalid column: %w", err)
+	}
+	for col := 2; col < salesIndex; col++ {
+		if err := setCell(f, sheet, cellName(col, row), "", st.totalLabel); err != nil {
+			return err
+		}
+	}
+
+	for _, col := range []string{salesCol, costCol} {
+		formula := "0"
+		if last >= first {
+			formula = fmt.Sprintf("SUM(%s%d:%s%d)", col, first, col, last)
+		}
+		if err := setFormula(f, sheet, fmt.Sprintf("%s%d", col, row), formula, st.totalYen); err != nil {
+			return err
+		}
+	}
+	return setProfitFormulas(f, sheet, row, salesCol, costCol, profitCol, marginCol, st.totalYen, st.totalPercent)
+}
+
+func setRow(f *excelize.File, sheet string, row, style int, values ...string) error {
+	for i, v := range values {
+		if err := setCell(f, sheet, cellName(i+1, row), v, style); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func setCell(f *excelize.File, sheet, cell string, value interface{}, style int) error {
+	if err := f.SetCellValue(sheet, cell, value); err != nil {
+		return fmt.Errorf("failed to set cell %s!%s: %w", sheet, cell, err)
+	}
+	if err := f.SetCellStyle(sheet, cell, cell, style); err != nil {
+		return fmt.Errorf("failed to set style %s!%s: %w", sheet, cell, err)
+	}
+	return nil
+}
+
+// setDateCell writes a YYYY-MM-DD string as an Excel date, falling back to text
+func setDateCell(f *excelize.File, sheet, cell, date string, st *xlsxStyles) error {
+	if t, err := parseReportDate(date); err == nil {
+		return setCell(f, sheet, cell, t, st.date)
+	}
+	return setCell(f, sheet, cell, date, st.label)
+}
+
+func setFormula(f *excelize.File, sheet, cell, formula string, style int) error {
+	if err := f.SetCellFormula(sheet, cell, formula); err != nil {
+		return fmt.Errorf("failed to set formula %s!%s: %w", sheet, cell, err)
+	}
+	if err := f.SetCellStyle(sheet, cell, cell, style); err != nil {
+		return fmt.Errorf("failed to set style %s!%s: %w", sheet, cell, err)
+	}
+	return nil
+}
+
+// freezeHeader keeps the header row visible while scrolling
+func freezeHeader(f *excelize.File, sheet string) error {
+	if err := f.SetPanes(sheet, &excelize.Panes{
+		Freeze:      true,
+		YSplit:      1,
+		TopLeftCell: "A2",
+		ActivePane:  "bottomLeft",
+	}); err != nil {
+		return fmt.Errorf("failed to freeze header: %w", err)
+	}
+	return nil
+}
+
+func cellName(col, row int) string {
+	name, _ := excelize.CoordinatesToCellName(col, row)
+	return name
+}
+
+// sheetRef quotes a sheet name for use in formulas
+func sheetRef(sheet string) string {
+	return "'" + strings.ReplaceAll(sheet, "'", "''") + "'"
+}
+
+// uniqueSheetName applies Excel's sheet name rules (31 characters, no []:*?/\) and avoids duplicates
+func uniqueSheetName(name string, used map[string]bool) string {
+	name = strings.NewReplacer(":", "_", "\\", "_", "/", "_", "?", "_", "*", "_", "[", "_", "]", "_").Replace(name)
+	name = strings.Trim(name, "'")
+	if name == "" {
+		name = "Company"
+	}
+
+	candidate := truncateRunes(name, maxSheetNameLength)
+	for i := 2; used[candidate]; i++ {
+		suffix := fmt.Sprintf(" (%d)", i)
+		candidate = truncateRunes(name, maxSheetNameLength-len(suffix)) + suffix
+	}
+	used[candidate] = true
+	return candidate
+}
+
+func truncateRunes(s string, n int) string {
+	runes := []rune(s)
+	if len(runes) > n {
+		return string(runes[:n])
+	}
+	return s
+}
+
+// parseReportDate parses a date scanned from MySQL, which is either YYYY-MM-DD or RFC3339 when parseTime is enabled
+func parseReportDate(date string) (time.Time, error) {
+	if t, err := time.Parse("2006-01-02", date); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, date)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+}
@@ -0,0 +1,21 @@
+CREATE TABLE `report_runs` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `company_id` int unsigned NOT NULL DEFAULT '0' COMMENT '会社ID (0: 全社)',
+  `company_name` varchar(255) NOT NULL COMMENT '会社名',
+  `warehouse_base_id` int unsigned NOT NULL DEFAULT '0' COMMENT '倉庫ID (0: 全倉庫)',
+  `warehouse_name` varchar(255) NOT NULL COMMENT '倉庫名',
+  `start_date` date NOT NULL COMMENT '開始日',
+  `end_date` date NOT NULL COMMENT '終了日',
+  `total_sales` decimal(15,3) NOT NULL DEFAULT '0.000' COMMENT '売上合計',
+  `total_cost` decimal(15,3) NOT NULL DEFAULT '0.000' COMMENT 'コスト合計',
+  `gross_profit` decimal(15,3) NOT NULL DEFAULT '0.000' COMMENT '粗利',
+  `gross_profit_rate` decimal(9,2) NOT NULL DEFAULT '0.00' COMMENT '粗利率 (%)',
+  `output_format` varchar(16) NOT NULL COMMENT '出力形式',
+  `destination` varchar(64) NOT NULL COMMENT '出力先',
+  `output_path` varchar(255) DEFAULT NULL COMMENT '出力ファイル',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_report_runs_target` (`company_id`,`warehouse_base_id`,`start_date`),
+  KEY `idx_report_runs_created_at` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='粗利レポート実行履歴'
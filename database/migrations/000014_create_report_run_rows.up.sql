@@ -0,0 +1,14 @@
+CREATE TABLE `report_run_rows` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `report_run_id` bigint unsigned NOT NULL COMMENT 'レポート実行ID',
+  `target_date` date NOT NULL COMMENT '対象日',
+  `sales` decimal(15,3) NOT NULL DEFAULT '0.000' COMMENT '売上',
+  `cost` decimal(15,3) NOT NULL DEFAULT '0.000' COMMENT 'コスト',
+  `gross_profit` decimal(15,3) NOT NULL DEFAULT '0.000' COMMENT '粗利',
+  `gross_profit_rate` decimal(9,2) NOT NULL DEFAULT '0.00' COMMENT '粗利率 (%)',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uniq_report_run_rows` (`report_run_id`,`target_date`),
+  CONSTRAINT `foreign_report_run_rows_report_run` FOREIGN KEY (`report_run_id`) REFERENCES `report_runs` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='粗利レポート実行履歴の日別明細'
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS `reported_daily_totals`;
@@ -0,0 +1,11 @@
+CREATE TABLE `reported_daily_totals` (
+  `company_id` int unsigned NOT NULL COMMENT '会社ID',
+  `warehouse_base_id` int unsigned NOT NULL COMMENT '倉庫ID',
+  `target_date` date NOT NULL COMMENT '対象日',
+  `sales` decimal(15,3) NOT NULL DEFAULT '0.000' COMMENT '報告済み売上',
+  `cost` decimal(15,3) NOT NULL DEFAULT '0.000' COMMENT '報告済みコスト',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`company_id`,`warehouse_base_id`,`target_date`),
+  KEY `idx_reported_daily_totals_date` (`target_date`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='報告済みの会社・倉庫・日別合計'
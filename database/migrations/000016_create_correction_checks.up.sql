@@ -0,0 +1,10 @@
+CREATE TABLE `correction_checks` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `checked_since` datetime NOT NULL COMMENT 'チェック対象の更新日時 (この日時より後)',
+  `checked_at` datetime NOT NULL COMMENT 'チェック日時',
+  `corrections` int unsigned NOT NULL DEFAULT '0' COMMENT '検出した修正件数',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_correction_checks_checked_at` (`checked_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='修正検出の実行履歴'
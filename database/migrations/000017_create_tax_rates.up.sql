@@ -0,0 +1,10 @@
+CREATE TABLE `tax_rates` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT,
+  `name` varchar(32) NOT NULL COMMENT '税率名',
+  `rate` decimal(5,4) NOT NULL COMMENT '税率 (0.1000 = 10%)',
+  `effective_from` date NOT NULL COMMENT '適用開始日',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uniq_tax_rates_effective_from` (`effective_from`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='消費税率'
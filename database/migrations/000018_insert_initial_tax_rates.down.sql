@@ -0,0 +1 @@
+delete from tax_rates;
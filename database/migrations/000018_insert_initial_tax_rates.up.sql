@@ -0,0 +1,5 @@
+INSERT INTO tax_rates (name, rate, effective_from)
+VALUES
+('消費税5%', 0.0500, '1997-04-01'),
+('消費税8%', 0.0800, '2014-04-01'),
+('消費税10%', 0.1000, '2019-10-01');
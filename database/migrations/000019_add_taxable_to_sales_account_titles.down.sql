@@ -0,0 +1 @@
+ALTER TABLE `sales_account_titles` DROP COLUMN `taxable`;
@@ -0,0 +1 @@
+ALTER TABLE `cost_account_titles` DROP COLUMN `taxable`;
@@ -0,0 +1,2 @@
+ALTER TABLE `cost_account_titles`
+  ADD COLUMN `taxable` tinyint(4) NOT NULL DEFAULT '1' COMMENT '課税区分 0:非課税 1:課税' AFTER `name`;
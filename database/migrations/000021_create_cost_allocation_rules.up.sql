@@ -0,0 +1,14 @@
+CREATE TABLE `cost_allocation_rules` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT,
+  `warehouse_base_id` int unsigned NOT NULL COMMENT '倉庫ID',
+  `cost_account_title_id` int unsigned NOT NULL COMMENT '原価科目ID',
+  `method` varchar(16) NOT NULL COMMENT '配賦方法 shipped_quantity:出荷数量比 sales:売上比 fixed:固定比率',
+  `disabled` tinyint(4) NOT NULL DEFAULT '0' COMMENT '無効フラグ 0:有効 1:無効',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uniq_cost_allocation_rules` (`warehouse_base_id`,`cost_account_title_id`),
+  KEY `idx_cost_allocation_rules_cost_account_title` (`cost_account_title_id`),
+  CONSTRAINT `foreign_cost_allocation_rules_warehouse_base` FOREIGN KEY (`warehouse_base_id`) REFERENCES `warehouse_bases` (`id`),
+  CONSTRAINT `foreign_cost_allocation_rules_cost_account_title` FOREIGN KEY (`cost_account_title_id`) REFERENCES `cost_account_titles` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='共通費配賦ルール'
@@ -0,0 +1,13 @@
+CREATE TABLE `cost_allocation_shares` (
+  `id` int unsigned NOT NULL AUTO_INCREMENT,
+  `cost_allocation_rule_id` int unsigned NOT NULL COMMENT '配賦ルールID',
+  `company_id` int unsigned NOT NULL COMMENT '会社ID',
+  `percentage` decimal(7,4) NOT NULL COMMENT '配賦比率 (%)',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uniq_cost_allocation_shares` (`cost_allocation_rule_id`,`company_id`),
+  KEY `idx_cost_allocation_shares_company` (`company_id`),
+  CONSTRAINT `foreign_cost_allocation_shares_rule` FOREIGN KEY (`cost_allocation_rule_id`) REFERENCES `cost_allocation_rules` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `foreign_cost_allocation_shares_company` FOREIGN KEY (`company_id`) REFERENCES `companies` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='共通費配賦の固定比率'
@@ -0,0 +1 @@
+ALTER TABLE `report_runs` DROP COLUMN `cost_allocated`;
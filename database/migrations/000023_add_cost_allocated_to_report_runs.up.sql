@@ -0,0 +1,2 @@
+ALTER TABLE `report_runs`
+  ADD COLUMN `cost_allocated` tinyint(4) NOT NULL DEFAULT '0' COMMENT '共通費配賦 0:未反映 1:反映済み' AFTER `gross_profit_rate`;
@@ -0,0 +1,12 @@
+CREATE TABLE `api_keys` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `name` varchar(255) NOT NULL COMMENT '利用者名',
+  `role` varchar(16) NOT NULL COMMENT '権限 admin:全社 manager:担当会社のみ',
+  `key_hash` char(64) NOT NULL COMMENT 'APIキーのSHA-256 (キー自体は保存しない)',
+  `last_used_at` datetime DEFAULT NULL COMMENT '最終利用日時',
+  `revoked_at` datetime DEFAULT NULL COMMENT '失効日時',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uniq_api_keys_key_hash` (`key_hash`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='粗利レポートAPIのAPIキー'
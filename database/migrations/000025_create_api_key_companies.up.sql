@@ -0,0 +1,12 @@
+CREATE TABLE `api_key_companies` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `api_key_id` bigint unsigned NOT NULL COMMENT 'APIキーID',
+  `company_id` int unsigned NOT NULL COMMENT '参照を許可する会社ID',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uniq_api_key_companies` (`api_key_id`,`company_id`),
+  KEY `idx_api_key_companies_company` (`company_id`),
+  CONSTRAINT `foreign_api_key_companies_api_key` FOREIGN KEY (`api_key_id`) REFERENCES `api_keys` (`id`) ON DELETE CASCADE,
+  CONSTRAINT `foreign_api_key_companies_company` FOREIGN KEY (`company_id`) REFERENCES `companies` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='APIキーごとの参照可能な会社'
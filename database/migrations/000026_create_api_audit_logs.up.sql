@@ -0,0 +1,16 @@
+CREATE TABLE `api_audit_logs` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `api_key_id` bigint unsigned NOT NULL DEFAULT '0' COMMENT 'APIキーID (0: 認証失敗)',
+  `principal_name` varchar(255) NOT NULL DEFAULT '' COMMENT '利用者名',
+  `method` varchar(8) NOT NULL COMMENT 'HTTPメソッド',
+  `path` varchar(255) NOT NULL COMMENT 'リクエストパス',
+  `query` varchar(1024) NOT NULL DEFAULT '' COMMENT 'クエリ文字列',
+  `company_id` int unsigned NOT NULL DEFAULT '0' COMMENT '指定された会社ID (0: 全社・指定なし)',
+  `status` smallint unsigned NOT NULL COMMENT 'HTTPステータス',
+  `remote_addr` varchar(64) NOT NULL DEFAULT '' COMMENT '接続元',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_api_audit_logs_api_key` (`api_key_id`,`created_at`),
+  KEY `idx_api_audit_logs_created_at` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='粗利レポートAPIの監査ログ'
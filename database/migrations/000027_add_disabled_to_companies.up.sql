@@ -0,0 +1,2 @@
+ALTER TABLE `companies`
+  ADD COLUMN `disabled` tinyint(4) NOT NULL DEFAULT '0' COMMENT '無効フラグ 0:有効 1:無効' AFTER `name`;
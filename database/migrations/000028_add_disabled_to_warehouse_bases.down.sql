@@ -0,0 +1 @@
+ALTER TABLE `warehouse_bases` DROP COLUMN `disabled`;
@@ -0,0 +1,14 @@
+CREATE TABLE `company_warehouse_contracts` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `company_id` int unsigned NOT NULL COMMENT '会社ID',
+  `warehouse_base_id` int unsigned NOT NULL COMMENT '倉庫ID',
+  `start_date` date NOT NULL COMMENT '契約開始日',
+  `end_date` date DEFAULT NULL COMMENT '契約終了日 (NULL: 終了日なし)',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uniq_company_warehouse_contracts` (`company_id`,`warehouse_base_id`,`start_date`),
+  KEY `idx_company_warehouse_contracts_warehouse_base` (`warehouse_base_id`,`start_date`),
+  CONSTRAINT `foreign_company_warehouse_contracts_company` FOREIGN KEY (`company_id`) REFERENCES `companies` (`id`),
+  CONSTRAINT `foreign_company_warehouse_contracts_warehouse_base` FOREIGN KEY (`warehouse_base_id`) REFERENCES `warehouse_bases` (`id`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='会社と倉庫の契約（契約期間内の組み合わせのみ集計・データ投入の対象とする）'
@@ -0,0 +1 @@
+delete from company_warehouse_contracts;
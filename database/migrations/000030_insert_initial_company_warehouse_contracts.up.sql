@@ -0,0 +1,13 @@
+INSERT INTO `company_warehouse_contracts` (`company_id`, `warehouse_base_id`, `start_date`)
+SELECT t.company_id, t.warehouse_base_id, MIN(t.start_date)
+FROM (
+  SELECT company_id, warehouse_base_id, target_date AS start_date FROM sales_daily_reports
+  UNION ALL
+  SELECT company_id, warehouse_base_id, target_date AS start_date FROM cost_daily_reports
+  UNION ALL
+  SELECT c.id, wb.id, '2024-01-01'
+  FROM companies c
+  INNER JOIN warehouse_bases wb
+    ON (c.code, wb.code) IN (('AK787','AAA'),('AK787','BBB'),('BB999','AAA'),('CC291','BBB'))
+) t
+GROUP BY t.company_id, t.warehouse_base_id;
@@ -0,0 +1,2 @@
+ALTER TABLE `api_keys`
+  MODIFY COLUMN `role` varchar(16) NOT NULL COMMENT '権限 admin:全社 manager:担当会社のみ ingest:担当会社の日次レポート登録のみ';
@@ -0,0 +1,12 @@
+CREATE TABLE `import_batches` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `source` varchar(255) NOT NULL COMMENT '取り込み元 (CSVファイル・api・data-insert など)',
+  `created_by` varchar(255) NOT NULL DEFAULT '' COMMENT '実行したAPIキーの利用者名 (CLI: 空)',
+  `report_count` int unsigned NOT NULL DEFAULT '0' COMMENT '作成・更新したレポート数',
+  `item_count` int unsigned NOT NULL DEFAULT '0' COMMENT '登録した明細数',
+  `rolled_back_at` datetime DEFAULT NULL COMMENT 'ロールバック日時 (NULL: 未ロールバック)',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_import_batches_created_at` (`created_at`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='日次レポートの取り込みバッチ'
@@ -0,0 +1,4 @@
+ALTER TABLE `sales_daily_reports`
+  DROP FOREIGN KEY `foreign_sales_daily_reports_import_batch`,
+  DROP KEY `idx_sales_daily_reports_import_batch`,
+  DROP COLUMN `import_batch_id`;
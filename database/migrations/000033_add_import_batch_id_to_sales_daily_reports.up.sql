@@ -0,0 +1,4 @@
+ALTER TABLE `sales_daily_reports`
+  ADD COLUMN `import_batch_id` bigint unsigned DEFAULT NULL COMMENT '最後に作成・更新した取り込みバッチID (NULL: バッチ導入前)' AFTER `sales_account_title_id`,
+  ADD KEY `idx_sales_daily_reports_import_batch` (`import_batch_id`),
+  ADD CONSTRAINT `foreign_sales_daily_reports_import_batch` FOREIGN KEY (`import_batch_id`) REFERENCES `import_batches` (`id`);
@@ -0,0 +1,4 @@
+ALTER TABLE `cost_daily_report_items`
+  DROP FOREIGN KEY `foreign_cost_daily_report_items_import_batch`,
+  DROP KEY `idx_cost_daily_report_items_import_batch`,
+  DROP COLUMN `import_batch_id`;
@@ -0,0 +1,4 @@
+ALTER TABLE `cost_daily_report_items`
+  ADD COLUMN `import_batch_id` bigint unsigned DEFAULT NULL COMMENT '登録した取り込みバッチID (NULL: バッチ導入前)' AFTER `cost_amount`,
+  ADD KEY `idx_cost_daily_report_items_import_batch` (`import_batch_id`),
+  ADD CONSTRAINT `foreign_cost_daily_report_items_import_batch` FOREIGN KEY (`import_batch_id`) REFERENCES `import_batches` (`id`);
@@ -0,0 +1,14 @@
+CREATE TABLE `import_batch_reports` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `import_batch_id` bigint unsigned NOT NULL COMMENT '取り込みバッチID',
+  `kind` varchar(8) NOT NULL COMMENT 'レポート種別 sales:売上 cost:コスト',
+  `report_id` bigint unsigned NOT NULL COMMENT '日次売上・原価レポートID',
+  `created` tinyint(4) NOT NULL DEFAULT '0' COMMENT '1:バッチで作成 0:既存のレポートの明細を置き換え',
+  `previous_import_batch_id` bigint unsigned DEFAULT NULL COMMENT '置き換え前の取り込みバッチID',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uniq_import_batch_reports` (`import_batch_id`,`kind`,`report_id`),
+  KEY `idx_import_batch_reports_report` (`kind`,`report_id`),
+  CONSTRAINT `foreign_import_batch_reports_import_batch` FOREIGN KEY (`import_batch_id`) REFERENCES `import_batches` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='取り込みバッチが作成・更新したレポート'
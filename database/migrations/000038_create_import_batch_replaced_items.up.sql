@@ -0,0 +1,19 @@
+CREATE TABLE `import_batch_replaced_items` (
+  `id` bigint unsigned NOT NULL AUTO_INCREMENT,
+  `import_batch_id` bigint unsigned NOT NULL COMMENT '明細を置き換えた取り込みバッチID',
+  `kind` varchar(8) NOT NULL COMMENT 'レポート種別 sales:売上 cost:コスト',
+  `report_id` bigint unsigned NOT NULL COMMENT '日次売上・原価レポートID',
+  `item_id` bigint unsigned NOT NULL COMMENT '置き換え前の明細ID',
+  `item_import_batch_id` bigint unsigned DEFAULT NULL COMMENT '置き換え前の明細の取り込みバッチID',
+  `size` varchar(16) DEFAULT NULL COMMENT 'サイズ',
+  `quantity` int NOT NULL DEFAULT '0' COMMENT '数量',
+  `price` decimal(9,3) NOT NULL DEFAULT '0.000' COMMENT '単価',
+  `amount` decimal(13,3) NOT NULL DEFAULT '0.000' COMMENT '金額',
+  `item_created_at` datetime NOT NULL COMMENT '置き換え前の明細の作成日時',
+  `item_updated_at` datetime NOT NULL COMMENT '置き換え前の明細の更新日時',
+  `created_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP,
+  `updated_at` datetime NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+  PRIMARY KEY (`id`),
+  KEY `idx_import_batch_replaced_items_report` (`import_batch_id`,`kind`,`report_id`),
+  CONSTRAINT `foreign_import_batch_replaced_items_import_batch` FOREIGN KEY (`import_batch_id`) REFERENCES `import_batches` (`id`) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COMMENT='取り込みバッチが置き換えた明細（ロールバック時に復元する）'
@@ -0,0 +1,146 @@
+// Package calendar classifies dates into business and non-business days
+// using weekends and Japanese national holidays. It is shared by the report
+// tools under cmd/ (each imports it through a replace directive in go.mod),
+// so the embedded holidays.csv is the only copy of the holiday table.
+package calendar
+
+import (
+	"bufio"
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// embeddedHolidays is the holiday table built into every tool.
+// See holidays.csv for how to add a new year.
+//
+//go:embed holidays.csv
+var embeddedHolidays []byte
+
+// dateLayouts lists accepted date formats for holiday files.
+// The slash form matches the CSV published by the Cabinet Office.
+var dateLayouts = []string{"2006-01-02", "2006/1/2"}
+
+// Calendar classifies dates into business and non-business days
+// using weekends and Japanese national holidays
+type Calendar struct {
+	holidays map[string]string
+	// years with registered holidays; other years cannot be classified
+	years map[int]bool
+}
+
+// NewCalendar creates a calendar populated with the embedded holiday list
+func NewCalendar() (*Calendar, error) {
+	c := &Calendar{holidays: make(map[string]string), years: make(map[int]bool)}
+	if err := c.load(bytes.NewReader(embeddedHolidays)); err != nil {
+		return nil, fmt.Errorf("failed to load embedded holidays: %w", err)
+	}
+	return c, nil
+}
+
+// LoadFile merges holidays from a local CSV file into the calendar.
+// Entries in the file override embedded entries for the same date.
+func (c *Calendar) LoadFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("failed to open holiday file: %w", err)
+	}
+	defer f.Close()
+
+	if err := c.load(f); err != nil {
+		return fmt.Errorf("failed to load holiday file %s: %w", path, err)
+	}
+	return nil
+}
+
+// load parses "date,name" lines. Blank lines, '#' comments and a
+// non-date header line are skipped.
+func (c *Calendar) load(r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.SplitN(line, ",", 2)
+		date, ok := parseDate(fields[0])
+		if !ok {
+			if lineNo == 1 {
+				continue
+			}
+			return fmt.Errorf("line %d: invalid date %q", lineNo, fields[0])
+		}
+
+		name := "休日"
+		if len(fields) == 2 && strings.TrimSpace(fields[1]) != "" {
+			name = strings.TrimSpace(fields[1])
+		}
+		c.holidays[date.Format("2006-01-02")] = name
+		c.years[date.Year()] = true
+	}
+	return scanner.Err()
+}
+
+// HolidayName returns the holiday name for the date, if any
+func (c *Calendar) HolidayName(date time.Time) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	name, ok := c.holidays[date.Format("2006-01-02")]
+	return name, ok
+}
+
+// IsHoliday reports whether the date is a national holiday
+func (c *Calendar) IsHoliday(date time.Time) bool {
+	_, ok := c.HolidayName(date)
+	return ok
+}
+
+// IsBusinessDay reports whether the date is neither a weekend nor a holiday
+func (c *Calendar) IsBusinessDay(date time.Time) bool {
+	switch date.Weekday() {
+	case time.Saturday, time.Sunday:
+		return false
+	}
+	return !c.IsHoliday(date)
+}
+
+// CheckRange returns an error listing the years between start and end that
+// have no registered holidays. Weekdays in those years are treated as
+// business days, so callers should warn before using the calendar.
+func (c *Calendar) CheckRange(start, end time.Time) error {
+	var missing []string
+	for year := start.Year(); year <= end.Year(); year++ {
+		if !c.years[year] {
+			missing = append(missing, strconv.Itoa(year))
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return fmt.Errorf("no holidays registered for %s; weekdays are treated as business days (pass a holiday file with -holidays)",
+		strings.Join(missing, ", "))
+}
+
+// Len returns the number of holidays registered in the calendar
+func (c *Calendar) Len() int {
+	return len(c.holidays)
+}
+
+func parseDate(s string) (time.Time, bool) {
+	s = strings.TrimSpace(s)
+	for _, layout := range dateLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
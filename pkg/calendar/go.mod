@@ -0,0 +1,3 @@
+module github.com/taka512/golang/pkg/calendar
+
+go 1.21